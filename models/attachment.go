@@ -0,0 +1,26 @@
+package models
+
+const (
+	AttachmentRelationshipSource      = "Source"
+	AttachmentRelationshipData        = "Data"
+	AttachmentRelationshipAlternative = "Alternative"
+	AttachmentRelationshipSupplement  = "Supplement"
+	AttachmentRelationshipUnspecified = "Unspecified"
+)
+
+type Attachment struct {
+	// file name of the embedded file
+	Name        string `json:"name" example:"factur-x.xml"`
+	Description string `json:"description,omitempty" example:"Factur-X invoice"`
+	// mime type of the embedded file; detected by file extension if empty
+	MimeType string `json:"mimeType,omitempty" example:"text/xml"`
+	// relationship of the embedded file to the document (PDF/A-3 AFRelationship); derived from the e-invoice profile if empty
+	Relationship string `json:"relationship,omitempty" enums:"Source,Data,Alternative,Supplement,Unspecified"`
+
+	// base64 encoded file content
+	Content []byte `json:"content,omitempty" swaggertype:"string" format:"base64"`
+	// path of the file inside of the bundle used as content (only for bundles)
+	BundleFile string `json:"bundleFile,omitempty" example:"factur-x.xml"`
+	// true if the content should be rendered with the template engine and model before embedding
+	IsTemplate bool `json:"isTemplate,omitempty" default:"false"`
+} // @name Attachment
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"math"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/utils"
+)
+
+type RenderOptionsAutoMargins struct {
+	// minimum margin top and bottom in mm
+	Min int `json:"min,omitempty" default:"10"`
+	// maximum margin top and bottom in mm
+	Max int `json:"max,omitempty" default:"100"`
+	// space between header or footer and the body in mm
+	Spacing int `json:"spacing,omitempty" default:"5"`
+} // @name RenderOptionsAutoMargins
+
+func (a *RenderOptionsAutoMargins) SetDefaults() {
+	utils.ReflectDefaultValues(a)
+}
+
+// MarginForHeight returns the margin in mm for a header or footer with the measured height in mm
+func (a *RenderOptionsAutoMargins) MarginForHeight(heightInMm float64) int {
+	if heightInMm <= 0 {
+		return a.Min
+	}
+
+	margin := int(math.Ceil(heightInMm)) + a.Spacing
+
+	return max(a.Min, min(a.Max, margin))
+}
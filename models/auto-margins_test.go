@@ -0,0 +1,29 @@
+package models
+
+import (
+	"fmt"
+	"testing"
+)
+
+var marginForHeightTestData = []struct {
+	height float64
+	margin int
+}{
+	{0, 10},
+	{2, 10},
+	{20.2, 26},
+	{200, 100},
+}
+
+func TestMarginForHeight(t *testing.T) {
+	autoMargins := &RenderOptionsAutoMargins{}
+	autoMargins.SetDefaults()
+
+	for _, d := range marginForHeightTestData {
+		t.Run(fmt.Sprintf("height %v mm", d.height), func(t *testing.T) {
+			if margin := autoMargins.MarginForHeight(d.height); margin != d.margin {
+				t.Fatalf("margin should be %d (curr: %d)", d.margin, margin)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package models
+
+type RenderOptionsBlankPages struct {
+	// add a blank page at the end if the page count is odd (for sections: every section ends on an even page)
+	EvenPageCount bool `json:"evenPageCount,omitempty"`
+	// optional html on the inserted blank pages
+	Html string `json:"html,omitempty" example:"<p>This page intentionally left blank</p>"`
+
+	// ids of the elements starting on an odd page (set while rendering)
+	OddPageStarts []string `json:"-"`
+} // @name RenderOptionsBlankPages
+
+// GetBlankPagePositions returns the ids of the elements which need a blank page before to start on an odd page
+// and if a blank page is needed at the end. pageOffset is the count of the pages before in the merged document.
+func (b *RenderOptionsBlankPages) GetBlankPagePositions(pageCount int, destinationPages map[string]int, pageOffset int) (beforeIds []string, atEnd bool) {
+	inserted := 0
+	lastPage := 0
+
+	// the ids are in document order, so the pages are ascending
+	for _, id := range b.OddPageStarts {
+		pageNr, ok := destinationPages[id]
+		if !ok || pageNr == lastPage {
+			continue
+		}
+		lastPage = pageNr
+
+		if (pageOffset+pageNr+inserted)%2 == 0 {
+			beforeIds = append(beforeIds, id)
+			inserted++
+		}
+	}
+
+	atEnd = b.EvenPageCount && (pageOffset+pageCount+inserted)%2 == 1
+
+	return beforeIds, atEnd
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetBlankPagePositions(t *testing.T) {
+	b := &RenderOptionsBlankPages{
+		EvenPageCount: true,
+		OddPageStarts: []string{"chapter-1", "chapter-1-b", "chapter-2", "chapter-3", "hidden"},
+	}
+
+	// the blank page before chapter 1 moves chapter 2 and 3 to odd pages
+	beforeIds, atEnd := b.GetBlankPagePositions(6, map[string]int{"chapter-1": 2, "chapter-1-b": 2, "chapter-2": 4, "chapter-3": 5}, 0)
+
+	if !reflect.DeepEqual(beforeIds, []string{"chapter-1", "chapter-3"}) {
+		t.Fatalf("unexpected blank pages (curr: %v)", beforeIds)
+	}
+
+	if atEnd {
+		t.Fatal("8 pages should not get a blank page at the end")
+	}
+}
+
+func TestGetBlankPagePositionsWithOffset(t *testing.T) {
+	b := &RenderOptionsBlankPages{EvenPageCount: true, OddPageStarts: []string{"chapter"}}
+
+	// the section starts on page 4 and ends on page 7 with the blank page
+	beforeIds, atEnd := b.GetBlankPagePositions(3, map[string]int{"chapter": 1}, 3)
+
+	if !reflect.DeepEqual(beforeIds, []string{"chapter"}) || !atEnd {
+		t.Fatalf("section after 3 pages should start and end with a blank page (curr: %v, %v)", beforeIds, atEnd)
+	}
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+type RenderOptionsDeterministic struct {
+	// date of the document in RFC 3339 format; 1970-01-01T00:00:00Z if empty
+	Timestamp string `json:"timestamp,omitempty" example:"2024-01-01T00:00:00Z"`
+} // @name RenderOptionsDeterministic
+
+// GetTimestamp returns the parsed timestamp or the unix epoch
+func (d *RenderOptionsDeterministic) GetTimestamp() (time.Time, error) {
+	if d.Timestamp == "" {
+		return time.Unix(0, 0).UTC(), nil
+	}
+
+	t, err := time.Parse(time.RFC3339, d.Timestamp)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid deterministic timestamp '%s': %w", d.Timestamp, err)
+	}
+
+	return t, nil
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetTimestamp(t *testing.T) {
+	d := &RenderOptionsDeterministic{}
+
+	if ts, err := d.GetTimestamp(); err != nil || !ts.Equal(time.Unix(0, 0)) {
+		t.Fatalf("timestamp should fallback to the unix epoch (curr: %v, err: %v)", ts, err)
+	}
+
+	d.Timestamp = "2024-03-01T12:30:00+01:00"
+
+	if ts, err := d.GetTimestamp(); err != nil || ts.UTC().Hour() != 11 {
+		t.Fatalf("timestamp should be parsed (curr: %v, err: %v)", ts, err)
+	}
+
+	d.Timestamp = "yesterday"
+
+	if _, err := d.GetTimestamp(); err == nil {
+		t.Fatal("invalid timestamp should fail")
+	}
+}
@@ -0,0 +1,10 @@
+package dto
+
+import "github.com/lucas-gaitzsch/pdf-turtle/models"
+
+// ModelValidationError is returned with status 422 if the model does not match the JSON Schema
+type ModelValidationError struct {
+	Msg        string                  `json:"msg"`
+	Violations []models.ModelViolation `json:"violations"`
+	RequestId  string                  `json:"requestId"`
+} // @name ModelValidationError
@@ -0,0 +1,21 @@
+package dto
+
+import "github.com/lucas-gaitzsch/pdf-turtle/models"
+
+type RenderResult struct {
+	// base64 encoded pdf
+	Pdf []byte `json:"pdf" swaggertype:"string" format:"base64"`
+	// size of the pdf in bytes
+	Size      int               `json:"size"`
+	PageCount int               `json:"pageCount"`
+	PageSizes []models.PageSize `json:"pageSizes"`
+	// time since the request was received in ms
+	RenderDurationMs int64         `json:"renderDurationMs"`
+	Stages           []RenderStage `json:"stages"`
+	Warnings         []string      `json:"warnings"`
+} // @name RenderResult
+
+type RenderStage struct {
+	Name       string `json:"name" example:"render pdf"`
+	DurationMs int64  `json:"durationMs"`
+} // @name RenderStage
@@ -1,8 +1,16 @@
 package dto
 
+import "github.com/lucas-gaitzsch/pdf-turtle/models"
+
 type TemplateTestResult struct {
-	IsValid             bool    `json:"isValid"`
-	BodyTemplateError   *string `json:"bodyTemplateError"`
+	// false if there is any diagnostic (including warnings for missing model values)
+	IsValid bool `json:"isValid"`
+	// message of the first diagnostic of the body
+	BodyTemplateError *string `json:"bodyTemplateError"`
+	// message of the first diagnostic of the header or its page variants
 	HeaderTemplateError *string `json:"headerTemplateError"`
+	// message of the first diagnostic of the footer or its page variants
 	FooterTemplateError *string `json:"footerTemplateError"`
+	// all issues of the templates with position and source excerpt
+	Diagnostics []models.TemplateDiagnostic `json:"diagnostics"`
 } // @name TemplateTestResult
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/utils"
+)
+
+const (
+	EInvoiceProfileMinimum   = "MINIMUM"
+	EInvoiceProfileBasicWl   = "BASIC WL"
+	EInvoiceProfileBasic     = "BASIC"
+	EInvoiceProfileEn16931   = "EN 16931"
+	EInvoiceProfileExtended  = "EXTENDED"
+	EInvoiceProfileXRechnung = "XRECHNUNG"
+)
+
+type RenderOptionsEInvoice struct {
+	// file name of the attached invoice xml
+	DocumentFileName string `json:"documentFileName,omitempty" default:"factur-x.xml"`
+	DocumentType     string `json:"documentType,omitempty" default:"INVOICE" enums:"INVOICE,ORDER,ORDER_RESPONSE,ORDER_CHANGE"`
+	// version of the Factur-X / ZUGFeRD xmp schema
+	Version string `json:"version,omitempty" default:"1.0"`
+	// conformance level (profile) of the invoice xml
+	ConformanceLevel string `json:"conformanceLevel,omitempty" default:"EN 16931" enums:"MINIMUM,BASIC WL,BASIC,EN 16931,EXTENDED,XRECHNUNG"`
+} // @name RenderOptionsEInvoice
+
+func (e *RenderOptionsEInvoice) SetDefaults() {
+	utils.ReflectDefaultValues(e)
+}
+
+// Relationship returns the AFRelationship of the invoice xml required by the conformance level.
+// The profiles MINIMUM and BASIC WL are no valid invoices on their own and only provide data.
+func (e *RenderOptionsEInvoice) Relationship() string {
+	switch strings.ToUpper(e.ConformanceLevel) {
+	case EInvoiceProfileMinimum, EInvoiceProfileBasicWl:
+		return AttachmentRelationshipData
+	default:
+		return AttachmentRelationshipAlternative
+	}
+}
@@ -0,0 +1,130 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/utils"
+)
+
+const (
+	ImpositionLayoutGrid    = "grid"
+	ImpositionLayoutBooklet = "booklet"
+)
+
+type RenderOptionsImposition struct {
+	// grid places the pages row by row; booklet places two pages side by side in saddle-stitch order
+	Layout string `json:"layout,omitempty" default:"grid" enums:"grid,booklet"`
+	// columns of the grid (ignored for booklets)
+	Columns int `json:"columns,omitempty" default:"2"`
+	// rows of the grid (ignored for booklets)
+	Rows int `json:"rows,omitempty" default:"1"`
+
+	// sheet size in mm; overrides sheet format
+	SheetSize   PageSize `json:"sheetSize,omitempty"`
+	SheetFormat string   `json:"sheetFormat,omitempty" default:"A4" enums:"A0,A1,A2,A3,A4,A5,A6,Letter,Legal"`
+	// sheet in landscape orientation
+	SheetLandscape bool `json:"sheetLandscape,omitempty" default:"false"`
+
+	// space between the pages in mm
+	Gutter int `json:"gutter,omitempty" default:"0"`
+	// draw lines around the placed pages
+	CutLines bool `json:"cutLines,omitempty" default:"false"`
+} // @name RenderOptionsImposition
+
+func (i *RenderOptionsImposition) SetDefaults() {
+	utils.ReflectDefaultValues(i)
+}
+
+func (i *RenderOptionsImposition) Validate() error {
+	if i.Layout != ImpositionLayoutGrid && i.Layout != ImpositionLayoutBooklet {
+		return fmt.Errorf("unknown imposition layout '%s'", i.Layout)
+	}
+
+	if i.Layout == ImpositionLayoutGrid && (i.Columns < 1 || i.Rows < 1) {
+		return fmt.Errorf("imposition grid requires at least one column and row (curr: %dx%d)", i.Columns, i.Rows)
+	}
+
+	if i.Gutter < 0 {
+		return fmt.Errorf("imposition gutter must not be negative (curr: %d)", i.Gutter)
+	}
+
+	if size := i.GetSheetSize(); size.Width <= 0 || size.Height <= 0 {
+		return fmt.Errorf("unknown imposition sheet format '%s'", i.SheetFormat)
+	}
+
+	return nil
+}
+
+// GetGrid returns the columns and rows of pages on one side of a sheet
+func (i *RenderOptionsImposition) GetGrid() (columns int, rows int) {
+	if i.Layout == ImpositionLayoutBooklet {
+		return 2, 1
+	}
+
+	return i.Columns, i.Rows
+}
+
+// GetSheetSize returns the sheet size by size or format, swapped for landscape sheets
+func (i *RenderOptionsImposition) GetSheetSize() PageSize {
+	size := i.SheetSize
+
+	if size.Width == 0 || size.Height == 0 {
+		size = PageSizesMap[strings.ToLower(i.SheetFormat)]
+	}
+
+	if i.SheetLandscape {
+		size.Width, size.Height = size.Height, size.Width
+	}
+
+	return size
+}
+
+// GetSheets returns the page numbers placed on every side of a sheet from the top left cell row by row.
+// Empty cells have the page number 0.
+func (i *RenderOptionsImposition) GetSheets(pageCount int) [][]int {
+	if i.Layout == ImpositionLayoutBooklet {
+		return getBookletSheets(pageCount)
+	}
+
+	cells := i.Columns * i.Rows
+	sheets := make([][]int, 0, (pageCount+cells-1)/cells)
+
+	for first := 1; first <= pageCount; first += cells {
+		sheet := make([]int, cells)
+
+		for c := range sheet {
+			if pageNr := first + c; pageNr <= pageCount {
+				sheet[c] = pageNr
+			}
+		}
+
+		sheets = append(sheets, sheet)
+	}
+
+	return sheets
+}
+
+// getBookletSheets returns the saddle-stitch order: the page count is filled up to a multiple of 4
+// and every sheet has the outermost remaining pages on the front and the following ones on the back.
+func getBookletSheets(pageCount int) [][]int {
+	paddedCount := (pageCount + 3) / 4 * 4
+
+	pageOrBlank := func(pageNr int) int {
+		if pageNr > pageCount {
+			return 0
+		}
+		return pageNr
+	}
+
+	sheets := make([][]int, 0, paddedCount/2)
+
+	for s := 0; s < paddedCount/4; s++ {
+		front := []int{pageOrBlank(paddedCount - 2*s), pageOrBlank(2*s + 1)}
+		back := []int{pageOrBlank(2*s + 2), pageOrBlank(paddedCount - 2*s - 1)}
+
+		sheets = append(sheets, front, back)
+	}
+
+	return sheets
+}
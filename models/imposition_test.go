@@ -0,0 +1,64 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetSheetsGrid(t *testing.T) {
+	i := &RenderOptionsImposition{Columns: 2, Rows: 2}
+	i.SetDefaults()
+
+	sheets := i.GetSheets(5)
+	expected := [][]int{{1, 2, 3, 4}, {5, 0, 0, 0}}
+
+	if !reflect.DeepEqual(sheets, expected) {
+		t.Fatalf("sheets should be %v (curr: %v)", expected, sheets)
+	}
+}
+
+func TestGetSheetsBooklet(t *testing.T) {
+	i := &RenderOptionsImposition{Layout: ImpositionLayoutBooklet}
+	i.SetDefaults()
+
+	sheets := i.GetSheets(6)
+	expected := [][]int{{0, 1}, {2, 0}, {6, 3}, {4, 5}}
+
+	if !reflect.DeepEqual(sheets, expected) {
+		t.Fatalf("sheets should be %v (curr: %v)", expected, sheets)
+	}
+
+	if columns, rows := i.GetGrid(); columns != 2 || rows != 1 {
+		t.Fatalf("booklet should have two pages side by side (curr: %dx%d)", columns, rows)
+	}
+}
+
+func TestGetSheetSize(t *testing.T) {
+	i := &RenderOptionsImposition{SheetFormat: "A3", SheetLandscape: true}
+	i.SetDefaults()
+
+	if size := i.GetSheetSize(); size.Width != 420 || size.Height != 297 {
+		t.Fatalf("landscape A3 sheet should be 420x297 (curr: %v)", size)
+	}
+
+	i.SheetSize = PageSize{Width: 100, Height: 200}
+	if size := i.GetSheetSize(); size.Width != 200 || size.Height != 100 {
+		t.Fatalf("sheet size should override the format (curr: %v)", size)
+	}
+}
+
+func TestImpositionValidate(t *testing.T) {
+	i := &RenderOptionsImposition{Layout: "spiral"}
+	i.SetDefaults()
+
+	if err := i.Validate(); err == nil {
+		t.Fatal("unknown layout should fail")
+	}
+
+	i = &RenderOptionsImposition{SheetFormat: "B5"}
+	i.SetDefaults()
+
+	if err := i.Validate(); err == nil {
+		t.Fatal("unknown sheet format should fail")
+	}
+}
@@ -0,0 +1,133 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/utils"
+)
+
+const (
+	MailingMarksTypeOmr        = "omr"
+	MailingMarksTypeDataMatrix = "datamatrix"
+
+	// highest values of the fixed length fields of the data matrix content
+	maxMailingDocumentIndex = 999999
+	maxMailingSheets        = 999
+)
+
+type RenderOptionsMailingMarks struct {
+	// omr draws horizontal marks, datamatrix a 2D code with the same information
+	Type string `json:"type,omitempty" default:"omr" enums:"omr,datamatrix"`
+	// index of the document (envelope) in the batch
+	DocumentIndex int `json:"documentIndex,omitempty" example:"1"`
+	// marks on the front pages only (odd pages); every sheet has two pages
+	Duplex bool `json:"duplex,omitempty" default:"false"`
+
+	// distance of the marks from the left edge of the page (trim box with print bleed) in mm
+	Left int `json:"left,omitempty" default:"5"`
+	// distance of the first mark from the top edge of the page (trim box with print bleed) in mm
+	Top int `json:"top,omitempty" default:"100"`
+	// length of the omr marks or size of the 2D code in mm
+	Size int `json:"size,omitempty" default:"10"`
+	// distance between the omr marks in mm
+	Spacing int `json:"spacing,omitempty" default:"4"`
+} // @name RenderOptionsMailingMarks
+
+// MailingMark is the information of one sheet read by the inserting machine
+type MailingMark struct {
+	PageNr        int
+	DocumentIndex int
+	// sheet number starting with 1
+	SheetNr       int
+	TotalSheets   int
+	EndOfEnvelope bool
+}
+
+func (m *RenderOptionsMailingMarks) SetDefaults() {
+	utils.ReflectDefaultValues(m)
+}
+
+func (m *RenderOptionsMailingMarks) Validate() error {
+	if m.Type != MailingMarksTypeOmr && m.Type != MailingMarksTypeDataMatrix {
+		return fmt.Errorf("unknown mailing marks type '%s'", m.Type)
+	}
+
+	if m.DocumentIndex < 0 || m.DocumentIndex > maxMailingDocumentIndex {
+		return fmt.Errorf("mailing marks document index must be between 0 and %d (curr: %d)", maxMailingDocumentIndex, m.DocumentIndex)
+	}
+
+	if m.Size <= 0 {
+		return fmt.Errorf("mailing marks size must be positive (curr: %d)", m.Size)
+	}
+
+	return nil
+}
+
+// GetMarks returns the mark of every sheet. The mark is placed on the first page of the sheet.
+func (m *RenderOptionsMailingMarks) GetMarks(pageCount int) ([]MailingMark, error) {
+	pagesPerSheet := 1
+	if m.Duplex {
+		pagesPerSheet = 2
+	}
+
+	totalSheets := (pageCount + pagesPerSheet - 1) / pagesPerSheet
+
+	if totalSheets > maxMailingSheets {
+		return nil, fmt.Errorf("mailing marks support up to %d sheets per document (curr: %d)", maxMailingSheets, totalSheets)
+	}
+
+	marks := make([]MailingMark, totalSheets)
+
+	for i := range marks {
+		marks[i] = MailingMark{
+			PageNr:        i*pagesPerSheet + 1,
+			DocumentIndex: m.DocumentIndex,
+			SheetNr:       i + 1,
+			TotalSheets:   totalSheets,
+			EndOfEnvelope: i == totalSheets-1,
+		}
+	}
+
+	return marks, nil
+}
+
+// OmrMarks returns the dark marks from the top: start mark, end of envelope, sheet sequence (3 bits, lowest first) and parity.
+// The parity mark makes the count of dark marks even.
+func (mm MailingMark) OmrMarks() []bool {
+	sequence := (mm.SheetNr - 1) % 8
+
+	marks := []bool{
+		true,
+		mm.EndOfEnvelope,
+		sequence&1 != 0,
+		sequence&2 != 0,
+		sequence&4 != 0,
+	}
+
+	darkCount := 0
+	for _, dark := range marks {
+		if dark {
+			darkCount++
+		}
+	}
+
+	return append(marks, darkCount%2 != 0)
+}
+
+// DataMatrixContent returns the document index (6 digits), sheet number and total sheets (3 digits each),
+// end of envelope (1 or 0) and a check digit (sum of all digits modulo 10)
+func (mm MailingMark) DataMatrixContent() string {
+	endOfEnvelope := 0
+	if mm.EndOfEnvelope {
+		endOfEnvelope = 1
+	}
+
+	content := fmt.Sprintf("%06d%03d%03d%d", mm.DocumentIndex, mm.SheetNr, mm.TotalSheets, endOfEnvelope)
+
+	sum := 0
+	for _, digit := range content {
+		sum += int(digit - '0')
+	}
+
+	return fmt.Sprintf("%s%d", content, sum%10)
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetMailingMarksDuplex(t *testing.T) {
+	m := &RenderOptionsMailingMarks{DocumentIndex: 42, Duplex: true}
+	m.SetDefaults()
+
+	marks, err := m.GetMarks(5)
+	if err != nil {
+		t.Fatalf("err should be nil: %v", err)
+	}
+
+	expected := []MailingMark{
+		{PageNr: 1, DocumentIndex: 42, SheetNr: 1, TotalSheets: 3},
+		{PageNr: 3, DocumentIndex: 42, SheetNr: 2, TotalSheets: 3},
+		{PageNr: 5, DocumentIndex: 42, SheetNr: 3, TotalSheets: 3, EndOfEnvelope: true},
+	}
+
+	if !reflect.DeepEqual(marks, expected) {
+		t.Fatalf("marks should be %v (curr: %v)", expected, marks)
+	}
+}
+
+func TestGetMailingMarksTooManySheets(t *testing.T) {
+	m := &RenderOptionsMailingMarks{}
+	m.SetDefaults()
+
+	if _, err := m.GetMarks(1000); err == nil {
+		t.Fatal("more sheets than the content can hold should fail")
+	}
+}
+
+func TestOmrMarks(t *testing.T) {
+	mark := MailingMark{SheetNr: 4, TotalSheets: 4, EndOfEnvelope: true}
+
+	// start, end, sequence 3 (bits 1 and 2), parity for 4 dark marks
+	expected := []bool{true, true, true, true, false, false}
+
+	if marks := mark.OmrMarks(); !reflect.DeepEqual(marks, expected) {
+		t.Fatalf("omr marks should be %v (curr: %v)", expected, marks)
+	}
+
+	mark = MailingMark{SheetNr: 1, TotalSheets: 4}
+
+	if marks := mark.OmrMarks(); !marks[len(marks)-1] {
+		t.Fatal("parity mark should make the count of dark marks even")
+	}
+}
+
+func TestDataMatrixContent(t *testing.T) {
+	mark := MailingMark{DocumentIndex: 42, SheetNr: 2, TotalSheets: 3}
+
+	if content := mark.DataMatrixContent(); content != "00004200200301" {
+		t.Fatalf("content should be 00004200200301 (curr: %s)", content)
+	}
+}
@@ -0,0 +1,16 @@
+package models
+
+type RenderOptionsMetadata struct {
+	// title of the document; the title of the html is used by chromium if empty
+	Title    string   `json:"title,omitempty" example:"Release Notes 2.0"`
+	Author   string   `json:"author,omitempty" example:"PDF Turtle"`
+	Subject  string   `json:"subject,omitempty"`
+	Keywords []string `json:"keywords,omitempty" example:"release,changelog"`
+	// application that created the document
+	Creator string `json:"creator,omitempty"`
+} // @name RenderOptionsMetadata
+
+// IsEmpty returns true if no entry of the document info is set
+func (m *RenderOptionsMetadata) IsEmpty() bool {
+	return m.Title == "" && m.Author == "" && m.Subject == "" && len(m.Keywords) == 0 && m.Creator == ""
+}
@@ -0,0 +1,28 @@
+package models
+
+// ModelPath is a value of the model referenced by a template
+type ModelPath struct {
+	// dot separated path; [] marks the items of a list (e.g. items[].price)
+	Path string `json:"path" example:"items[].price"`
+	// only tested by conditions (if / with), so the value can be missing
+	Optional bool `json:"optional"`
+} // @name ModelPath
+
+// ModelContract describes the model required by the templates
+type ModelContract struct {
+	// all model paths referenced by the templates
+	Paths []ModelPath `json:"paths"`
+	// JSON Schema (draft 2020-12) of the model derived from the paths
+	Schema map[string]any `json:"schema" swaggertype:"object"`
+	// required paths missing in the given model
+	MissingFields []string `json:"missingFields"`
+	// paths of the given model not referenced by the templates
+	UnusedFields []string `json:"unusedFields"`
+} // @name ModelContract
+
+// ModelViolation is a value of the model not matching the JSON Schema
+type ModelViolation struct {
+	// JSON pointer (RFC 6901) of the value in the model
+	Pointer string `json:"pointer" example:"/items/0/price"`
+	Message string `json:"message" example:"got string, want number"`
+} // @name ModelViolation
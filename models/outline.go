@@ -0,0 +1,26 @@
+package models
+
+import "github.com/lucas-gaitzsch/pdf-turtle/utils"
+
+type RenderOptionsOutline struct {
+	// css selector of the elements used as bookmarks; elements with the attribute data-pdf-bookmark are always used.
+	// Without selector and data-pdf-bookmark attributes the outline of all headings is generated by chromium.
+	Selector string `json:"selector,omitempty" example:"h1, h2, .chapter"`
+
+	// bookmarks parsed from the html (set while rendering)
+	Entries []OutlineEntry `json:"-"`
+	// the outline is generated by chromium; the entries are only used if the chromium version does not support it (set while rendering)
+	GeneratedByChromium bool `json:"-"`
+} // @name RenderOptionsOutline
+
+func (o *RenderOptionsOutline) SetDefaults() {
+	utils.ReflectDefaultValues(o)
+}
+
+type OutlineEntry struct {
+	Title string
+	// level starting with 1 for top level bookmarks
+	Level int
+	// id of the target element
+	Id string
+}
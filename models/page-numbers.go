@@ -0,0 +1,183 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/utils"
+)
+
+const (
+	PageNumberFormatDecimal    = "decimal"
+	PageNumberFormatLowerRoman = "lower-roman"
+	PageNumberFormatUpperRoman = "upper-roman"
+	PageNumberFormatLowerAlpha = "lower-alpha"
+	PageNumberFormatUpperAlpha = "upper-alpha"
+)
+
+var PageNumberFormats = []string{PageNumberFormatDecimal, PageNumberFormatLowerRoman, PageNumberFormatUpperRoman, PageNumberFormatLowerAlpha, PageNumberFormatUpperAlpha}
+
+type RenderOptionsPageNumbers struct {
+	// number of the first page (e.g. 5 if the document continues another one)
+	Start int `json:"start,omitempty" default:"1"`
+	// format of the page numbers
+	Format string `json:"format,omitempty" default:"decimal" enums:"decimal,lower-roman,upper-roman,lower-alpha,upper-alpha"`
+
+	// sections parsed from the html (set while rendering)
+	Sections []PageNumberSection `json:"-"`
+	// rendered header and footer with page numbers (set while rendering)
+	HeaderFooterPdf []byte `json:"-"`
+} // @name RenderOptionsPageNumbers
+
+type PageNumberSection struct {
+	// id of the element starting the section
+	Id string
+	// number of the first page of the section; 0 starts with 1
+	Start int
+	// format of the page numbers; empty uses the format of the document
+	Format string
+}
+
+// Page numbers of a page formatted for the placeholders
+type PageNumbers struct {
+	PageNumber        string
+	TotalPages        string
+	SectionPageNumber string
+	SectionTotalPages string
+}
+
+func (o *RenderOptionsPageNumbers) SetDefaults() {
+	utils.ReflectDefaultValues(o)
+}
+
+func (o *RenderOptionsPageNumbers) Validate() error {
+	if !slices.Contains(PageNumberFormats, o.Format) {
+		return fmt.Errorf("unknown page number format '%s'", o.Format)
+	}
+
+	for _, s := range o.Sections {
+		if s.Format != "" && !slices.Contains(PageNumberFormats, s.Format) {
+			return fmt.Errorf("unknown page number format '%s' of section '%s'", s.Format, s.Id)
+		}
+	}
+
+	return nil
+}
+
+// GetPageNumbers returns the page numbers of all pages. sectionPages contains the page of the element starting the section by id.
+// Pages before the first section belong to a section with the start and format of the document.
+func (o *RenderOptionsPageNumbers) GetPageNumbers(pageCount int, sectionPages map[string]int) []PageNumbers {
+	type sectionStart struct {
+		page int
+		PageNumberSection
+	}
+
+	starts := []sectionStart{{1, PageNumberSection{Start: o.Start, Format: o.Format}}}
+
+	for _, s := range o.Sections {
+		page, ok := sectionPages[s.Id]
+		if !ok || page < 1 || page > pageCount {
+			continue
+		}
+
+		if s.Start == 0 {
+			s.Start = 1
+		}
+
+		if s.Format == "" {
+			s.Format = o.Format
+		}
+
+		starts = append(starts, sectionStart{page, s})
+	}
+
+	// the last section starting on a page wins
+	sort.SliceStable(starts, func(i, j int) bool {
+		return starts[i].page < starts[j].page
+	})
+
+	res := make([]PageNumbers, pageCount)
+	totalPages := FormatPageNumber(o.Start+pageCount-1, o.Format)
+
+	for i, s := range starts {
+		end := pageCount
+		if i+1 < len(starts) {
+			end = starts[i+1].page - 1
+		}
+
+		sectionTotalPages := FormatPageNumber(s.Start+end-s.page, s.Format)
+
+		for pageNr := s.page; pageNr <= end; pageNr++ {
+			res[pageNr-1] = PageNumbers{
+				PageNumber:        FormatPageNumber(o.Start+pageNr-1, o.Format),
+				TotalPages:        totalPages,
+				SectionPageNumber: FormatPageNumber(s.Start+pageNr-s.page, s.Format),
+				SectionTotalPages: sectionTotalPages,
+			}
+		}
+	}
+
+	return res
+}
+
+// FormatPageNumber formats the number like the css list-style-type. Numbers not representable in the format are formatted decimal.
+func FormatPageNumber(n int, format string) string {
+	switch format {
+	case PageNumberFormatLowerRoman:
+		return strings.ToLower(toRoman(n))
+	case PageNumberFormatUpperRoman:
+		return toRoman(n)
+	case PageNumberFormatLowerAlpha:
+		return strings.ToLower(toAlpha(n))
+	case PageNumberFormatUpperAlpha:
+		return toAlpha(n)
+	default:
+		return strconv.Itoa(n)
+	}
+}
+
+var romanNumerals = []struct {
+	value  int
+	symbol string
+}{
+	{1000, "M"}, {900, "CM"}, {500, "D"}, {400, "CD"},
+	{100, "C"}, {90, "XC"}, {50, "L"}, {40, "XL"},
+	{10, "X"}, {9, "IX"}, {5, "V"}, {4, "IV"}, {1, "I"},
+}
+
+func toRoman(n int) string {
+	if n < 1 || n > 3999 {
+		return strconv.Itoa(n)
+	}
+
+	sb := new(strings.Builder)
+
+	for _, r := range romanNumerals {
+		for n >= r.value {
+			sb.WriteString(r.symbol)
+			n -= r.value
+		}
+	}
+
+	return sb.String()
+}
+
+// toAlpha returns A-Z, AA-AZ, ... for 1, 2, ...
+func toAlpha(n int) string {
+	if n < 1 {
+		return strconv.Itoa(n)
+	}
+
+	res := ""
+
+	for n > 0 {
+		n--
+		res = string(rune('A'+n%26)) + res
+		n /= 26
+	}
+
+	return res
+}
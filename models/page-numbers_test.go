@@ -0,0 +1,82 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+var formatPageNumberTestData = []struct {
+	n        int
+	format   string
+	expected string
+}{
+	{4, PageNumberFormatDecimal, "4"},
+	{4, PageNumberFormatLowerRoman, "iv"},
+	{1994, PageNumberFormatUpperRoman, "MCMXCIV"},
+	{0, PageNumberFormatUpperRoman, "0"},
+	{1, PageNumberFormatLowerAlpha, "a"},
+	{26, PageNumberFormatUpperAlpha, "Z"},
+	{28, PageNumberFormatUpperAlpha, "AB"},
+	{7, "unknown", "7"},
+}
+
+func TestFormatPageNumber(t *testing.T) {
+	for _, d := range formatPageNumberTestData {
+		t.Run(d.format+" "+d.expected, func(t *testing.T) {
+			if res := FormatPageNumber(d.n, d.format); res != d.expected {
+				t.Fatalf("page number should be %s (curr: %s)", d.expected, res)
+			}
+		})
+	}
+}
+
+func TestGetPageNumbersWithStart(t *testing.T) {
+	o := &RenderOptionsPageNumbers{Start: 5}
+	o.SetDefaults()
+
+	numbers := o.GetPageNumbers(2, nil)
+
+	expected := []PageNumbers{
+		{PageNumber: "5", TotalPages: "6", SectionPageNumber: "5", SectionTotalPages: "6"},
+		{PageNumber: "6", TotalPages: "6", SectionPageNumber: "6", SectionTotalPages: "6"},
+	}
+
+	if !reflect.DeepEqual(numbers, expected) {
+		t.Fatalf("unexpected page numbers %v", numbers)
+	}
+}
+
+func TestGetPageNumbersWithSections(t *testing.T) {
+	o := &RenderOptionsPageNumbers{
+		Format: PageNumberFormatLowerRoman,
+		Sections: []PageNumberSection{
+			{Id: "chapter-1", Format: PageNumberFormatDecimal},
+			{Id: "appendix", Start: 10, Format: PageNumberFormatUpperAlpha},
+			{Id: "hidden"},
+		},
+	}
+	o.SetDefaults()
+
+	numbers := o.GetPageNumbers(5, map[string]int{"chapter-1": 3, "appendix": 5})
+
+	expectedSectionNumbers := []string{"i/ii", "ii/ii", "1/2", "2/2", "J/J"}
+
+	for i, n := range numbers {
+		if res := n.SectionPageNumber + "/" + n.SectionTotalPages; res != expectedSectionNumbers[i] {
+			t.Fatalf("section page number of page %d should be %s (curr: %s)", i+1, expectedSectionNumbers[i], res)
+		}
+
+		if n.TotalPages != "v" {
+			t.Fatalf("total pages should be v (curr: %s)", n.TotalPages)
+		}
+	}
+}
+
+func TestValidatePageNumbers(t *testing.T) {
+	o := &RenderOptionsPageNumbers{Sections: []PageNumberSection{{Id: "s", Format: "greek"}}}
+	o.SetDefaults()
+
+	if err := o.Validate(); err == nil {
+		t.Fatal("unknown section format should be invalid")
+	}
+}
@@ -0,0 +1,77 @@
+package models
+
+const (
+	PageVariantFirst = "first"
+	PageVariantOdd   = "odd"
+	PageVariantEven  = "even"
+	PageVariantLast  = "last"
+)
+
+var PageVariantNames = []string{PageVariantFirst, PageVariantOdd, PageVariantEven, PageVariantLast}
+
+// Html of the header or footer for specific pages. Null falls back to the default html, an empty string hides the header or footer.
+// The precedence is first, last, odd/even.
+type PageVariants struct {
+	First *string `json:"first,omitempty"`
+	Odd   *string `json:"odd,omitempty"`
+	Even  *string `json:"even,omitempty"`
+	Last  *string `json:"last,omitempty"`
+} // @name PageVariants
+
+func (v *PageVariants) IsEmpty() bool {
+	return v.First == nil && v.Odd == nil && v.Even == nil && v.Last == nil
+}
+
+func (v *PageVariants) Get(variant string) *string {
+	switch variant {
+	case PageVariantFirst:
+		return v.First
+	case PageVariantOdd:
+		return v.Odd
+	case PageVariantEven:
+		return v.Even
+	case PageVariantLast:
+		return v.Last
+	default:
+		return nil
+	}
+}
+
+func (v *PageVariants) Set(variant string, html *string) {
+	switch variant {
+	case PageVariantFirst:
+		v.First = html
+	case PageVariantOdd:
+		v.Odd = html
+	case PageVariantEven:
+		v.Even = html
+	case PageVariantLast:
+		v.Last = html
+	}
+}
+
+// GetPageVariant returns the variant used for the page (one based) by the precedence first, last, odd/even.
+// An empty string is returned if no variant is defined for the page.
+func GetPageVariant(pageNr int, pageCount int, variants ...*PageVariants) string {
+	has := func(variant string) bool {
+		for _, v := range variants {
+			if v.Get(variant) != nil {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch {
+	case pageNr == 1 && has(PageVariantFirst):
+		return PageVariantFirst
+	case pageNr == pageCount && has(PageVariantLast):
+		return PageVariantLast
+	case pageNr%2 == 1 && has(PageVariantOdd):
+		return PageVariantOdd
+	case pageNr%2 == 0 && has(PageVariantEven):
+		return PageVariantEven
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"fmt"
+	"testing"
+)
+
+var pageVariantTestData = []struct {
+	pageNr    int
+	pageCount int
+	variant   string
+}{
+	{1, 1, PageVariantFirst},
+	{1, 4, PageVariantFirst},
+	{2, 4, PageVariantEven},
+	{3, 4, PageVariantOdd},
+	{4, 4, PageVariantLast},
+}
+
+func TestGetPageVariant(t *testing.T) {
+	empty := ""
+	header := &PageVariants{First: &empty, Odd: &empty}
+	footer := &PageVariants{Even: &empty, Last: &empty}
+
+	for _, d := range pageVariantTestData {
+		t.Run(fmt.Sprintf("page %d of %d", d.pageNr, d.pageCount), func(t *testing.T) {
+			if variant := GetPageVariant(d.pageNr, d.pageCount, header, footer); variant != d.variant {
+				t.Fatalf("variant should be %s (curr: %s)", d.variant, variant)
+			}
+		})
+	}
+}
+
+func TestGetPageVariantWithoutVariants(t *testing.T) {
+	if variant := GetPageVariant(1, 3, &PageVariants{}); variant != "" {
+		t.Fatalf("variant should be empty (curr: %s)", variant)
+	}
+}
@@ -0,0 +1,42 @@
+package models
+
+import "github.com/lucas-gaitzsch/pdf-turtle/utils"
+
+type RenderOptionsPrint struct {
+	// bleed around the page in mm; the page is rendered larger by the bleed
+	Bleed int `json:"bleed,omitempty" default:"3"`
+	// draw crop marks at the corners of the trim box
+	CropMarks bool `json:"cropMarks,omitempty" default:"false"`
+	// draw registration marks at the sides of the page
+	RegistrationMarks bool `json:"registrationMarks,omitempty" default:"false"`
+	// space around the bleed for the marks in mm
+	Slug int `json:"slug,omitempty" default:"10"`
+} // @name RenderOptionsPrint
+
+func (p *RenderOptionsPrint) SetDefaults() {
+	utils.ReflectDefaultValues(p)
+}
+
+func (p *RenderOptionsPrint) HasMarks() bool {
+	return p.CropMarks || p.RegistrationMarks
+}
+
+// ApplyBleed enlarges the page size and the margins by the bleed, so the layout of the page does not change
+func (p *RenderOptionsPrint) ApplyBleed(ro *RenderOptions) {
+	ro.PageSize = PageSize{
+		Width:  ro.PageSize.Width + 2*p.Bleed,
+		Height: ro.PageSize.Height + 2*p.Bleed,
+	}
+
+	margins := RenderOptionsMargins{}
+	if ro.Margins != nil {
+		margins = *ro.Margins
+	}
+
+	margins.Top += p.Bleed
+	margins.Right += p.Bleed
+	margins.Bottom += p.Bleed
+	margins.Left += p.Bleed
+
+	ro.Margins = &margins
+}
@@ -0,0 +1,24 @@
+package models
+
+import "testing"
+
+func TestApplyBleed(t *testing.T) {
+	ro := &RenderOptions{Print: &RenderOptionsPrint{}}
+	ro.SetDefaults()
+
+	margins := ro.Margins
+
+	ro.Print.ApplyBleed(ro)
+
+	if ro.PageSize.Width != 216 || ro.PageSize.Height != 303 {
+		t.Fatalf("page size should be enlarged by the bleed (curr: %v)", ro.PageSize)
+	}
+
+	if ro.Margins.Top != margins.Top+3 || ro.Margins.Left != margins.Left+3 {
+		t.Fatalf("margins should be enlarged by the bleed (curr: %v)", *ro.Margins)
+	}
+
+	if margins.Top != 25 {
+		t.Fatal("margins should be copied")
+	}
+}
@@ -7,6 +7,15 @@ type RenderData struct {
 	HeaderHtml string  `json:"headerHtml,omitempty" example:"<h1>Heading</h1>"`                                                                                                        // Optional html for header. If empty, the header html will be parsed from main html (<PdfHeader></PdfHeader>).
 	FooterHtml string  `json:"footerHtml,omitempty" default:"<div class=\"default-footer\"><div><span class=\"pageNumber\"></span> of <span class=\"totalPages\"></span></div></div>"` // Optional html for footer. If empty, the footer html will be parsed from main html (<PdfFooter></PdfFooter>).
 
+	// Optional html of the header for the first, odd, even or last pages. If empty, the variants will be parsed from main html (<PdfHeader page="first"></PdfHeader>).
+	HeaderHtmlPages PageVariants `json:"headerHtmlPages,omitempty"`
+	// Optional html of the footer for the first, odd, even or last pages. If empty, the variants will be parsed from main html (<PdfFooter page="last"></PdfFooter>).
+	FooterHtmlPages PageVariants `json:"footerHtmlPages,omitempty"`
+
+	// Optional sections rendered with their own page options and merged into one pdf. The html is used for shared styles and scripts.
+	// If empty, the sections will be parsed from main html (<PdfSection options='{"landscape": true}'></PdfSection>).
+	Sections []RenderSection `json:"sections,omitempty"`
+
 	RenderOptions RenderOptions `json:"options,omitempty"`
 } // @name RenderData
 
@@ -14,6 +23,10 @@ func (d *RenderData) HasHeaderOrFooterHtml() bool {
 	return d.HeaderHtml != "" || d.FooterHtml != ""
 }
 
+func (d *RenderData) HasHeaderOrFooterVariants() bool {
+	return !d.HeaderHtmlPages.IsEmpty() || !d.FooterHtmlPages.IsEmpty()
+}
+
 func (d *RenderData) GetHeaderHtml() string {
 	return d.HeaderHtml
 }
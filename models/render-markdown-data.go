@@ -0,0 +1,24 @@
+package models
+
+// ThemesReader provides custom markdown themes, e.g. the files of the themes directory of a bundle.
+// The names are the file names without extension (e.g. corporate for themes/corporate.css).
+type ThemesReader interface {
+	GetTheme(name string) (css *string, found bool)
+}
+
+type RenderMarkdownData struct {
+	Markdown string `json:"markdown" example:"# Hello World"`
+	// Optional html for header. If empty, the header html will be parsed from the markdown (<PdfHeader></PdfHeader>).
+	HeaderHtml string `json:"headerHtml,omitempty"`
+	// Optional html for footer. If empty, the footer html will be parsed from the markdown (<PdfFooter></PdfFooter>).
+	FooterHtml string `json:"footerHtml,omitempty"`
+
+	// Builtin theme (default, github, academic or none) or a theme of the bundle; default if empty. Overridden by the theme of the front matter
+	Theme string `json:"theme,omitempty" example:"github"`
+
+	// Options overridden by the options of the front matter
+	RenderOptions RenderOptions `json:"options,omitempty"`
+
+	// Custom themes in addition to the builtin themes (provided by bundles)
+	Themes ThemesReader `json:"-"`
+} // @name RenderMarkdownData
@@ -25,8 +25,43 @@ type RenderOptions struct {
 	PageSize   PageSize `json:"pageSize,omitempty"`
 	PageFormat string   `json:"pageFormat,omitempty" default:"A4" enums:"A0,A1,A2,A3,A4,A5,A6,Letter,Legal"`
 
+	// locale of the formatting helpers in templates (dates, numbers and currencies); en-US if empty
+	Locale string `json:"locale,omitempty" example:"de-DE"`
+
 	// margins in mm; fallback to default if null
 	Margins *RenderOptionsMargins `json:"margins,omitempty"`
+	// measure header and footer and set the margins top and bottom to fit; disabled if null
+	AutoMargins *RenderOptionsAutoMargins `json:"autoMargins,omitempty"`
+
+	// page numbers for the placeholder classes pdf-page-number, pdf-total-pages, pdf-section-page-number and pdf-section-total-pages in header and footer
+	PageNumbers *RenderOptionsPageNumbers `json:"pageNumbers,omitempty"`
+
+	// blank pages for duplex printing; elements with the class page-break-before-odd always start on an odd page
+	BlankPages *RenderOptionsBlankPages `json:"blankPages,omitempty"`
+
+	// bleed, crop marks and page boxes for print production; disabled if null
+	Print *RenderOptionsPrint `json:"print,omitempty"`
+	// omr marks or data matrix codes for envelope inserting machines; disabled if null
+	MailingMarks *RenderOptionsMailingMarks `json:"mailingMarks,omitempty"`
+	// places the pages on larger sheets in a grid (n-up) or in booklet order; disabled if null
+	Imposition *RenderOptionsImposition `json:"imposition,omitempty"`
+
+	// files to embed into the pdf
+	Attachments []Attachment `json:"attachments,omitempty"`
+	// embed the invoice xml as Factur-X / ZUGFeRD e-invoice (without PDF/A conformance); disabled if null
+	EInvoice *RenderOptionsEInvoice `json:"eInvoice,omitempty"`
+	// text, image or html stamped on the pages after rendering; disabled if null
+	Watermark *RenderOptionsWatermark `json:"watermark,omitempty"`
+	// letterhead pdf placed underneath the rendered pages; disabled if null
+	Stationery *RenderOptionsStationery `json:"stationery,omitempty"`
+	// pdf bookmarks generated from the headings; disabled if null
+	Outline *RenderOptionsOutline `json:"outline,omitempty"`
+
+	// title, author, subject, keywords and creator of the document info; taken from the html if null
+	Metadata *RenderOptionsMetadata `json:"metadata,omitempty"`
+
+	// normalize the dates and the file identifier, so the same input produces the same bytes; disabled if null
+	Deterministic *RenderOptionsDeterministic `json:"deterministic,omitempty"`
 
 	// true if options was parsed from bundle
 	IsBundle bool `json:"-"`
@@ -39,6 +74,38 @@ func (ro *RenderOptions) SetDefaults() {
 
 	ro.setDefaultMargin()
 	ro.setEmptyPageSizeByFormat()
+
+	if ro.AutoMargins != nil {
+		ro.AutoMargins.SetDefaults()
+	}
+
+	if ro.PageNumbers != nil {
+		ro.PageNumbers.SetDefaults()
+	}
+
+	if ro.Print != nil {
+		ro.Print.SetDefaults()
+	}
+
+	if ro.MailingMarks != nil {
+		ro.MailingMarks.SetDefaults()
+	}
+
+	if ro.Imposition != nil {
+		ro.Imposition.SetDefaults()
+	}
+
+	if ro.EInvoice != nil {
+		ro.EInvoice.SetDefaults()
+	}
+
+	if ro.Watermark != nil {
+		ro.Watermark.SetDefaults()
+	}
+
+	if ro.Outline != nil {
+		ro.Outline.SetDefaults()
+	}
 }
 
 func (ro *RenderOptions) setDefaultMargin() {
@@ -0,0 +1,83 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+type RenderSection struct {
+	Html *string `json:"html" example:"<table>...</table>"`
+	// Optional html for the header of the section. If empty, the header html will be parsed from the section html or the header of the document is used.
+	HeaderHtml string `json:"headerHtml,omitempty"`
+	// Optional html for the footer of the section. If empty, the footer html will be parsed from the section html or the footer of the document is used.
+	FooterHtml string `json:"footerHtml,omitempty"`
+
+	// page options of the section; fallback to the options of the document if null
+	Options *RenderSectionOptions `json:"options,omitempty"`
+} // @name RenderSection
+
+type RenderSectionOptions struct {
+	Landscape bool `json:"landscape,omitempty"`
+
+	// page size in mm; overrides page format
+	PageSize   PageSize `json:"pageSize,omitempty"`
+	PageFormat string   `json:"pageFormat,omitempty" enums:"A0,A1,A2,A3,A4,A5,A6,Letter,Legal"`
+
+	// margins in mm; fallback to the margins of the document if null
+	Margins *RenderOptionsMargins `json:"margins,omitempty"`
+} // @name RenderSectionOptions
+
+// GetRenderOptions returns the options of the document with the page options of the section.
+// Options applied to the merged document (e.g. watermark or attachments) are removed.
+func (s *RenderSection) GetRenderOptions(doc *RenderOptions) (RenderOptions, error) {
+	ro := *doc
+
+	ro.Attachments = nil
+	ro.EInvoice = nil
+	ro.Watermark = nil
+	ro.Stationery = nil
+	ro.MailingMarks = nil
+	ro.Imposition = nil
+	ro.Deterministic = nil
+	// bookmarks are prepared for the whole document
+	ro.Outline = nil
+
+	if doc.PageNumbers != nil {
+		ro.PageNumbers = &RenderOptionsPageNumbers{Start: doc.PageNumbers.Start, Format: doc.PageNumbers.Format}
+	}
+
+	if doc.BlankPages != nil {
+		ro.BlankPages = &RenderOptionsBlankPages{EvenPageCount: doc.BlankPages.EvenPageCount, Html: doc.BlankPages.Html}
+	}
+
+	if doc.Margins != nil {
+		margins := *doc.Margins
+		ro.Margins = &margins
+	}
+
+	o := s.Options
+	if o == nil {
+		return ro, nil
+	}
+
+	ro.Landscape = o.Landscape
+
+	if o.PageSize.Width > 0 && o.PageSize.Height > 0 {
+		ro.PageSize = o.PageSize
+	} else if o.PageFormat != "" {
+		size, ok := PageSizesMap[strings.ToLower(o.PageFormat)]
+		if !ok {
+			return ro, fmt.Errorf("unknown page format '%s'", o.PageFormat)
+		}
+
+		ro.PageFormat = o.PageFormat
+		ro.PageSize = size
+	}
+
+	if o.Margins != nil {
+		margins := *o.Margins
+		ro.Margins = &margins
+	}
+
+	return ro, nil
+}
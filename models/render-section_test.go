@@ -0,0 +1,53 @@
+package models
+
+import "testing"
+
+func TestSectionRenderOptions(t *testing.T) {
+	doc := &RenderOptions{
+		Watermark: &RenderOptionsWatermark{Text: "Draft"},
+		Outline:   &RenderOptionsOutline{},
+	}
+	doc.SetDefaults()
+
+	s := &RenderSection{Options: &RenderSectionOptions{Landscape: true, PageFormat: "A3"}}
+
+	ro, err := s.GetRenderOptions(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !ro.Landscape || ro.PageSize != PageSizesMap[PageSizeKeyA3] {
+		t.Fatalf("section should be A3 landscape (curr: %v, landscape: %v)", ro.PageSize, ro.Landscape)
+	}
+
+	if *ro.Margins != *doc.Margins || ro.Margins == doc.Margins {
+		t.Fatal("margins of the document should be copied")
+	}
+
+	if ro.Watermark != nil || ro.Outline != nil {
+		t.Fatal("options of the merged document should be removed")
+	}
+}
+
+func TestSectionRenderOptionsFallback(t *testing.T) {
+	doc := &RenderOptions{Landscape: true}
+	doc.SetDefaults()
+
+	ro, err := (&RenderSection{}).GetRenderOptions(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !ro.Landscape || ro.PageSize != doc.PageSize {
+		t.Fatal("section without options should use the options of the document")
+	}
+}
+
+func TestSectionRenderOptionsUnknownFormat(t *testing.T) {
+	doc := &RenderOptions{}
+	doc.SetDefaults()
+
+	if _, err := (&RenderSection{Options: &RenderSectionOptions{PageFormat: "B7"}}).GetRenderOptions(doc); err == nil {
+		t.Fatal("unknown page format should fail")
+	}
+}
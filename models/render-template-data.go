@@ -1,6 +1,16 @@
 package models
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"io"
+)
+
+// PartialsReader provides shared templates (partials), e.g. the files of the partials directory of a bundle.
+// The names are the paths relative to the partials directory (e.g. address.html).
+type PartialsReader interface {
+	GetPartialNames() []string
+	GetPartial(name string) (io.ReadCloser, error)
+}
 
 type RenderTemplateData struct {
 	HtmlTemplate *string `json:"htmlTemplate"`
@@ -8,13 +18,23 @@ type RenderTemplateData struct {
 	HeaderHtmlTemplate string `json:"headerHtmlTemplate,omitempty"`
 	// Optional template for footer. If empty, the footer template will be parsed from main template (<PdfFooter></PdfFooter>).
 	FooterHtmlTemplate string `json:"footerHtmlTemplate,omitempty"`
+	// Optional templates of the header for the first, odd, even or last pages.
+	HeaderHtmlTemplatePages PageVariants `json:"headerHtmlTemplatePages,omitempty"`
+	// Optional templates of the footer for the first, odd, even or last pages.
+	FooterHtmlTemplatePages PageVariants `json:"footerHtmlTemplatePages,omitempty"`
 
 	// Model with your data matching to the templates
 	Model any `json:"model,omitempty" swaggertype:"object"`
 
-	TemplateEngine string `json:"templateEngine,omitempty" default:"golang" enums:"golang,handlebars,django"`
+	// Optional JSON Schema to validate the model before templating (violations are returned with status 422)
+	ModelSchema any `json:"modelSchema,omitempty" swaggertype:"object"`
+
+	TemplateEngine string `json:"templateEngine,omitempty" default:"golang" enums:"golang,handlebars,django,liquid"`
 
 	RenderOptions RenderOptions `json:"options,omitempty"`
+
+	// Shared templates available in all templates (provided by bundles)
+	Partials PartialsReader `json:"-"`
 } // @name RenderTemplateData
 
 func (d *RenderTemplateData) HasHeaderOrFooterHtml() bool {
@@ -39,6 +59,7 @@ func (d *RenderTemplateData) SetFooterHtml(html string) {
 
 func (d *RenderTemplateData) ParseJsonModelDataFromDoubleEncodedString() {
 	d.Model = parseJsonFieldFromDoubleEncodedString(d.Model)
+	d.ModelSchema = parseJsonFieldFromDoubleEncodedString(d.ModelSchema)
 }
 func parseJsonFieldFromDoubleEncodedString(model any) any {
 	if str, ok := model.(string); ok {
@@ -0,0 +1,18 @@
+package models
+
+import "errors"
+
+type RenderOptionsStationery struct {
+	// base64 encoded pdf placed underneath every page; the first page of the pdf is used
+	Pdf []byte `json:"pdf,omitempty" swaggertype:"string" format:"base64"`
+	// base64 encoded pdf placed underneath the first page instead of the stationery pdf
+	FirstPagePdf []byte `json:"firstPagePdf,omitempty" swaggertype:"string" format:"base64"`
+} // @name RenderOptionsStationery
+
+func (s *RenderOptionsStationery) Validate() error {
+	if len(s.Pdf) == 0 && len(s.FirstPagePdf) == 0 {
+		return errors.New("stationery requires a pdf or a first page pdf")
+	}
+
+	return nil
+}
@@ -0,0 +1,24 @@
+package models
+
+const (
+	TemplateDiagnosticSeverityError   = "error"
+	TemplateDiagnosticSeverityWarning = "warning"
+)
+
+// TemplateDiagnostic is an issue of a template found by the template engine
+type TemplateDiagnostic struct {
+	// template with the issue: body, header, footer or a page variant (e.g. header.first)
+	Template string `json:"template" example:"body"`
+	// errors fail the rendering, warnings (e.g. missing model values) render empty values
+	Severity string `json:"severity" enums:"error,warning"`
+	// line of the issue starting with 1; omitted if unknown
+	Line int `json:"line,omitempty" example:"12"`
+	// column of the issue starting with 1; omitted if unknown
+	Column int `json:"column,omitempty" example:"8"`
+	// lines around the issue with a marker under the column
+	Excerpt string `json:"excerpt,omitempty"`
+	// path of the model value missing for the template; only set by the golang and liquid engines (handlebars and django render missing values empty without error)
+	ModelPath string `json:"modelPath,omitempty" example:"company.name"`
+	// error message of the template engine
+	Message string `json:"message"`
+} // @name TemplateDiagnostic
@@ -0,0 +1,102 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/utils"
+)
+
+const (
+	WatermarkPositionTopLeft      = "top-left"
+	WatermarkPositionTopCenter    = "top-center"
+	WatermarkPositionTopRight     = "top-right"
+	WatermarkPositionLeft         = "left"
+	WatermarkPositionCenter       = "center"
+	WatermarkPositionRight        = "right"
+	WatermarkPositionBottomLeft   = "bottom-left"
+	WatermarkPositionBottomCenter = "bottom-center"
+	WatermarkPositionBottomRight  = "bottom-right"
+)
+
+// fonts of text stamps (standard fonts of pdf viewers)
+var WatermarkFontNames = []string{"Helvetica", "Times-Roman", "Courier"}
+
+var watermarkColorRegex = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+type RenderOptionsWatermark struct {
+	// text of the stamp (e.g. DRAFT or CONFIDENTIAL); use \n for multiple lines
+	Text string `json:"text,omitempty" example:"DRAFT"`
+	// base64 encoded image (png, jpeg or tiff)
+	Image []byte `json:"image,omitempty" swaggertype:"string" format:"base64"`
+	// path of an image inside of the bundle (only for bundles)
+	BundleFile string `json:"bundleFile,omitempty" example:"assets/logo.png"`
+	// html snippet rendered transparent with the page size of the document
+	Html string `json:"html,omitempty" example:"<div style=\"position: absolute; bottom: 10mm; right: 10mm\">COPY</div>"`
+
+	// font for text stamps (only Helvetica, Times-Roman and Courier)
+	FontName string `json:"fontName,omitempty" default:"Helvetica" enums:"Helvetica,Times-Roman,Courier"`
+	// font size of text stamps in pt
+	FontSize int `json:"fontSize,omitempty" default:"48"`
+	// color of text stamps as hex value
+	Color string `json:"color,omitempty" default:"#808080" example:"#ff0000"`
+
+	// rotation in degrees counter clockwise (-180 to 180)
+	Rotation int `json:"rotation,omitempty" example:"45"`
+	// opacity in percent (0 to 100); 0 is a valid (invisible) value
+	Opacity *int `json:"opacity,omitempty" default:"100" example:"30"`
+	// width of text and image stamps relative to the page width in percent
+	Scale    int    `json:"scale,omitempty" default:"50"`
+	Position string `json:"position,omitempty" default:"center" enums:"top-left,top-center,top-right,left,center,right,bottom-left,bottom-center,bottom-right"`
+	// horizontal offset from the position in mm
+	OffsetX int `json:"offsetX,omitempty"`
+	// vertical offset from the position in mm
+	OffsetY int `json:"offsetY,omitempty"`
+
+	// pages to stamp (e.g. "1", "2-4", "odd", "even", "1,3-5", "!1" or "l" for the last page); all pages if empty
+	Pages string `json:"pages,omitempty" example:"1-3"`
+	// stamp behind the page content instead of on top
+	Background bool `json:"background,omitempty" default:"false"`
+
+	// html rendered as pdf (set while rendering)
+	HtmlPdf []byte `json:"-"`
+} // @name RenderOptionsWatermark
+
+func (w *RenderOptionsWatermark) SetDefaults() {
+	utils.ReflectDefaultValues(w)
+}
+
+func (w *RenderOptionsWatermark) Validate() error {
+	count := 0
+
+	if w.Text != "" {
+		count++
+	}
+	if len(w.Image) > 0 || w.BundleFile != "" {
+		count++
+	}
+	if w.Html != "" {
+		count++
+	}
+
+	if count != 1 {
+		return errors.New("watermark requires exactly one of text, image or html")
+	}
+
+	// the font and the color are passed to pdfcpu as description string
+	if w.FontName != "" && !slices.Contains(WatermarkFontNames, w.FontName) {
+		return fmt.Errorf("invalid watermark font '%s' (Helvetica, Times-Roman or Courier)", w.FontName)
+	}
+
+	if w.Color != "" && !watermarkColorRegex.MatchString(w.Color) {
+		return fmt.Errorf("invalid watermark color '%s' (hex value like #808080)", w.Color)
+	}
+
+	if w.Opacity != nil && (*w.Opacity < 0 || *w.Opacity > 100) {
+		return fmt.Errorf("invalid watermark opacity %d (0 to 100)", *w.Opacity)
+	}
+
+	return nil
+}
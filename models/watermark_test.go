@@ -0,0 +1,55 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func intPtr(v int) *int {
+	return &v
+}
+
+var watermarkValidationTestData = []struct {
+	name    string
+	wm      RenderOptionsWatermark
+	isValid bool
+}{
+	{"text", RenderOptionsWatermark{Text: "DRAFT"}, true},
+	{"image", RenderOptionsWatermark{Image: []byte("png")}, true},
+	{"bundle image", RenderOptionsWatermark{BundleFile: "assets/logo.png"}, true},
+	{"html", RenderOptionsWatermark{Html: "<b>COPY</b>"}, true},
+	{"empty", RenderOptionsWatermark{}, false},
+	{"text and html", RenderOptionsWatermark{Text: "DRAFT", Html: "<b>COPY</b>"}, false},
+	{"font and color", RenderOptionsWatermark{Text: "DRAFT", FontName: "Courier", Color: "#ff0000"}, true},
+	{"font with parameters", RenderOptionsWatermark{Text: "DRAFT", FontName: "Helvetica, rotation:90"}, false},
+	{"color with parameters", RenderOptionsWatermark{Text: "DRAFT", Color: "#ff0000, scale:1 abs"}, false},
+	{"opacity 0", RenderOptionsWatermark{Text: "DRAFT", Opacity: intPtr(0)}, true},
+	{"opacity 100", RenderOptionsWatermark{Text: "DRAFT", Opacity: intPtr(100)}, true},
+	{"opacity negative", RenderOptionsWatermark{Text: "DRAFT", Opacity: intPtr(-1)}, false},
+	{"opacity over 100", RenderOptionsWatermark{Text: "DRAFT", Opacity: intPtr(101)}, false},
+}
+
+func TestWatermarkValidate(t *testing.T) {
+	for _, d := range watermarkValidationTestData {
+		t.Run(d.name, func(t *testing.T) {
+			if err := d.wm.Validate(); (err == nil) != d.isValid {
+				t.Fatalf("watermark valid should be %v (err: %v)", d.isValid, err)
+			}
+		})
+	}
+}
+
+func TestWatermarkDefaultOpacity(t *testing.T) {
+	for body, expected := range map[string]int{`{"text":"DRAFT"}`: 100, `{"text":"DRAFT","opacity":0}`: 0, `{"text":"DRAFT","opacity":30}`: 30} {
+		wm := RenderOptionsWatermark{}
+		if err := json.Unmarshal([]byte(body), &wm); err != nil {
+			t.Fatalf("cant unmarshal %s: %v", body, err)
+		}
+
+		wm.SetDefaults()
+
+		if wm.Opacity == nil || *wm.Opacity != expected {
+			t.Fatalf("opacity of %s should be %d (curr: %v)", body, expected, wm.Opacity)
+		}
+	}
+}
@@ -1,5 +1,4 @@
-// Package docs GENERATED BY SWAG; DO NOT EDIT
-// This file was generated by swaggo/swag
+// Package docs Code generated by swaggo/swag. DO NOT EDIT
 package docs
 
 import "github.com/swaggo/swag"
@@ -25,7 +24,7 @@ const docTemplate = `{
     "paths": {
         "/api/pdf/from/html-bundle/render": {
             "post": {
-                "description": "Returns PDF file generated from bundle (Zip-File) of HTML or HTML template of body, header, footer and assets. The index.html file in the Zip-Bundle is required",
+                "description": "Returns PDF file generated from bundle (Zip-File) of HTML or HTML template of body, header, footer and assets. The index.html (or index.md for Markdown with the themes of the themes directory) file in the Zip-Bundle is required. The model is validated against the optional schema.json (JSON Schema) of the bundle",
                 "consumes": [
                     "multipart/form-data"
                 ],
@@ -55,11 +54,77 @@ const docTemplate = `{
                         "description": "Template engine to use for template (only required for template)",
                         "name": "templateEngine",
                         "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Files to embed into the PDF (e.g. factur-x.xml)",
+                        "name": "attachments",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return the base64 encoded PDF with page count, page sizes, stage timings and warnings as JSON (RenderResult)",
+                        "name": "envelope",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "PDF File"
+                        "description": "PDF File (or RenderResult as JSON with envelope)",
+                        "headers": {
+                            "X-PdfTurtle-Page-Count": {
+                                "type": "integer",
+                                "description": "Number of pages"
+                            },
+                            "X-PdfTurtle-Render-Duration-Ms": {
+                                "type": "integer",
+                                "description": "Time since the request was received in ms"
+                            },
+                            "X-PdfTurtle-Size": {
+                                "type": "integer",
+                                "description": "Size of the PDF in bytes"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Model does not match the schema.json of the bundle",
+                        "schema": {
+                            "$ref": "#/definitions/ModelValidationError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/pdf/from/html-template/contract": {
+            "post": {
+                "description": "Returns the model paths referenced by the body, header and footer templates (including loops and conditions) as list and JSON Schema. If a model is given, the missing and unused fields of the model are returned.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Render HTML-Template"
+                ],
+                "summary": "Model contract of HTML template",
+                "parameters": [
+                    {
+                        "description": "Render Data",
+                        "name": "renderTemplateData",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/RenderTemplateData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ModelContract"
+                        }
                     }
                 }
             }
@@ -86,18 +151,44 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/RenderTemplateData"
                         }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return the base64 encoded PDF with page count, page sizes, stage timings and warnings as JSON (RenderResult)",
+                        "name": "envelope",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "PDF File"
+                        "description": "PDF File (or RenderResult as JSON with envelope)",
+                        "headers": {
+                            "X-PdfTurtle-Page-Count": {
+                                "type": "integer",
+                                "description": "Number of pages"
+                            },
+                            "X-PdfTurtle-Render-Duration-Ms": {
+                                "type": "integer",
+                                "description": "Time since the request was received in ms"
+                            },
+                            "X-PdfTurtle-Size": {
+                                "type": "integer",
+                                "description": "Size of the PDF in bytes"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Model does not match the modelSchema",
+                        "schema": {
+                            "$ref": "#/definitions/ModelValidationError"
+                        }
                     }
                 }
             }
         },
         "/api/pdf/from/html-template/test": {
             "post": {
-                "description": "Returns information about matching model data to template",
+                "description": "Returns the issues of the body, header and footer templates with the model as diagnostics with line, column and source excerpt",
                 "consumes": [
                     "application/json"
                 ],
@@ -151,11 +242,82 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/RenderData"
                         }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return the base64 encoded PDF with page count, page sizes, stage timings and warnings as JSON (RenderResult)",
+                        "name": "envelope",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "PDF File (or RenderResult as JSON with envelope)",
+                        "headers": {
+                            "X-PdfTurtle-Page-Count": {
+                                "type": "integer",
+                                "description": "Number of pages"
+                            },
+                            "X-PdfTurtle-Render-Duration-Ms": {
+                                "type": "integer",
+                                "description": "Time since the request was received in ms"
+                            },
+                            "X-PdfTurtle-Size": {
+                                "type": "integer",
+                                "description": "Size of the PDF in bytes"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/pdf/from/markdown/render": {
+            "post": {
+                "description": "Returns PDF file generated from Markdown (GitHub flavored with footnotes and heading anchors) wrapped in a theme. The front matter (title, author, subject, keywords, theme and options) overrides the theme and the options of the request",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "Render Markdown"
+                ],
+                "summary": "Render PDF from Markdown",
+                "parameters": [
+                    {
+                        "description": "Render Markdown Data",
+                        "name": "renderMarkdownData",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/RenderMarkdownData"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return the base64 encoded PDF with page count, page sizes, stage timings and warnings as JSON (RenderResult)",
+                        "name": "envelope",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "PDF File"
+                        "description": "PDF File (or RenderResult as JSON with envelope)",
+                        "headers": {
+                            "X-PdfTurtle-Page-Count": {
+                                "type": "integer",
+                                "description": "Number of pages"
+                            },
+                            "X-PdfTurtle-Render-Duration-Ms": {
+                                "type": "integer",
+                                "description": "Time since the request was received in ms"
+                            },
+                            "X-PdfTurtle-Size": {
+                                "type": "integer",
+                                "description": "Size of the PDF in bytes"
+                            }
+                        }
                     }
                 }
             }
@@ -174,13 +336,133 @@ const docTemplate = `{
                 "summary": "Liveness probe for this service",
                 "responses": {
                     "200": {
-                        "description": ""
+                        "description": "OK"
                     }
                 }
             }
         }
     },
     "definitions": {
+        "Attachment": {
+            "type": "object",
+            "properties": {
+                "bundleFile": {
+                    "description": "path of the file inside of the bundle used as content (only for bundles)",
+                    "type": "string",
+                    "example": "factur-x.xml"
+                },
+                "content": {
+                    "description": "base64 encoded file content",
+                    "type": "string",
+                    "format": "base64"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Factur-X invoice"
+                },
+                "isTemplate": {
+                    "description": "true if the content should be rendered with the template engine and model before embedding",
+                    "type": "boolean",
+                    "default": false
+                },
+                "mimeType": {
+                    "description": "mime type of the embedded file; detected by file extension if empty",
+                    "type": "string",
+                    "example": "text/xml"
+                },
+                "name": {
+                    "description": "file name of the embedded file",
+                    "type": "string",
+                    "example": "factur-x.xml"
+                },
+                "relationship": {
+                    "description": "relationship of the embedded file to the document (PDF/A-3 AFRelationship); derived from the e-invoice profile if empty",
+                    "type": "string",
+                    "enum": [
+                        "Source",
+                        "Data",
+                        "Alternative",
+                        "Supplement",
+                        "Unspecified"
+                    ]
+                }
+            }
+        },
+        "ModelContract": {
+            "type": "object",
+            "properties": {
+                "missingFields": {
+                    "description": "required paths missing in the given model",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "paths": {
+                    "description": "all model paths referenced by the templates",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ModelPath"
+                    }
+                },
+                "schema": {
+                    "description": "JSON Schema (draft 2020-12) of the model derived from the paths",
+                    "type": "object"
+                },
+                "unusedFields": {
+                    "description": "paths of the given model not referenced by the templates",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "ModelPath": {
+            "type": "object",
+            "properties": {
+                "optional": {
+                    "description": "only tested by conditions (if / with), so the value can be missing",
+                    "type": "boolean"
+                },
+                "path": {
+                    "description": "dot separated path; [] marks the items of a list (e.g. items[].price)",
+                    "type": "string",
+                    "example": "items[].price"
+                }
+            }
+        },
+        "ModelValidationError": {
+            "type": "object",
+            "properties": {
+                "msg": {
+                    "type": "string"
+                },
+                "requestId": {
+                    "type": "string"
+                },
+                "violations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ModelViolation"
+                    }
+                }
+            }
+        },
+        "ModelViolation": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "got string, want number"
+                },
+                "pointer": {
+                    "description": "JSON pointer (RFC 6901) of the value in the model",
+                    "type": "string",
+                    "example": "/items/0/price"
+                }
+            }
+        },
         "PageSize": {
             "type": "object",
             "properties": {
@@ -196,6 +478,23 @@ const docTemplate = `{
                 }
             }
         },
+        "PageVariants": {
+            "type": "object",
+            "properties": {
+                "even": {
+                    "type": "string"
+                },
+                "first": {
+                    "type": "string"
+                },
+                "last": {
+                    "type": "string"
+                },
+                "odd": {
+                    "type": "string"
+                }
+            }
+        },
         "RenderData": {
             "type": "object",
             "properties": {
@@ -204,34 +503,167 @@ const docTemplate = `{
                     "type": "string",
                     "default": "\u003cdiv class=\"default-footer\"\u003e\u003cdiv\u003e\u003cspan class=\"pageNumber\"\u003e\u003c/span\u003e of \u003cspan class=\"totalPages\"\u003e\u003c/span\u003e\u003c/div\u003e\u003c/div\u003e"
                 },
+                "footerHtmlPages": {
+                    "description": "Optional html of the footer for the first, odd, even or last pages. If empty, the variants will be parsed from main html (\u003cPdfFooter page=\"last\"\u003e\u003c/PdfFooter\u003e).",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/PageVariants"
+                        }
+                    ]
+                },
                 "headerHtml": {
                     "description": "Optional html for header. If empty, the header html will be parsed from main html (\u003cPdfHeader\u003e\u003c/PdfHeader\u003e).",
                     "type": "string",
                     "example": "\u003ch1\u003eHeading\u003c/h1\u003e"
                 },
+                "headerHtmlPages": {
+                    "description": "Optional html of the header for the first, odd, even or last pages. If empty, the variants will be parsed from main html (\u003cPdfHeader page=\"first\"\u003e\u003c/PdfHeader\u003e).",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/PageVariants"
+                        }
+                    ]
+                },
                 "html": {
                     "type": "string",
                     "example": "\u003cb\u003eHello World\u003c/b\u003e"
                 },
                 "options": {
                     "$ref": "#/definitions/RenderOptions"
+                },
+                "sections": {
+                    "description": "Optional sections rendered with their own page options and merged into one pdf. The html is used for shared styles and scripts.\nIf empty, the sections will be parsed from main html (\u003cPdfSection options='{\"landscape\": true}'\u003e\u003c/PdfSection\u003e).",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/RenderSection"
+                    }
+                }
+            }
+        },
+        "RenderMarkdownData": {
+            "type": "object",
+            "properties": {
+                "footerHtml": {
+                    "description": "Optional html for footer. If empty, the footer html will be parsed from the markdown (\u003cPdfFooter\u003e\u003c/PdfFooter\u003e).",
+                    "type": "string"
+                },
+                "headerHtml": {
+                    "description": "Optional html for header. If empty, the header html will be parsed from the markdown (\u003cPdfHeader\u003e\u003c/PdfHeader\u003e).",
+                    "type": "string"
+                },
+                "markdown": {
+                    "type": "string",
+                    "example": "# Hello World"
+                },
+                "options": {
+                    "description": "Options overridden by the options of the front matter",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/RenderOptions"
+                        }
+                    ]
+                },
+                "theme": {
+                    "description": "Builtin theme (default, github, academic or none) or a theme of the bundle; default if empty. Overridden by the theme of the front matter",
+                    "type": "string",
+                    "example": "github"
                 }
             }
         },
         "RenderOptions": {
             "type": "object",
             "properties": {
+                "attachments": {
+                    "description": "files to embed into the pdf",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Attachment"
+                    }
+                },
+                "autoMargins": {
+                    "description": "measure header and footer and set the margins top and bottom to fit; disabled if null",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/RenderOptionsAutoMargins"
+                        }
+                    ]
+                },
+                "blankPages": {
+                    "description": "blank pages for duplex printing; elements with the class page-break-before-odd always start on an odd page",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/RenderOptionsBlankPages"
+                        }
+                    ]
+                },
+                "deterministic": {
+                    "description": "normalize the dates and the file identifier, so the same input produces the same bytes; disabled if null",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/RenderOptionsDeterministic"
+                        }
+                    ]
+                },
+                "eInvoice": {
+                    "description": "embed the invoice xml as Factur-X / ZUGFeRD e-invoice (without PDF/A conformance); disabled if null",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/RenderOptionsEInvoice"
+                        }
+                    ]
+                },
                 "excludeBuiltinStyles": {
                     "type": "boolean",
                     "default": false
                 },
+                "imposition": {
+                    "description": "places the pages on larger sheets in a grid (n-up) or in booklet order; disabled if null",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/RenderOptionsImposition"
+                        }
+                    ]
+                },
                 "landscape": {
                     "type": "boolean",
                     "default": false
                 },
+                "locale": {
+                    "description": "locale of the formatting helpers in templates (dates, numbers and currencies); en-US if empty",
+                    "type": "string",
+                    "example": "de-DE"
+                },
+                "mailingMarks": {
+                    "description": "omr marks or data matrix codes for envelope inserting machines; disabled if null",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/RenderOptionsMailingMarks"
+                        }
+                    ]
+                },
                 "margins": {
                     "description": "margins in mm; fallback to default if null",
-                    "$ref": "#/definitions/RenderOptionsMargins"
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/RenderOptionsMargins"
+                        }
+                    ]
+                },
+                "metadata": {
+                    "description": "title, author, subject, keywords and creator of the document info; taken from the html if null",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/RenderOptionsMetadata"
+                        }
+                    ]
+                },
+                "outline": {
+                    "description": "pdf bookmarks generated from the headings; disabled if null",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/RenderOptionsOutline"
+                        }
+                    ]
                 },
                 "pageFormat": {
                     "type": "string",
@@ -248,9 +680,233 @@ const docTemplate = `{
                         "Legal"
                     ]
                 },
+                "pageNumbers": {
+                    "description": "page numbers for the placeholder classes pdf-page-number, pdf-total-pages, pdf-section-page-number and pdf-section-total-pages in header and footer",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/RenderOptionsPageNumbers"
+                        }
+                    ]
+                },
                 "pageSize": {
                     "description": "page size in mm; overrides page format",
-                    "$ref": "#/definitions/PageSize"
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/PageSize"
+                        }
+                    ]
+                },
+                "print": {
+                    "description": "bleed, crop marks and page boxes for print production; disabled if null",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/RenderOptionsPrint"
+                        }
+                    ]
+                },
+                "stationery": {
+                    "description": "letterhead pdf placed underneath the rendered pages; disabled if null",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/RenderOptionsStationery"
+                        }
+                    ]
+                },
+                "watermark": {
+                    "description": "text, image or html stamped on the pages after rendering; disabled if null",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/RenderOptionsWatermark"
+                        }
+                    ]
+                }
+            }
+        },
+        "RenderOptionsAutoMargins": {
+            "type": "object",
+            "properties": {
+                "max": {
+                    "description": "maximum margin top and bottom in mm",
+                    "type": "integer",
+                    "default": 100
+                },
+                "min": {
+                    "description": "minimum margin top and bottom in mm",
+                    "type": "integer",
+                    "default": 10
+                },
+                "spacing": {
+                    "description": "space between header or footer and the body in mm",
+                    "type": "integer",
+                    "default": 5
+                }
+            }
+        },
+        "RenderOptionsBlankPages": {
+            "type": "object",
+            "properties": {
+                "evenPageCount": {
+                    "description": "add a blank page at the end if the page count is odd (for sections: every section ends on an even page)",
+                    "type": "boolean"
+                },
+                "html": {
+                    "description": "optional html on the inserted blank pages",
+                    "type": "string",
+                    "example": "\u003cp\u003eThis page intentionally left blank\u003c/p\u003e"
+                }
+            }
+        },
+        "RenderOptionsDeterministic": {
+            "type": "object",
+            "properties": {
+                "timestamp": {
+                    "description": "date of the document in RFC 3339 format; 1970-01-01T00:00:00Z if empty",
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                }
+            }
+        },
+        "RenderOptionsEInvoice": {
+            "type": "object",
+            "properties": {
+                "conformanceLevel": {
+                    "description": "conformance level (profile) of the invoice xml",
+                    "type": "string",
+                    "default": "EN 16931",
+                    "enum": [
+                        "MINIMUM",
+                        "BASIC WL",
+                        "BASIC",
+                        "EN 16931",
+                        "EXTENDED",
+                        "XRECHNUNG"
+                    ]
+                },
+                "documentFileName": {
+                    "description": "file name of the attached invoice xml",
+                    "type": "string",
+                    "default": "factur-x.xml"
+                },
+                "documentType": {
+                    "type": "string",
+                    "default": "INVOICE",
+                    "enum": [
+                        "INVOICE",
+                        "ORDER",
+                        "ORDER_RESPONSE",
+                        "ORDER_CHANGE"
+                    ]
+                },
+                "version": {
+                    "description": "version of the Factur-X / ZUGFeRD xmp schema",
+                    "type": "string",
+                    "default": "1.0"
+                }
+            }
+        },
+        "RenderOptionsImposition": {
+            "type": "object",
+            "properties": {
+                "columns": {
+                    "description": "columns of the grid (ignored for booklets)",
+                    "type": "integer",
+                    "default": 2
+                },
+                "cutLines": {
+                    "description": "draw lines around the placed pages",
+                    "type": "boolean",
+                    "default": false
+                },
+                "gutter": {
+                    "description": "space between the pages in mm",
+                    "type": "integer",
+                    "default": 0
+                },
+                "layout": {
+                    "description": "grid places the pages row by row; booklet places two pages side by side in saddle-stitch order",
+                    "type": "string",
+                    "default": "grid",
+                    "enum": [
+                        "grid",
+                        "booklet"
+                    ]
+                },
+                "rows": {
+                    "description": "rows of the grid (ignored for booklets)",
+                    "type": "integer",
+                    "default": 1
+                },
+                "sheetFormat": {
+                    "type": "string",
+                    "default": "A4",
+                    "enum": [
+                        "A0",
+                        "A1",
+                        "A2",
+                        "A3",
+                        "A4",
+                        "A5",
+                        "A6",
+                        "Letter",
+                        "Legal"
+                    ]
+                },
+                "sheetLandscape": {
+                    "description": "sheet in landscape orientation",
+                    "type": "boolean",
+                    "default": false
+                },
+                "sheetSize": {
+                    "description": "sheet size in mm; overrides sheet format",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/PageSize"
+                        }
+                    ]
+                }
+            }
+        },
+        "RenderOptionsMailingMarks": {
+            "type": "object",
+            "properties": {
+                "documentIndex": {
+                    "description": "index of the document (envelope) in the batch",
+                    "type": "integer",
+                    "example": 1
+                },
+                "duplex": {
+                    "description": "marks on the front pages only (odd pages); every sheet has two pages",
+                    "type": "boolean",
+                    "default": false
+                },
+                "left": {
+                    "description": "distance of the marks from the left edge of the page (trim box with print bleed) in mm",
+                    "type": "integer",
+                    "default": 5
+                },
+                "size": {
+                    "description": "length of the omr marks or size of the 2D code in mm",
+                    "type": "integer",
+                    "default": 10
+                },
+                "spacing": {
+                    "description": "distance between the omr marks in mm",
+                    "type": "integer",
+                    "default": 4
+                },
+                "top": {
+                    "description": "distance of the first mark from the top edge of the page (trim box with print bleed) in mm",
+                    "type": "integer",
+                    "default": 100
+                },
+                "type": {
+                    "description": "omr draws horizontal marks, datamatrix a 2D code with the same information",
+                    "type": "string",
+                    "default": "omr",
+                    "enum": [
+                        "omr",
+                        "datamatrix"
+                    ]
                 }
             }
         },
@@ -279,6 +935,267 @@ const docTemplate = `{
                 }
             }
         },
+        "RenderOptionsMetadata": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string",
+                    "example": "PDF Turtle"
+                },
+                "creator": {
+                    "description": "application that created the document",
+                    "type": "string"
+                },
+                "keywords": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "release",
+                        "changelog"
+                    ]
+                },
+                "subject": {
+                    "type": "string"
+                },
+                "title": {
+                    "description": "title of the document; the title of the html is used by chromium if empty",
+                    "type": "string",
+                    "example": "Release Notes 2.0"
+                }
+            }
+        },
+        "RenderOptionsOutline": {
+            "type": "object",
+            "properties": {
+                "selector": {
+                    "description": "css selector of the elements used as bookmarks; elements with the attribute data-pdf-bookmark are always used.\nWithout selector and data-pdf-bookmark attributes the outline of all headings is generated by chromium.",
+                    "type": "string",
+                    "example": "h1, h2, .chapter"
+                }
+            }
+        },
+        "RenderOptionsPageNumbers": {
+            "type": "object",
+            "properties": {
+                "format": {
+                    "description": "format of the page numbers",
+                    "type": "string",
+                    "default": "decimal",
+                    "enum": [
+                        "decimal",
+                        "lower-roman",
+                        "upper-roman",
+                        "lower-alpha",
+                        "upper-alpha"
+                    ]
+                },
+                "start": {
+                    "description": "number of the first page (e.g. 5 if the document continues another one)",
+                    "type": "integer",
+                    "default": 1
+                }
+            }
+        },
+        "RenderOptionsPrint": {
+            "type": "object",
+            "properties": {
+                "bleed": {
+                    "description": "bleed around the page in mm; the page is rendered larger by the bleed",
+                    "type": "integer",
+                    "default": 3
+                },
+                "cropMarks": {
+                    "description": "draw crop marks at the corners of the trim box",
+                    "type": "boolean",
+                    "default": false
+                },
+                "registrationMarks": {
+                    "description": "draw registration marks at the sides of the page",
+                    "type": "boolean",
+                    "default": false
+                },
+                "slug": {
+                    "description": "space around the bleed for the marks in mm",
+                    "type": "integer",
+                    "default": 10
+                }
+            }
+        },
+        "RenderOptionsStationery": {
+            "type": "object",
+            "properties": {
+                "firstPagePdf": {
+                    "description": "base64 encoded pdf placed underneath the first page instead of the stationery pdf",
+                    "type": "string",
+                    "format": "base64"
+                },
+                "pdf": {
+                    "description": "base64 encoded pdf placed underneath every page; the first page of the pdf is used",
+                    "type": "string",
+                    "format": "base64"
+                }
+            }
+        },
+        "RenderOptionsWatermark": {
+            "type": "object",
+            "properties": {
+                "background": {
+                    "description": "stamp behind the page content instead of on top",
+                    "type": "boolean",
+                    "default": false
+                },
+                "bundleFile": {
+                    "description": "path of an image inside of the bundle (only for bundles)",
+                    "type": "string",
+                    "example": "assets/logo.png"
+                },
+                "color": {
+                    "description": "color of text stamps as hex value",
+                    "type": "string",
+                    "default": "#808080",
+                    "example": "#ff0000"
+                },
+                "fontName": {
+                    "description": "font for text stamps (only Helvetica, Times-Roman and Courier)",
+                    "type": "string",
+                    "default": "Helvetica",
+                    "enum": [
+                        "Helvetica",
+                        "Times-Roman",
+                        "Courier"
+                    ]
+                },
+                "fontSize": {
+                    "description": "font size of text stamps in pt",
+                    "type": "integer",
+                    "default": 48
+                },
+                "html": {
+                    "description": "html snippet rendered transparent with the page size of the document",
+                    "type": "string",
+                    "example": "\u003cdiv style=\"position: absolute; bottom: 10mm; right: 10mm\"\u003eCOPY\u003c/div\u003e"
+                },
+                "image": {
+                    "description": "base64 encoded image (png, jpeg or tiff)",
+                    "type": "string",
+                    "format": "base64"
+                },
+                "offsetX": {
+                    "description": "horizontal offset from the position in mm",
+                    "type": "integer"
+                },
+                "offsetY": {
+                    "description": "vertical offset from the position in mm",
+                    "type": "integer"
+                },
+                "opacity": {
+                    "description": "opacity in percent (0 to 100); 0 is a valid (invisible) value",
+                    "type": "integer",
+                    "default": 100,
+                    "example": 30
+                },
+                "pages": {
+                    "description": "pages to stamp (e.g. \"1\", \"2-4\", \"odd\", \"even\", \"1,3-5\", \"!1\" or \"l\" for the last page); all pages if empty",
+                    "type": "string",
+                    "example": "1-3"
+                },
+                "position": {
+                    "type": "string",
+                    "default": "center",
+                    "enum": [
+                        "top-left",
+                        "top-center",
+                        "top-right",
+                        "left",
+                        "center",
+                        "right",
+                        "bottom-left",
+                        "bottom-center",
+                        "bottom-right"
+                    ]
+                },
+                "rotation": {
+                    "description": "rotation in degrees counter clockwise (-180 to 180)",
+                    "type": "integer",
+                    "example": 45
+                },
+                "scale": {
+                    "description": "width of text and image stamps relative to the page width in percent",
+                    "type": "integer",
+                    "default": 50
+                },
+                "text": {
+                    "description": "text of the stamp (e.g. DRAFT or CONFIDENTIAL); use \\n for multiple lines",
+                    "type": "string",
+                    "example": "DRAFT"
+                }
+            }
+        },
+        "RenderSection": {
+            "type": "object",
+            "properties": {
+                "footerHtml": {
+                    "description": "Optional html for the footer of the section. If empty, the footer html will be parsed from the section html or the footer of the document is used.",
+                    "type": "string"
+                },
+                "headerHtml": {
+                    "description": "Optional html for the header of the section. If empty, the header html will be parsed from the section html or the header of the document is used.",
+                    "type": "string"
+                },
+                "html": {
+                    "type": "string",
+                    "example": "\u003ctable\u003e...\u003c/table\u003e"
+                },
+                "options": {
+                    "description": "page options of the section; fallback to the options of the document if null",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/RenderSectionOptions"
+                        }
+                    ]
+                }
+            }
+        },
+        "RenderSectionOptions": {
+            "type": "object",
+            "properties": {
+                "landscape": {
+                    "type": "boolean"
+                },
+                "margins": {
+                    "description": "margins in mm; fallback to the margins of the document if null",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/RenderOptionsMargins"
+                        }
+                    ]
+                },
+                "pageFormat": {
+                    "type": "string",
+                    "enum": [
+                        "A0",
+                        "A1",
+                        "A2",
+                        "A3",
+                        "A4",
+                        "A5",
+                        "A6",
+                        "Letter",
+                        "Legal"
+                    ]
+                },
+                "pageSize": {
+                    "description": "page size in mm; overrides page format",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/PageSize"
+                        }
+                    ]
+                }
+            }
+        },
         "RenderTemplateData": {
             "type": "object",
             "properties": {
@@ -286,10 +1203,26 @@ const docTemplate = `{
                     "description": "Optional template for footer. If empty, the footer template will be parsed from main template (\u003cPdfFooter\u003e\u003c/PdfFooter\u003e).",
                     "type": "string"
                 },
+                "footerHtmlTemplatePages": {
+                    "description": "Optional templates of the footer for the first, odd, even or last pages.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/PageVariants"
+                        }
+                    ]
+                },
                 "headerHtmlTemplate": {
                     "description": "Optional template for header. If empty, the header template will be parsed from main template (\u003cPdfHeader\u003e\u003c/PdfHeader\u003e).",
                     "type": "string"
                 },
+                "headerHtmlTemplatePages": {
+                    "description": "Optional templates of the header for the first, odd, even or last pages.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/PageVariants"
+                        }
+                    ]
+                },
                 "htmlTemplate": {
                     "type": "string"
                 },
@@ -297,6 +1230,10 @@ const docTemplate = `{
                     "description": "Model with your data matching to the templates",
                     "type": "object"
                 },
+                "modelSchema": {
+                    "description": "Optional JSON Schema to validate the model before templating (violations are returned with status 422)",
+                    "type": "object"
+                },
                 "options": {
                     "$ref": "#/definitions/RenderOptions"
                 },
@@ -306,8 +1243,50 @@ const docTemplate = `{
                     "enum": [
                         "golang",
                         "handlebars",
-                        "django"
+                        "django",
+                        "liquid"
+                    ]
+                }
+            }
+        },
+        "TemplateDiagnostic": {
+            "type": "object",
+            "properties": {
+                "column": {
+                    "description": "column of the issue starting with 1; omitted if unknown",
+                    "type": "integer",
+                    "example": 8
+                },
+                "excerpt": {
+                    "description": "lines around the issue with a marker under the column",
+                    "type": "string"
+                },
+                "line": {
+                    "description": "line of the issue starting with 1; omitted if unknown",
+                    "type": "integer",
+                    "example": 12
+                },
+                "message": {
+                    "description": "error message of the template engine",
+                    "type": "string"
+                },
+                "modelPath": {
+                    "description": "path of the model value missing for the template; only set by the golang and liquid engines (handlebars and django render missing values empty without error)",
+                    "type": "string",
+                    "example": "company.name"
+                },
+                "severity": {
+                    "description": "errors fail the rendering, warnings (e.g. missing model values) render empty values",
+                    "type": "string",
+                    "enum": [
+                        "error",
+                        "warning"
                     ]
+                },
+                "template": {
+                    "description": "template with the issue: body, header, footer or a page variant (e.g. header.first)",
+                    "type": "string",
+                    "example": "body"
                 }
             }
         },
@@ -315,15 +1294,26 @@ const docTemplate = `{
             "type": "object",
             "properties": {
                 "bodyTemplateError": {
+                    "description": "message of the first diagnostic of the body",
                     "type": "string"
                 },
+                "diagnostics": {
+                    "description": "all issues of the templates with position and source excerpt",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/TemplateDiagnostic"
+                    }
+                },
                 "footerTemplateError": {
+                    "description": "message of the first diagnostic of the footer or its page variants",
                     "type": "string"
                 },
                 "headerTemplateError": {
+                    "description": "message of the first diagnostic of the header or its page variants",
                     "type": "string"
                 },
                 "isValid": {
+                    "description": "false if there is any diagnostic (including warnings for missing model values)",
                     "type": "boolean"
                 }
             }
@@ -341,6 +1331,8 @@ var SwaggerInfo = &swag.Spec{
 	Description:      "A painless HTML to PDF rendering service. Generate PDF reports and documents from HTML templates or raw HTML.",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
+	LeftDelim:        "{{",
+	RightDelim:       "}}",
 }
 
 func init() {
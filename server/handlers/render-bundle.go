@@ -14,18 +14,25 @@ const (
 	formDataKeyBundle         = "bundle"
 	formDataKeyModel          = "model"
 	formDataKeyTemplateEngine = "templateEngine"
+	formDataKeyAttachments    = "attachments"
 )
 
 // RenderBundleHandler godoc
 // @Summary      Render PDF from bundle including HTML(-Template) with model and assets provided in form-data (keys: bundle, model)
-// @Description  Returns PDF file generated from bundle (Zip-File) of HTML or HTML template of body, header, footer and assets. The index.html file in the Zip-Bundle is required
+// @Description  Returns PDF file generated from bundle (Zip-File) of HTML or HTML template of body, header, footer and assets. The index.html (or index.md for Markdown with the themes of the themes directory) file in the Zip-Bundle is required. The model is validated against the optional schema.json (JSON Schema) of the bundle
 // @Tags         Render HTML-Bundle
 // @Accept       multipart/form-data
 // @Produce      application/pdf
 // @Param        bundle          formData  file    true   "Bundle Zip-File"
 // @Param        model           formData  string  false  "JSON-Model for template (only required for template)"
 // @Param        templateEngine  formData  string  false  "Template engine to use for template (only required for template)"
-// @Success      200             "PDF File"
+// @Param        attachments     formData  file    false  "Files to embed into the PDF (e.g. factur-x.xml)"
+// @Param        envelope        query     bool    false  "Return the base64 encoded PDF with page count, page sizes, stage timings and warnings as JSON (RenderResult)"
+// @Success      200             "PDF File (or RenderResult as JSON with envelope)"
+// @Header       200             {integer}  X-PdfTurtle-Page-Count          "Number of pages"
+// @Header       200             {integer}  X-PdfTurtle-Render-Duration-Ms  "Time since the request was received in ms"
+// @Header       200             {integer}  X-PdfTurtle-Size                "Size of the PDF in bytes"
+// @Failure      422             {object}   dto.ModelValidationError        "Model does not match the schema.json of the bundle"
 // @Router       /api/pdf/from/html-bundle/render [post]
 func RenderBundleHandler(c fiber.Ctx) error {
 	ctx := c.Context()
@@ -64,6 +71,12 @@ func RenderBundleHandler(c fiber.Ctx) error {
 		}
 	}
 
+	for _, fa := range form.File[formDataKeyAttachments] {
+		bundle.AddFile(bundles.BundleAttachmentsDir+fa.Filename, &bundles.OpenerFileProxy{
+			MultipartFileOpener: fa,
+		})
+	}
+
 	err = bundle.TestIndexFile()
 	if err != nil {
 		return err
@@ -77,7 +90,7 @@ func RenderBundleHandler(c fiber.Ctx) error {
 	pdfData, errRender := pdfService.PdfFromBundle(bundle, jsonModel, templateEngine)
 
 	if errRender != nil {
-		return errRender
+		return handleRenderError(c, errRender)
 	}
 
 	return writePdf(c, pdfData)
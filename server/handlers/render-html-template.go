@@ -1,12 +1,15 @@
 package handlers
 
 import (
+	"cmp"
+	"strings"
+
 	"github.com/gofiber/fiber/v3"
 	"github.com/lucas-gaitzsch/pdf-turtle/models"
 	"github.com/lucas-gaitzsch/pdf-turtle/models/dto"
 
 	"github.com/lucas-gaitzsch/pdf-turtle/services/pdf"
-	"github.com/lucas-gaitzsch/pdf-turtle/services/templating/templateengines"
+	"github.com/lucas-gaitzsch/pdf-turtle/services/templating"
 )
 
 // RenderPdfFromHtmlFromTemplateHandler godoc
@@ -16,7 +19,12 @@ import (
 // @Accept       json
 // @Produce      application/pdf
 // @Param        renderTemplateData  body      models.RenderTemplateData  true  "Render Data"
-// @Success      200                 "PDF File"
+// @Param        envelope            query     bool                       false  "Return the base64 encoded PDF with page count, page sizes, stage timings and warnings as JSON (RenderResult)"
+// @Success      200                 "PDF File (or RenderResult as JSON with envelope)"
+// @Header       200                 {integer}  X-PdfTurtle-Page-Count          "Number of pages"
+// @Header       200                 {integer}  X-PdfTurtle-Render-Duration-Ms  "Time since the request was received in ms"
+// @Header       200                 {integer}  X-PdfTurtle-Size                "Size of the PDF in bytes"
+// @Failure      422                 {object}   dto.ModelValidationError        "Model does not match the modelSchema"
 // @Router       /api/pdf/from/html-template/render [post]
 func RenderPdfFromHtmlFromTemplateHandler(c fiber.Ctx) error {
 	ctx := c.Context()
@@ -34,7 +42,7 @@ func RenderPdfFromHtmlFromTemplateHandler(c fiber.Ctx) error {
 	pdfData, err := pdfService.PdfFromHtmlTemplate(templateData)
 
 	if err != nil {
-		return err
+		return handleRenderError(c, err)
 	}
 
 	return writePdf(c, pdfData)
@@ -42,7 +50,7 @@ func RenderPdfFromHtmlFromTemplateHandler(c fiber.Ctx) error {
 
 // TestHtmlTemplateHandler godoc
 // @Summary      Test HTML template matching model
-// @Description  Returns information about matching model data to template
+// @Description  Returns the issues of the body, header and footer templates with the model as diagnostics with line, column and source excerpt
 // @Tags         Render HTML-Template
 // @Accept       json
 // @Produce      json
@@ -60,22 +68,55 @@ func TestHtmlTemplateHandler(c fiber.Ctx) error {
 
 		templateData.ParseJsonModelDataFromDoubleEncodedString()
 
-		templateEngine, found := templateengines.GetTemplateEngineByKey(templateData.TemplateEngine)
+		response.Diagnostics = templating.NewTemplateService().TestTemplate(templateData)
+	}
 
-		templateengines.LogParsedTemplateEngine(templateData.TemplateEngine, templateEngine, found)
+	response.IsValid = true
 
-		bodyErr := templateEngine.Test(templateData.HtmlTemplate, templateData.Model)
-		if bodyErr != nil {
-			strErr := bodyErr.Error()
-			response.BodyTemplateError = &strErr
+	// the legacy fields report warnings (missing model values) as errors like previous versions; the severity is only given in the diagnostics
+	for _, d := range response.Diagnostics {
+		response.IsValid = false
+
+		msg := d.Message
+
+		switch strings.SplitN(d.Template, ".", 2)[0] {
+		case "body":
+			response.BodyTemplateError = cmp.Or(response.BodyTemplateError, &msg)
+		case "header":
+			response.HeaderTemplateError = cmp.Or(response.HeaderTemplateError, &msg)
+		case "footer":
+			response.FooterTemplateError = cmp.Or(response.FooterTemplateError, &msg)
 		}
 	}
 
-	if response.BodyTemplateError == nil && response.HeaderTemplateError == nil && response.FooterTemplateError == nil {
-		response.IsValid = true
-	} else {
-		response.IsValid = false
+	return c.JSON(response)
+}
+
+// ModelContractHtmlTemplateHandler godoc
+// @Summary      Model contract of HTML template
+// @Description  Returns the model paths referenced by the body, header and footer templates (including loops and conditions) as list and JSON Schema. If a model is given, the missing and unused fields of the model are returned.
+// @Tags         Render HTML-Template
+// @Accept       json
+// @Produce      json
+// @Param        renderTemplateData  body  models.RenderTemplateData  true  "Render Data"
+// @Success      200                 {object}  models.ModelContract
+// @Router       /api/pdf/from/html-template/contract [post]
+func ModelContractHtmlTemplateHandler(c fiber.Ctx) error {
+	templateData := &models.RenderTemplateData{}
+
+	err := c.Bind().Body(templateData)
+
+	if err != nil {
+		return err
 	}
 
-	return c.JSON(response)
+	templateData.ParseJsonModelDataFromDoubleEncodedString()
+
+	contract, err := templating.NewTemplateService().GetModelContract(templateData)
+
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(contract)
 }
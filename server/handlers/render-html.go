@@ -16,7 +16,11 @@ const TemplateEngineQueryKey = "template-engine"
 // @Accept       json
 // @Produce      application/pdf
 // @Param        renderData  body  models.RenderData  true  "Render Data"
-// @Success      200         "PDF File"
+// @Param        envelope    query  bool               false  "Return the base64 encoded PDF with page count, page sizes, stage timings and warnings as JSON (RenderResult)"
+// @Success      200         "PDF File (or RenderResult as JSON with envelope)"
+// @Header       200         {integer}  X-PdfTurtle-Page-Count          "Number of pages"
+// @Header       200         {integer}  X-PdfTurtle-Render-Duration-Ms  "Time since the request was received in ms"
+// @Header       200         {integer}  X-PdfTurtle-Size                "Size of the PDF in bytes"
 // @Router       /api/pdf/from/html/render [post]
 func RenderPdfFromHtmlHandler(c fiber.Ctx) error {
 	ctx := c.Context()
@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v3"
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/services/pdf"
+)
+
+// RenderPdfFromMarkdownHandler godoc
+// @Summary      Render PDF from Markdown
+// @Description  Returns PDF file generated from Markdown (GitHub flavored with footnotes and heading anchors) wrapped in a theme. The front matter (title, author, subject, keywords, theme and options) overrides the theme and the options of the request
+// @Tags         Render Markdown
+// @Accept       json
+// @Produce      application/pdf
+// @Param        renderMarkdownData  body   models.RenderMarkdownData  true   "Render Markdown Data"
+// @Param        envelope            query  bool                       false  "Return the base64 encoded PDF with page count, page sizes, stage timings and warnings as JSON (RenderResult)"
+// @Success      200                 "PDF File (or RenderResult as JSON with envelope)"
+// @Header       200                 {integer}  X-PdfTurtle-Page-Count          "Number of pages"
+// @Header       200                 {integer}  X-PdfTurtle-Render-Duration-Ms  "Time since the request was received in ms"
+// @Header       200                 {integer}  X-PdfTurtle-Size                "Size of the PDF in bytes"
+// @Router       /api/pdf/from/markdown/render [post]
+func RenderPdfFromMarkdownHandler(c fiber.Ctx) error {
+	ctx := c.Context()
+
+	data := &models.RenderMarkdownData{}
+
+	if err := c.Bind().Body(data); err != nil {
+		return err
+	}
+
+	pdfService := pdf.NewPdfService(ctx)
+
+	pdfData, err := pdfService.PdfFromMarkdown(data)
+	if err != nil {
+		return err
+	}
+
+	return writePdf(c, pdfData)
+}
@@ -3,13 +3,33 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
+	"strconv"
 
 	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/lucas-gaitzsch/pdf-turtle/config"
+	"github.com/lucas-gaitzsch/pdf-turtle/models/dto"
+	"github.com/lucas-gaitzsch/pdf-turtle/services/modelcontract"
+	"github.com/lucas-gaitzsch/pdf-turtle/services/postprocessing"
+	"github.com/lucas-gaitzsch/pdf-turtle/utils/logging"
 	"github.com/rs/zerolog/log"
 )
 
+const (
+	// return the pdf with page count, page sizes, stage timings and warnings as json
+	EnvelopeQueryKey = "envelope"
+
+	HeaderPageCount      = "X-PdfTurtle-Page-Count"
+	HeaderRenderDuration = "X-PdfTurtle-Render-Duration-Ms"
+	HeaderSize           = "X-PdfTurtle-Size"
+)
+
+// ResultHeaders are exposed to browser clients by cors
+var ResultHeaders = []string{HeaderPageCount, HeaderRenderDuration, HeaderSize}
+
 func writePdf(c fiber.Ctx, data io.Reader) error {
 	ctx := c.Context()
 
@@ -18,10 +38,71 @@ func writePdf(c fiber.Ctx, data io.Reader) error {
 		return c.SendStatus(http.StatusNoContent)
 	}
 
+	pdf, err := io.ReadAll(data)
+	if err != nil {
+		return err
+	}
+
+	result := getRenderResult(ctx, pdf)
+
+	c.Set(HeaderPageCount, strconv.Itoa(result.PageCount))
+	c.Set(HeaderRenderDuration, strconv.FormatInt(result.RenderDurationMs, 10))
+	c.Set(HeaderSize, strconv.Itoa(result.Size))
+
+	if fiber.Query[bool](c, EnvelopeQueryKey) {
+		return c.JSON(result)
+	}
+
 	c.Set("Content-type", "application/pdf")
 	c.Set("Content-disposition", "attachment; filename=\"document.pdf\"")
 
-	return c.SendStream(data)
+	return c.Send(pdf)
+}
+
+func getRenderResult(ctx context.Context, pdf []byte) dto.RenderResult {
+	result := dto.RenderResult{
+		Pdf:      pdf,
+		Size:     len(pdf),
+		Stages:   []dto.RenderStage{},
+		Warnings: []string{},
+	}
+
+	pageSizes, err := postprocessing.GetPageSizes(pdf)
+	if err == nil {
+		result.PageCount = len(pageSizes)
+		result.PageSizes = pageSizes
+	} else {
+		log.Ctx(ctx).Warn().Err(err).Msg("cant read page sizes of the pdf")
+	}
+
+	if report := logging.GetReport(ctx); report != nil {
+		result.RenderDurationMs = report.Duration().Milliseconds()
+		result.Warnings = append(result.Warnings, report.Warnings()...)
+
+		for _, stage := range report.Stages() {
+			result.Stages = append(result.Stages, dto.RenderStage{Name: stage.Name, DurationMs: stage.Duration.Milliseconds()})
+		}
+	}
+
+	return result
+}
+
+// handleRenderError responds with status 422 and the violations if the model does not match the schema
+func handleRenderError(c fiber.Ctx, err error) error {
+	var validationErr *modelcontract.ValidationError
+	if !errors.As(err, &validationErr) {
+		return err
+	}
+
+	ctx := c.Context()
+
+	log.Ctx(ctx).Info().Int("violations", len(validationErr.Violations)).Msg("model does not match the schema")
+
+	return c.Status(http.StatusUnprocessableEntity).JSON(dto.ModelValidationError{
+		Msg:        "model does not match the schema",
+		Violations: validationErr.Violations,
+		RequestId:  ctx.Value(config.ContextKeyRequestId).(uuid.UUID).String(),
+	})
 }
 
 func writeJson(ctx context.Context, w http.ResponseWriter, data any) error {
@@ -51,6 +51,7 @@ func (s *Server) Serve(ctx context.Context) {
 			AllowOrigins:     []string{"*"},
 			AllowHeaders:     []string{"*"},
 			AllowMethods:     []string{http.MethodGet, http.MethodPost},
+			ExposeHeaders:    handlers.ResultHeaders,
 			AllowCredentials: false,
 		}),
 		recover.New(),
@@ -87,9 +88,15 @@ func (s *Server) Serve(ctx context.Context) {
 	api.Post("/pdf/from/html-template/test", handlers.TestHtmlTemplateHandler).
 		Name("Test HTML template")
 
+	api.Post("/pdf/from/html-template/contract", handlers.ModelContractHtmlTemplateHandler).
+		Name("Model contract of HTML template")
+
 	api.Post("/pdf/from/html-bundle/render", handlers.RenderBundleHandler).
 		Name("Render PDF from HTML-Bundle")
 
+	api.Post("/pdf/from/markdown/render", handlers.RenderPdfFromMarkdownHandler).
+		Name("Render PDF from Markdown")
+
 	// Swagger
 	app.Get("/swagger/*", swaggo.HandlerDefault)
 
@@ -11,6 +11,7 @@ import (
 	"github.com/gofiber/fiber/v3"
 	"github.com/lucas-gaitzsch/pdf-turtle/config"
 	"github.com/lucas-gaitzsch/pdf-turtle/models/dto"
+	"github.com/lucas-gaitzsch/pdf-turtle/utils/logging"
 
 	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
@@ -58,6 +59,7 @@ func RequestLoggingMiddleware() func(c fiber.Ctx) error {
 
 		ctx = requestLogger.WithContext(ctx)
 		ctx = context.WithValue(ctx, config.ContextKeyRequestId, requestUUID)
+		ctx = logging.ContextWithReport(ctx)
 
 		defer func(begin time.Time) {
 			status := c.Response().StatusCode()
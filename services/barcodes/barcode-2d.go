@@ -52,3 +52,20 @@ func (bc *Barcode2D) Svg() string {
 
 	return sb.String()
 }
+
+// Modules returns the dark modules row by row from the top left
+func (bc *Barcode2D) Modules() [][]bool {
+	bounds := bc.data.Bounds()
+
+	modules := make([][]bool, bounds.Dy())
+
+	for y := range modules {
+		modules[y] = make([]bool, bounds.Dx())
+
+		for x := range modules[y] {
+			modules[y][x] = bc.data.At(bounds.Min.X+x, bounds.Min.Y+y) == color.Black
+		}
+	}
+
+	return modules
+}
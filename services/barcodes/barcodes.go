@@ -2,6 +2,7 @@ package barcodes
 
 import (
 	"github.com/boombuler/barcode"
+	"github.com/boombuler/barcode/datamatrix"
 	"github.com/boombuler/barcode/ean"
 	"github.com/boombuler/barcode/qr"
 )
@@ -35,3 +36,19 @@ func NewEanCode(content string) (BarcodeSvg, error) {
 func NewQrCode(content string) (BarcodeSvg, error) {
 	return NewBarcodeSvg(func() (barcode.Barcode, error) { return qr.Encode(content, qr.L, qr.Auto) })
 }
+
+func NewDataMatrixCode(content string) (BarcodeSvg, error) {
+	return NewDataMatrix(content)
+}
+
+// NewDataMatrix returns the data matrix code with access to the modules (e.g. for drawing into a pdf)
+func NewDataMatrix(content string) (*Barcode2D, error) {
+	bc, err := datamatrix.Encode(content)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Barcode2D{
+		data: bc,
+	}, nil
+}
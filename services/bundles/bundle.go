@@ -4,8 +4,10 @@ import (
 	"archive/zip"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"mime/multipart"
+	"slices"
 	"strings"
 
 	"github.com/lucas-gaitzsch/pdf-turtle/models"
@@ -16,6 +18,25 @@ const (
 	BundleHeaderFile  = "header.html"
 	BundleFooterFile  = "footer.html"
 	BundleOptionsFile = "options.json"
+	// markdown converted to html instead of the index.html
+	BundleMarkdownIndexFile = "index.md"
+	// JSON Schema to validate the model
+	BundleSchemaFile = "schema.json"
+	// invoice xml of an e-invoice (Factur-X / ZUGFeRD)
+	BundleEInvoiceFile = "factur-x.xml"
+	// letterhead placed underneath all pages
+	BundleStationeryFile = "stationery.pdf"
+	// letterhead placed underneath the first page
+	BundleStationeryFirstPageFile = "stationery-first.pdf"
+
+	// all files in this directory are shared templates (partials) of the body, header and footer
+	BundlePartialsDir = "partials/"
+
+	// all css files in this directory are themes of the markdown (e.g. themes/corporate.css)
+	BundleThemesDir = "themes/"
+
+	// all files in this directory are embedded into the pdf
+	BundleAttachmentsDir = "attachments/"
 )
 
 type MultipartFileOpener interface {
@@ -42,6 +63,8 @@ type BundleReader interface {
 	GetHeaderHtml() string
 	GetFooterHtml() string
 	GetOptions() models.RenderOptions
+	GetPartialNames() []string
+	GetPartial(name string) (io.ReadCloser, error)
 }
 
 type Bundle struct {
@@ -71,9 +94,14 @@ func (b *Bundle) AddFile(path string, file Opener) {
 
 	if !strings.Contains(path, "/") &&
 		path != BundleIndexFile &&
+		path != BundleMarkdownIndexFile &&
 		path != BundleHeaderFile &&
 		path != BundleFooterFile &&
-		path != BundleOptionsFile {
+		path != BundleOptionsFile &&
+		path != BundleSchemaFile &&
+		path != BundleEInvoiceFile &&
+		path != BundleStationeryFile &&
+		path != BundleStationeryFirstPageFile {
 		path = "assets/" + path
 	}
 
@@ -81,12 +109,20 @@ func (b *Bundle) AddFile(path string, file Opener) {
 }
 
 func (b *Bundle) TestIndexFile() error {
-	if _, hasIndexFile := b.files[BundleIndexFile]; !hasIndexFile {
-		return errors.New("no index.html file was found on root of bundle")
+	if _, hasIndexFile := b.files[BundleIndexFile]; !hasIndexFile && !b.IsMarkdown() {
+		return errors.New("no index.html or index.md file was found on root of bundle")
 	}
 	return nil
 }
 
+// Returns true if the bundle has an index.md but no index.html.
+func (b *Bundle) IsMarkdown() bool {
+	_, hasIndexFile := b.files[BundleIndexFile]
+	_, hasMarkdownIndexFile := b.files[BundleMarkdownIndexFile]
+
+	return hasMarkdownIndexFile && !hasIndexFile
+}
+
 func (b *Bundle) GetFileByPath(path string) (io.ReadCloser, error) {
 	f, ok := b.files[path]
 
@@ -122,6 +158,20 @@ func (b *Bundle) GetBodyHtml() *string {
 	return s
 }
 
+func (b *Bundle) GetMarkdown() string {
+	s, _ := b.GetFileAsStringByPath(BundleMarkdownIndexFile)
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// Returns the css of the markdown theme from the themes directory (e.g. corporate for themes/corporate.css).
+func (b *Bundle) GetTheme(name string) (*string, bool) {
+	css, err := b.GetFileAsStringByPath(BundleThemesDir + name + ".css")
+	return css, err == nil
+}
+
 func (b *Bundle) GetHeaderHtml() string {
 	s, _ := b.GetFileAsStringByPath(BundleHeaderFile)
 	if s == nil {
@@ -138,6 +188,25 @@ func (b *Bundle) GetFooterHtml() string {
 	return *s
 }
 
+// Returns the paths of all files in the partials directory relative to the directory (e.g. address.html).
+func (b *Bundle) GetPartialNames() []string {
+	names := []string{}
+
+	for path := range b.files {
+		if name, ok := strings.CutPrefix(path, BundlePartialsDir); ok && name != "" && !strings.HasSuffix(name, "/") {
+			names = append(names, name)
+		}
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+func (b *Bundle) GetPartial(name string) (io.ReadCloser, error) {
+	return b.GetFileByPath(BundlePartialsDir + name)
+}
+
 func (b *Bundle) GetOptions() models.RenderOptions {
 	opt := models.RenderOptions{}
 
@@ -151,5 +220,142 @@ func (b *Bundle) GetOptions() models.RenderOptions {
 
 	opt.IsBundle = true
 
+	if _, hasEInvoice := b.files[BundleEInvoiceFile]; hasEInvoice && opt.EInvoice == nil {
+		opt.EInvoice = &models.RenderOptionsEInvoice{}
+	}
+
+	if b.hasStationery() && opt.Stationery == nil {
+		opt.Stationery = &models.RenderOptionsStationery{}
+	}
+
 	return opt
 }
+
+// Returns the given attachments with the content of the referenced bundle files,
+// plus the e-invoice xml and all files of the attachments directory if not already referenced.
+// The e-invoice xml is a template like the html files.
+func (b *Bundle) GetAttachments(attachments []models.Attachment) ([]models.Attachment, error) {
+	res := make([]models.Attachment, 0, len(attachments))
+
+	for _, a := range attachments {
+		if a.BundleFile != "" {
+			content, err := b.GetFileAsStringByPath(a.BundleFile)
+			if err != nil {
+				return nil, err
+			}
+
+			a.Content = []byte(*content)
+		}
+
+		if a.Name == "" {
+			a.Name = a.BundleFile
+		}
+
+		res = append(res, a)
+	}
+
+	paths := make([]string, 0)
+	for path := range b.files {
+		if path == BundleEInvoiceFile || strings.HasPrefix(path, BundleAttachmentsDir) {
+			paths = append(paths, path)
+		}
+	}
+	slices.Sort(paths)
+
+	for _, path := range paths {
+		name := strings.TrimPrefix(path, BundleAttachmentsDir)
+
+		isReferenced := slices.ContainsFunc(res, func(a models.Attachment) bool {
+			return a.BundleFile == path || a.Name == name
+		})
+		if isReferenced {
+			continue
+		}
+
+		content, err := b.GetFileAsStringByPath(path)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, models.Attachment{
+			Name:       name,
+			Content:    []byte(*content),
+			BundleFile: path,
+			IsTemplate: path == BundleEInvoiceFile,
+		})
+	}
+
+	return res, nil
+}
+
+// Loads the image of the watermark from the referenced bundle file.
+func (b *Bundle) LoadWatermarkImage(wm *models.RenderOptionsWatermark) error {
+	if wm.BundleFile == "" {
+		return nil
+	}
+
+	f, err := b.GetFileByPath(wm.BundleFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	wm.Image, err = io.ReadAll(f)
+
+	return err
+}
+
+// Returns the decoded JSON Schema of the model or nil if the bundle has no schema.
+func (b *Bundle) GetModelSchema() (any, error) {
+	data, err := b.getOptionalFileAsBytes(BundleSchemaFile)
+	if err != nil || data == nil {
+		return nil, err
+	}
+
+	var schema any
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, fmt.Errorf("cant read %s: %w", BundleSchemaFile, err)
+	}
+
+	return schema, nil
+}
+
+func (b *Bundle) hasStationery() bool {
+	_, hasStationery := b.files[BundleStationeryFile]
+	_, hasFirstPageStationery := b.files[BundleStationeryFirstPageFile]
+
+	return hasStationery || hasFirstPageStationery
+}
+
+// Loads the stationery pdfs from the bundle if not given by the options.
+func (b *Bundle) LoadStationery(s *models.RenderOptionsStationery) error {
+	var err error
+
+	if len(s.Pdf) == 0 {
+		if s.Pdf, err = b.getOptionalFileAsBytes(BundleStationeryFile); err != nil {
+			return err
+		}
+	}
+
+	if len(s.FirstPagePdf) == 0 {
+		if s.FirstPagePdf, err = b.getOptionalFileAsBytes(BundleStationeryFirstPageFile); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (b *Bundle) getOptionalFileAsBytes(path string) ([]byte, error) {
+	if _, ok := b.files[path]; !ok {
+		return nil, nil
+	}
+
+	f, err := b.GetFileByPath(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return io.ReadAll(f)
+}
@@ -2,9 +2,13 @@ package bundles
 
 import (
 	"bytes"
+	"io"
 	"os"
+	"slices"
 	"strings"
 	"testing"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
 )
 
 const assetsTestFile = "assets/testasset.png"
@@ -133,3 +137,164 @@ func assertHasAssetAndCanReadFile(t *testing.T, b *Bundle, name string) {
 		t.Fatalf("cant read anything: %s; err: %v", name, err)
 	}
 }
+
+type stringOpener string
+
+func (s stringOpener) Open() (io.ReadCloser, error) {
+	return io.NopCloser(strings.NewReader(string(s))), nil
+}
+
+func TestGetAttachments(t *testing.T) {
+	b := getTestBundle()
+	b.AddFile(BundleEInvoiceFile, stringOpener("<invoice/>"))
+	b.AddFile(BundleAttachmentsDir+"terms.txt", stringOpener("terms"))
+	b.AddFile("data/data.json", stringOpener("{}"))
+
+	attachments, err := b.GetAttachments([]models.Attachment{{BundleFile: "data/data.json", Relationship: models.AttachmentRelationshipSource}})
+	if err != nil {
+		t.Fatalf("err should be null but is: %v", err)
+	}
+
+	if len(attachments) != 3 {
+		t.Fatalf("bundle should contain 3 attachments (curr: %d)", len(attachments))
+	}
+
+	if a := attachments[0]; a.Name != "data/data.json" || string(a.Content) != "{}" {
+		t.Fatal("referenced bundle file should be loaded as content")
+	}
+
+	if a := attachments[1]; a.Name != "terms.txt" || a.IsTemplate {
+		t.Fatal("files of attachments dir should be attached")
+	}
+
+	if a := attachments[2]; a.Name != BundleEInvoiceFile || !a.IsTemplate {
+		t.Fatal("e-invoice xml should be attached as template")
+	}
+}
+
+func TestGetAttachmentsMissingFile(t *testing.T) {
+	b := getTestBundle()
+
+	_, err := b.GetAttachments([]models.Attachment{{BundleFile: "missing.xml"}})
+	if err == nil {
+		t.Fatal("missing bundle file should fail")
+	}
+}
+
+func TestGetOptionsEnablesEInvoice(t *testing.T) {
+	b := getTestBundle()
+	b.AddFile(BundleEInvoiceFile, stringOpener("<invoice/>"))
+
+	if opt := b.GetOptions(); opt.EInvoice == nil {
+		t.Fatal("e-invoice should be enabled by invoice xml in bundle")
+	}
+}
+
+func TestLoadWatermarkImage(t *testing.T) {
+	b := &Bundle{}
+	b.AddFile("logo.png", stringOpener("png"))
+
+	wm := &models.RenderOptionsWatermark{BundleFile: "assets/logo.png"}
+
+	if err := b.LoadWatermarkImage(wm); err != nil {
+		t.Fatalf("err should be nil: %v", err)
+	}
+
+	if string(wm.Image) != "png" {
+		t.Fatal("image of watermark was not loaded from bundle")
+	}
+
+	if err := b.LoadWatermarkImage(&models.RenderOptionsWatermark{BundleFile: "assets/missing.png"}); err == nil {
+		t.Fatal("missing bundle file should fail")
+	}
+}
+
+func TestLoadStationery(t *testing.T) {
+	b := &Bundle{}
+	b.AddFile(BundleIndexFile, stringOpener("<b>test</b>"))
+	b.AddFile(BundleOptionsFile, stringOpener("{}"))
+	b.AddFile(BundleStationeryFirstPageFile, stringOpener("first"))
+
+	opt := b.GetOptions()
+	if opt.Stationery == nil {
+		t.Fatal("stationery should be enabled by the stationery file")
+	}
+
+	if err := b.LoadStationery(opt.Stationery); err != nil {
+		t.Fatalf("err should be nil: %v", err)
+	}
+
+	if string(opt.Stationery.FirstPagePdf) != "first" || opt.Stationery.Pdf != nil {
+		t.Fatal("only the first page stationery should be loaded")
+	}
+}
+
+func TestGetModelSchema(t *testing.T) {
+	b := getTestBundle()
+
+	if schema, err := b.GetModelSchema(); schema != nil || err != nil {
+		t.Fatalf("bundle without schema should return nil (curr: %v, %v)", schema, err)
+	}
+
+	b.AddFile(BundleSchemaFile, stringOpener(`{"type": "object", "required": ["name"]}`))
+
+	schema, err := b.GetModelSchema()
+	if err != nil {
+		t.Fatalf("err should be nil: %v", err)
+	}
+
+	if s, ok := schema.(map[string]any); !ok || s["type"] != "object" {
+		t.Fatalf("schema should be decoded (curr: %v)", schema)
+	}
+
+	b.AddFile(BundleSchemaFile, stringOpener(`{"type":`))
+
+	if _, err := b.GetModelSchema(); err == nil {
+		t.Fatal("invalid json should fail")
+	}
+}
+
+func TestGetPartials(t *testing.T) {
+	b := getTestBundle()
+	b.AddFile(BundlePartialsDir, stringOpener(""))
+	b.AddFile(BundlePartialsDir+"blocks/address.html", stringOpener("<address/>"))
+	b.AddFile(BundlePartialsDir+"styles.html", stringOpener("<style/>"))
+
+	if names := b.GetPartialNames(); !slices.Equal(names, []string{"blocks/address.html", "styles.html"}) {
+		t.Fatalf("partial names should be relative to the partials dir (curr: %v)", names)
+	}
+
+	r, err := b.GetPartial("blocks/address.html")
+	if err != nil {
+		t.Fatalf("err should be nil: %v", err)
+	}
+	r.Close()
+}
+
+func TestMarkdownBundle(t *testing.T) {
+	b := &Bundle{}
+	b.AddFile(BundleMarkdownIndexFile, stringOpener("# Hello"))
+	b.AddFile(BundleThemesDir+"corporate.css", stringOpener(".markdown-body {}"))
+
+	if err := b.TestIndexFile(); err != nil || !b.IsMarkdown() {
+		t.Fatalf("bundle with index.md should be a markdown bundle (err: %v)", err)
+	}
+
+	if md := b.GetMarkdown(); md != "# Hello" {
+		t.Fatalf("markdown should be read from index.md (curr: %s)", md)
+	}
+
+	if css, found := b.GetTheme("corporate"); !found || *css != ".markdown-body {}" {
+		t.Fatal("theme should be read from the themes dir")
+	}
+
+	if _, found := b.GetTheme("github"); found {
+		t.Fatal("missing theme should not be found")
+	}
+
+	b.AddFile(BundleIndexFile, stringOpener("<p>html</p>"))
+
+	if b.IsMarkdown() {
+		t.Fatal("index.html should be preferred")
+	}
+}
@@ -0,0 +1,109 @@
+package charts
+
+// Bar draws the series as bars next to each other per category
+func Bar(data any, options *Options) (string, error) {
+	return drawBars(data, options, false)
+}
+
+// StackedBar draws the series as one bar per category; negative values are stacked below the axis
+func StackedBar(data any, options *Options) (string, error) {
+	return drawBars(data, options, true)
+}
+
+func drawBars(data any, options *Options, stacked bool) (string, error) {
+	opt := options.withDefaults(600, 300)
+
+	d, err := readData(data, opt)
+	if err != nil {
+		return "", err
+	}
+
+	lo, hi := d.valueRange(stacked, true)
+	s := newScale(lo, hi, opt)
+
+	c := newCanvas(opt)
+
+	if opt.hasLegend(len(d.series) > 1) {
+		c.legend(seriesNames(d))
+	}
+
+	if opt.ValueLabels {
+		// space for the labels above the highest value
+		c.top += float64(opt.FontSize)
+	}
+
+	if opt.hasAxes() {
+		c.axes(s, d.labels)
+	}
+
+	base := s.baseline()
+
+	for i := range d.labels {
+		bandX, bandW := c.band(i, len(d.labels))
+		groupX, groupW := bandX+bandW*0.1, bandW*0.8
+
+		pos, neg := base, base
+
+		for j, series := range d.series {
+			v := series.values[i]
+
+			x, w := groupX, groupW
+			from, to := base, v
+
+			if stacked {
+				if v >= 0 {
+					from, to = pos, pos+v
+					pos = to
+				} else {
+					from, to = neg, neg+v
+					neg = to
+				}
+			} else {
+				w = groupW / float64(len(d.series))
+				x = groupX + float64(j)*w
+			}
+
+			c.bar(x, w, c.y(s, from), c.y(s, to), opt.color(j))
+
+			if opt.ValueLabels && v != 0 {
+				c.barValueLabel(x, w, c.y(s, from), c.y(s, to), v, stacked)
+			}
+		}
+	}
+
+	if opt.hasAxes() {
+		c.axisLine(s, base)
+	}
+
+	return c.end(), nil
+}
+
+// bar draws the rect between the positions; the edges are rounded, so bars next to each other have no gaps
+func (c *canvas) bar(x float64, w float64, yFrom float64, yTo float64, color string) {
+	x1, x2 := round(x), round(x+w)
+	y1, y2 := round(min(yFrom, yTo)), round(max(yFrom, yTo))
+
+	if x2 > x1 && y2 > y1 {
+		c.Rect(x1, y1, x2-x1, y2-y1, "fill:"+color)
+	}
+}
+
+// barValueLabel draws the value above (below for negative values) the bar or in the center of a stacked segment
+func (c *canvas) barValueLabel(x float64, w float64, yFrom float64, yTo float64, value float64, stacked bool) {
+	fs := float64(c.opt.FontSize)
+
+	var y float64
+	style := "text-anchor:middle"
+
+	switch {
+	case stacked:
+		y = (yFrom+yTo)/2 + fs*0.35
+		style += "; fill:#fff"
+	case value >= 0:
+		y = yTo - 3
+	default:
+		y = yTo + fs
+	}
+
+	c.Text(round(x+w/2), round(y), c.formatValue(value), style)
+}
@@ -0,0 +1,212 @@
+package charts
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	svg "github.com/ajstarks/svgo"
+)
+
+const (
+	padding = 10
+	// space between the labels and the axes
+	labelGap = 6
+
+	textColor = "#333"
+	gridColor = "#e0e0e0"
+	axisColor = "#666"
+)
+
+// canvas is the svg of a chart with the remaining plot area.
+// The title, the legend and the axes reserve their space at the borders of the plot area.
+type canvas struct {
+	*svg.SVG
+	sb  *strings.Builder
+	opt *Options
+
+	left   float64
+	top    float64
+	right  float64
+	bottom float64
+}
+
+func newCanvas(opt *Options) *canvas {
+	sb := new(strings.Builder)
+
+	c := &canvas{
+		SVG:    svg.New(sb),
+		sb:     sb,
+		opt:    opt,
+		left:   padding,
+		top:    padding,
+		right:  float64(opt.Width - padding),
+		bottom: float64(opt.Height - padding),
+	}
+
+	c.Start(
+		opt.Width,
+		opt.Height,
+		fmt.Sprintf(`viewBox="0 0 %d %d"`, opt.Width, opt.Height),
+		`role="img"`,
+	)
+	c.Gstyle(fmt.Sprintf("font-size:%dpx; fill:%s", opt.FontSize, textColor))
+
+	if opt.Title != "" {
+		c.Title(opt.Title)
+		c.Text(opt.Width/2, padding+opt.FontSize, opt.Title, "text-anchor:middle; font-weight:bold")
+		c.top += float64(opt.FontSize + padding)
+	}
+
+	return c
+}
+
+func (c *canvas) end() string {
+	c.Gend()
+	c.End()
+
+	return c.sb.String()
+}
+
+// legend draws the names with the colors in one row below the plot area
+func (c *canvas) legend(names []string) {
+	fs := c.opt.FontSize
+
+	x := c.left
+	y := round(c.bottom) - fs
+
+	for i, name := range names {
+		c.Rect(round(x), y, fs, fs, "fill:"+c.opt.color(i))
+		c.Text(round(x)+fs+4, y+fs-2, name)
+
+		x += float64(fs+4) + textWidth(name, fs) + float64(fs)
+	}
+
+	c.bottom -= float64(fs + padding)
+}
+
+// axes draws the grid with the ticks of the scale, the labels of the categories and the axis labels
+func (c *canvas) axes(s scale, labels []string) {
+	fs := float64(c.opt.FontSize)
+
+	ticks := s.ticks()
+	tickLabels := make([]string, len(ticks))
+	tickLabelsWidth := 0.0
+
+	for i, tick := range ticks {
+		tickLabels[i] = c.opt.FormatNumber(tick, s.decimals)
+		tickLabelsWidth = max(tickLabelsWidth, textWidth(tickLabels[i], c.opt.FontSize))
+	}
+
+	// reserve the space of the labels before drawing, the positions depend on the plot area
+	xAxisLabelY := c.bottom
+	if c.opt.XAxisLabel != "" {
+		c.bottom -= fs + labelGap
+	}
+
+	categoryLabelY := c.bottom
+	c.bottom -= fs + labelGap
+
+	yAxisLabelX := c.left + fs
+	if c.opt.YAxisLabel != "" {
+		c.left += fs + labelGap
+	}
+
+	c.left += tickLabelsWidth + labelGap
+
+	for i, tick := range ticks {
+		y := round(c.y(s, tick))
+
+		c.Line(round(c.left), y, round(c.right), y, "stroke:"+gridColor)
+		c.Text(round(c.left)-labelGap, y+round(fs*0.35), tickLabels[i], "text-anchor:end")
+	}
+
+	for i, label := range labels {
+		x, w := c.band(i, len(labels))
+		c.Text(round(x+w/2), round(categoryLabelY-fs*0.2), label, "text-anchor:middle")
+	}
+
+	if c.opt.XAxisLabel != "" {
+		c.Text(round((c.left+c.right)/2), round(xAxisLabelY-fs*0.2), c.opt.XAxisLabel, "text-anchor:middle")
+	}
+
+	if c.opt.YAxisLabel != "" {
+		x, y := round(yAxisLabelX), round((c.top+c.bottom)/2)
+		c.Text(x, y, c.opt.YAxisLabel, fmt.Sprintf(`transform="rotate(-90 %d %d)"`, x, y), "text-anchor:middle")
+	}
+}
+
+// axisLine draws the category axis at the value
+func (c *canvas) axisLine(s scale, value float64) {
+	y := round(c.y(s, value))
+	c.Line(round(c.left), y, round(c.right), y, "stroke:"+axisColor)
+}
+
+// band returns the x position and the width of the category
+func (c *canvas) band(i int, n int) (x float64, w float64) {
+	w = (c.right - c.left) / float64(max(n, 1))
+	return c.left + float64(i)*w, w
+}
+
+// y returns the position of the value in the plot area; values outside of the scale are placed at the border
+func (c *canvas) y(s scale, value float64) float64 {
+	ratio := min(max(s.ratio(value), 0), 1)
+	return c.bottom - ratio*(c.bottom-c.top)
+}
+
+// formatValue formats the value with up to two decimal places
+func (c *canvas) formatValue(value float64) string {
+	decimals := 0
+
+	s := strconv.FormatFloat(value, 'f', -1, 64)
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		decimals = min(len(s)-i-1, 2)
+	}
+
+	return c.opt.FormatNumber(value, decimals)
+}
+
+// textWidth estimates the width of the text, the font is chosen by the html document
+func textWidth(text string, fontSize int) float64 {
+	return float64(utf8.RuneCountInString(text)*fontSize) * 0.6
+}
+
+func round(v float64) int {
+	return int(math.Round(v))
+}
+
+// coord formats a coordinate of a path with one decimal place
+func coord(v float64) string {
+	return strconv.FormatFloat(v, 'f', 1, 64)
+}
+
+type point struct {
+	x float64
+	y float64
+}
+
+// linePath returns the path through the points
+func linePath(points []point) string {
+	var sb strings.Builder
+
+	for i, p := range points {
+		if i == 0 {
+			sb.WriteString("M")
+		} else {
+			sb.WriteString(" L")
+		}
+		sb.WriteString(coord(p.x) + " " + coord(p.y))
+	}
+
+	return sb.String()
+}
+
+func seriesNames(d *chartData) []string {
+	names := make([]string, len(d.series))
+	for i, s := range d.series {
+		names[i] = s.name
+	}
+	return names
+}
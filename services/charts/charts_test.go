@@ -0,0 +1,141 @@
+package charts
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// go test ./services/charts -update
+var update = flag.Bool("update", false, "update the golden files")
+
+var sales = []any{
+	map[string]any{"month": "Jan", "net": 120.0, "tax": 22.8},
+	map[string]any{"month": "Feb", "net": 95.5, "tax": 18.15},
+	map[string]any{"month": "Mar", "net": "140", "tax": 26.6},
+	map[string]any{"month": "Apr", "net": 80.0, "tax": 15.2},
+}
+
+var goldenCases = []struct {
+	name    string
+	draw    func(data any, options *Options) (string, error)
+	data    any
+	options string
+}{
+	{"bar", Bar, sales, `{"title": "Sales", "label": "month", "values": ["net", "tax"], "series": ["Net", "Tax"], "yAxisLabel": "EUR"}`},
+	{"bar-numbers", Bar, []any{3.0, -1.5, 2.0}, `{"labels": ["A", "B", "C"], "colors": ["#336699"], "valueLabels": true, "width": 300, "height": 200}`},
+	{"stacked-bar", StackedBar, sales, `{"label": "month", "values": ["net", "tax"], "valueLabels": true, "xAxisLabel": "2026"}`},
+	{"line", Line, sales, `{"label": "month", "values": ["net", "tax"], "legend": false, "min": 0}`},
+	{"pie", Pie, sales, `{"label": "month", "values": ["net"], "valueLabels": true}`},
+	{"donut", Donut, []any{1.0, 1.0, 2.0}, `{"labels": ["Open", "Closed", "Done"], "innerRadius": 0.5}`},
+	{"donut-full", Donut, []any{5.0}, `{"axes": false}`},
+	{"sparkline", Sparkline, []any{1.0, 4.0, 2.0, 5.0, 3.0}, ``},
+}
+
+func TestGolden(t *testing.T) {
+	for _, c := range goldenCases {
+		opt, err := ParseOptions(c.options)
+		if err != nil {
+			t.Fatalf("%s: cant parse options: %v", c.name, err)
+		}
+
+		svg, err := c.draw(c.data, opt)
+		if err != nil {
+			t.Fatalf("%s: cant draw chart: %v", c.name, err)
+		}
+
+		path := filepath.Join("testdata", c.name+".svg")
+
+		if *update {
+			if err := os.WriteFile(path, []byte(svg), 0o644); err != nil {
+				t.Fatalf("cant write golden file: %v", err)
+			}
+		}
+
+		golden, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("cant read golden file (run with -update): %v", err)
+		}
+
+		if svg != string(golden) {
+			t.Fatalf("%s: svg differs from %s:\n%s", c.name, path, svg)
+		}
+
+		if again, _ := c.draw(c.data, opt); again != svg {
+			t.Fatalf("%s: output should be deterministic", c.name)
+		}
+	}
+}
+
+func TestParseOptions(t *testing.T) {
+	opt, err := ParseOptions(map[string]any{"width": 200.0, "values": []any{"net"}, "axes": false})
+	if err != nil {
+		t.Fatalf("cant parse options of model: %v", err)
+	}
+
+	if opt.Width != 200 || len(opt.Values) != 1 || opt.hasAxes() {
+		t.Fatalf("options of model should be read (curr: %+v)", opt)
+	}
+
+	for _, options := range []any{nil, "", " "} {
+		if _, err := ParseOptions(options); err != nil {
+			t.Fatalf("empty options %q should be valid: %v", options, err)
+		}
+	}
+
+	for _, options := range []string{
+		`{"widht": 200}`,
+		`{"colors": ["red\" onload=\"alert(1)"]}`,
+		`{"width": -1}`,
+		`{"innerRadius": 1}`,
+		`{"title": `,
+	} {
+		if _, err := ParseOptions(options); err == nil {
+			t.Fatalf("options %s should fail", options)
+		}
+	}
+}
+
+func TestChartErrors(t *testing.T) {
+	opt := &Options{Label: "month", Values: []string{"net"}}
+
+	if _, err := Bar("no list", opt); err == nil {
+		t.Fatal("data without list should fail")
+	}
+
+	if _, err := Line(sales, &Options{Values: []string{"gross"}}); err == nil || !strings.Contains(err.Error(), "gross") {
+		t.Fatalf("missing field should fail (curr: %v)", err)
+	}
+
+	if _, err := Pie([]any{1.0, -1.0}, &Options{}); err == nil {
+		t.Fatal("negative pie values should fail")
+	}
+
+	if _, err := Bar([]any{"abc"}, &Options{}); err == nil {
+		t.Fatal("non numeric values should fail")
+	}
+}
+
+func TestEmptyData(t *testing.T) {
+	for _, draw := range []func(data any, options *Options) (string, error){Bar, StackedBar, Line, Pie, Donut, Sparkline} {
+		if _, err := draw([]any{}, &Options{}); err != nil {
+			t.Fatalf("empty data should be drawn: %v", err)
+		}
+	}
+}
+
+func TestScaleTicks(t *testing.T) {
+	s := newScale(0, 0.9, &Options{})
+
+	if s.max != 1 || s.step != 0.2 || s.decimals != 1 || len(s.ticks()) != 6 {
+		t.Fatalf("scale should have ticks 0 to 1 in steps of 0.2 (curr: %+v)", s)
+	}
+
+	s = newScale(-3, 140, &Options{})
+
+	if s.min != -50 || s.max != 150 || s.decimals != 0 {
+		t.Fatalf("scale should be -50 to 150 (curr: %+v)", s)
+	}
+}
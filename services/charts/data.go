@@ -0,0 +1,116 @@
+package charts
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/services/decimals"
+)
+
+type series struct {
+	name   string
+	values []float64
+}
+
+type chartData struct {
+	labels []string
+	series []series
+}
+
+// readData reads the labels and the series from a list of numbers or a list of items with the paths of the options
+func readData(data any, opt *Options) (*chartData, error) {
+	rv := reflect.ValueOf(data)
+	if data == nil {
+		rv = reflect.ValueOf([]any{})
+	}
+
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, fmt.Errorf("cant draw chart of %T, a list is required", data)
+	}
+
+	paths := opt.Values
+	if len(paths) == 0 {
+		paths = []string{""}
+	}
+
+	d := &chartData{
+		labels: make([]string, rv.Len()),
+		series: make([]series, len(paths)),
+	}
+
+	for j, path := range paths {
+		d.series[j].name = path
+		if j < len(opt.Series) {
+			d.series[j].name = opt.Series[j]
+		}
+
+		d.series[j].values = make([]float64, rv.Len())
+	}
+
+	for i := range rv.Len() {
+		item := rv.Index(i).Interface()
+
+		if opt.Label != "" {
+			label, err := decimals.GetField(item, opt.Label)
+			if err != nil {
+				return nil, fmt.Errorf("item %d: %w", i, err)
+			}
+			d.labels[i] = fmt.Sprint(label)
+		} else if i < len(opt.Labels) {
+			d.labels[i] = opt.Labels[i]
+		}
+
+		for j, path := range paths {
+			value, err := decimals.GetField(item, path)
+			if err != nil {
+				return nil, fmt.Errorf("item %d: %w", i, err)
+			}
+
+			r, err := decimals.Parse(value)
+			if err != nil {
+				return nil, fmt.Errorf("item %d: %w", i, err)
+			}
+
+			d.series[j].values[i], _ = r.Float64()
+		}
+	}
+
+	return d, nil
+}
+
+// valueRange returns the lowest and the highest value of all series (stacked: of the sums of the positive and negative values)
+func (d *chartData) valueRange(stacked bool, includeZero bool) (lo float64, hi float64) {
+	lo, hi = math.Inf(1), math.Inf(-1)
+	if includeZero {
+		lo, hi = 0, 0
+	}
+
+	for i := range d.labels {
+		pos, neg := 0.0, 0.0
+
+		for _, s := range d.series {
+			v := s.values[i]
+
+			switch {
+			case !stacked:
+				lo, hi = min(lo, v), max(hi, v)
+			case v >= 0:
+				pos += v
+			default:
+				neg += v
+			}
+		}
+
+		if stacked {
+			lo, hi = min(lo, neg), max(hi, pos)
+		}
+	}
+
+	if lo > hi {
+		// no values
+		return 0, 0
+	}
+
+	return lo, hi
+}
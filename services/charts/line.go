@@ -0,0 +1,109 @@
+package charts
+
+import "fmt"
+
+// Line draws the series as lines with points at the categories
+func Line(data any, options *Options) (string, error) {
+	opt := options.withDefaults(600, 300)
+
+	d, err := readData(data, opt)
+	if err != nil {
+		return "", err
+	}
+
+	lo, hi := d.valueRange(false, false)
+	s := newScale(lo, hi, opt)
+
+	c := newCanvas(opt)
+
+	if opt.hasLegend(len(d.series) > 1) {
+		c.legend(seriesNames(d))
+	}
+
+	if opt.ValueLabels {
+		// space for the labels above the highest value
+		c.top += float64(opt.FontSize)
+	}
+
+	if opt.hasAxes() {
+		c.axes(s, d.labels)
+		c.axisLine(s, s.min)
+	}
+
+	for j, series := range d.series {
+		color := opt.color(j)
+
+		points := make([]point, len(series.values))
+		for i, v := range series.values {
+			x, w := c.band(i, len(series.values))
+			points[i] = point{x: x + w/2, y: c.y(s, v)}
+		}
+
+		if len(points) > 1 {
+			c.Path(linePath(points), fmt.Sprintf("fill:none; stroke:%s; stroke-width:2; stroke-linejoin:round", color))
+		}
+
+		for i, p := range points {
+			c.Circle(round(p.x), round(p.y), 3, "fill:"+color)
+
+			if opt.ValueLabels {
+				c.Text(round(p.x), round(p.y)-6, c.formatValue(series.values[i]), "text-anchor:middle")
+			}
+		}
+	}
+
+	return c.end(), nil
+}
+
+// Sparkline draws the series as small lines without axes, legend and title, e.g. in table cells
+func Sparkline(data any, options *Options) (string, error) {
+	opt := options.withDefaults(100, 20)
+	opt.Title = ""
+
+	d, err := readData(data, opt)
+	if err != nil {
+		return "", err
+	}
+
+	lo, hi := d.valueRange(false, false)
+	if opt.Min != nil {
+		lo = *opt.Min
+	}
+	if opt.Max != nil {
+		hi = *opt.Max
+	}
+	// the line of equal values is drawn in the middle
+	if hi <= lo {
+		lo, hi = lo-1, lo+1
+	}
+
+	s := scale{min: lo, max: hi}
+
+	c := newCanvas(opt)
+	// space for the stroke and the last point
+	c.left, c.top, c.right, c.bottom = 3, 3, float64(opt.Width-3), float64(opt.Height-3)
+
+	for j, series := range d.series {
+		color := opt.color(j)
+
+		points := make([]point, len(series.values))
+		for i, v := range series.values {
+			x := (c.left + c.right) / 2
+			if len(points) > 1 {
+				x = c.left + float64(i)*(c.right-c.left)/float64(len(points)-1)
+			}
+			points[i] = point{x: x, y: c.y(s, v)}
+		}
+
+		if len(points) > 1 {
+			c.Path(linePath(points), fmt.Sprintf("fill:none; stroke:%s; stroke-width:1.5; stroke-linejoin:round", color))
+		}
+
+		if len(points) > 0 {
+			last := points[len(points)-1]
+			c.Circle(round(last.x), round(last.y), 2, "fill:"+color)
+		}
+	}
+
+	return c.end(), nil
+}
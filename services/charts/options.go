@@ -0,0 +1,160 @@
+package charts
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const maxSize = 10000
+
+// palette is used for series and slices without color
+var palette = []string{"#4e79a7", "#f28e2b", "#e15759", "#76b7b2", "#59a14f", "#edc948", "#b07aa1", "#ff9da7", "#9c755f", "#bab0ac"}
+
+type Options struct {
+	// size of the svg in px
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	Title  string `json:"title"`
+
+	// (dot separated) path of the category label in the items, e.g. month
+	Label string `json:"label"`
+	// paths of the values in the items, one series per path; empty for lists of numbers
+	Values []string `json:"values"`
+	// labels of the categories for lists of numbers
+	Labels []string `json:"labels"`
+	// names of the series in the legend; the paths of the values if empty
+	Series []string `json:"series"`
+
+	// colors of the series (pie and donut: of the slices) as hex, rgb() or name; repeated if there are more series
+	Colors []string `json:"colors"`
+
+	// value axis with grid lines and category axis with labels; default true (not used by pie, donut and sparkline)
+	Axes       *bool  `json:"axes"`
+	XAxisLabel string `json:"xAxisLabel"`
+	YAxisLabel string `json:"yAxisLabel"`
+	// range of the value axis; calculated from the values if null
+	Min *float64 `json:"min"`
+	Max *float64 `json:"max"`
+
+	// legend below the chart; default true for more than one series and for pie and donut charts
+	Legend *bool `json:"legend"`
+	// values on the bars and points, percentages on the slices
+	ValueLabels bool `json:"valueLabels"`
+
+	// font size in px; default 12
+	FontSize int `json:"fontSize"`
+	// inner radius of donut charts relative to the outer radius; default 0.6
+	InnerRadius float64 `json:"innerRadius"`
+
+	// formats the numbers of the value axis and the value labels (e.g. with the locale of the template)
+	FormatNumber func(value float64, decimals int) string `json:"-"`
+}
+
+var colorRegex = regexp.MustCompile(`^(#[0-9a-fA-F]{3,8}|[a-zA-Z]+|(rgb|rgba|hsl|hsla)\([0-9., %]+\))$`)
+
+// ParseOptions reads the options from a JSON string or an object of the model. Unknown options fail, so typos are found.
+func ParseOptions(options any) (*Options, error) {
+	opt := &Options{}
+
+	var data []byte
+
+	switch o := options.(type) {
+	case nil:
+	case *Options:
+		*opt = *o
+	case string:
+		data = []byte(strings.TrimSpace(o))
+	default:
+		var err error
+		if data, err = json.Marshal(o); err != nil {
+			return nil, fmt.Errorf("invalid chart options: %w", err)
+		}
+	}
+
+	if len(data) > 0 {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+
+		if err := dec.Decode(opt); err != nil {
+			return nil, fmt.Errorf("invalid chart options: %w", err)
+		}
+	}
+
+	return opt, opt.validate()
+}
+
+func (opt *Options) validate() error {
+	if opt.Width < 0 || opt.Width > maxSize || opt.Height < 0 || opt.Height > maxSize {
+		return fmt.Errorf("chart size must be between 0 and %d px (curr: %dx%d)", maxSize, opt.Width, opt.Height)
+	}
+
+	if opt.FontSize < 0 || opt.FontSize > 100 {
+		return fmt.Errorf("chart font size must be between 0 and 100 px (curr: %d)", opt.FontSize)
+	}
+
+	if opt.InnerRadius < 0 || opt.InnerRadius >= 1 {
+		return fmt.Errorf("inner radius must be between 0 and 1 (curr: %v)", opt.InnerRadius)
+	}
+
+	// the colors are written into style attributes
+	for _, c := range opt.Colors {
+		if !colorRegex.MatchString(c) {
+			return fmt.Errorf("invalid chart color '%s'", c)
+		}
+	}
+
+	return nil
+}
+
+// withDefaults returns a copy with the default size of the chart type and the default font size
+func (opt *Options) withDefaults(width int, height int) *Options {
+	o := *opt
+
+	if o.Width == 0 {
+		o.Width = width
+	}
+
+	if o.Height == 0 {
+		o.Height = height
+	}
+
+	if o.FontSize == 0 {
+		o.FontSize = 12
+	}
+
+	if o.InnerRadius == 0 {
+		o.InnerRadius = 0.6
+	}
+
+	if o.FormatNumber == nil {
+		o.FormatNumber = func(value float64, decimals int) string {
+			return strconv.FormatFloat(value, 'f', decimals, 64)
+		}
+	}
+
+	return &o
+}
+
+func (opt *Options) color(i int) string {
+	if len(opt.Colors) > 0 {
+		return opt.Colors[i%len(opt.Colors)]
+	}
+
+	return palette[i%len(palette)]
+}
+
+func (opt *Options) hasAxes() bool {
+	return opt.Axes == nil || *opt.Axes
+}
+
+func (opt *Options) hasLegend(defaultLegend bool) bool {
+	if opt.Legend == nil {
+		return defaultLegend
+	}
+
+	return *opt.Legend
+}
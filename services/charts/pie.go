@@ -0,0 +1,119 @@
+package charts
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// Pie draws the values of the first series as slices with the category labels in the legend
+func Pie(data any, options *Options) (string, error) {
+	return drawPie(data, options, false)
+}
+
+// Donut draws a pie chart with a hole of the inner radius
+func Donut(data any, options *Options) (string, error) {
+	return drawPie(data, options, true)
+}
+
+func drawPie(data any, options *Options, donut bool) (string, error) {
+	opt := options.withDefaults(300, 300)
+
+	d, err := readData(data, opt)
+	if err != nil {
+		return "", err
+	}
+
+	values := d.series[0].values
+
+	total := 0.0
+	for i, v := range values {
+		if v < 0 {
+			return "", fmt.Errorf("item %d: values of pie charts must not be negative (curr: %v)", i, v)
+		}
+		total += v
+	}
+
+	c := newCanvas(opt)
+
+	hasLabels := slices.ContainsFunc(d.labels, func(label string) bool { return label != "" })
+	if opt.hasLegend(hasLabels) {
+		c.legend(d.labels)
+	}
+
+	cx, cy := (c.left+c.right)/2, (c.top+c.bottom)/2
+	r := max(min(c.right-c.left, c.bottom-c.top)/2, 0)
+
+	ri := 0.0
+	if donut {
+		ri = r * opt.InnerRadius
+	}
+
+	angle := 0.0
+
+	for i, v := range values {
+		if v == 0 {
+			continue
+		}
+
+		sweep := v / total * 2 * math.Pi
+
+		c.Path(slicePath(cx, cy, r, ri, angle, angle+sweep), "fill:"+opt.color(i)+"; fill-rule:evenodd; stroke:#fff")
+
+		if opt.ValueLabels {
+			labelRadius := r * 0.65
+			if donut {
+				labelRadius = (r + ri) / 2
+			}
+
+			p := polar(cx, cy, labelRadius, angle+sweep/2)
+			percent := c.opt.FormatNumber(v/total*100, 0) + "%"
+
+			c.Text(round(p.x), round(p.y+float64(opt.FontSize)*0.35), percent, "text-anchor:middle; fill:#fff")
+		}
+
+		angle += sweep
+	}
+
+	return c.end(), nil
+}
+
+// polar returns the point at the angle, starting at 12 o'clock clockwise
+func polar(cx float64, cy float64, r float64, angle float64) point {
+	return point{x: cx + r*math.Sin(angle), y: cy - r*math.Cos(angle)}
+}
+
+// slicePath returns the path of the slice between the angles with a hole of the inner radius ri
+func slicePath(cx float64, cy float64, r float64, ri float64, from float64, to float64) string {
+	if to-from >= 2*math.Pi-1e-9 {
+		// an arc cant start and end at the same point: the full circle is drawn with two arcs (the hole is cut by the fill rule)
+		path := circlePath(cx, cy, r)
+		if ri > 0 {
+			path += " " + circlePath(cx, cy, ri)
+		}
+		return path
+	}
+
+	large := 0
+	if to-from > math.Pi {
+		large = 1
+	}
+
+	o1, o2 := polar(cx, cy, r, from), polar(cx, cy, r, to)
+
+	if ri <= 0 {
+		return fmt.Sprintf("M%s %s L%s %s A%s %s 0 %d 1 %s %s Z",
+			coord(cx), coord(cy), coord(o1.x), coord(o1.y), coord(r), coord(r), large, coord(o2.x), coord(o2.y))
+	}
+
+	i1, i2 := polar(cx, cy, ri, from), polar(cx, cy, ri, to)
+
+	return fmt.Sprintf("M%s %s A%s %s 0 %d 1 %s %s L%s %s A%s %s 0 %d 0 %s %s Z",
+		coord(o1.x), coord(o1.y), coord(r), coord(r), large, coord(o2.x), coord(o2.y),
+		coord(i2.x), coord(i2.y), coord(ri), coord(ri), large, coord(i1.x), coord(i1.y))
+}
+
+func circlePath(cx float64, cy float64, r float64) string {
+	return fmt.Sprintf("M%s %s A%s %s 0 1 1 %s %s A%s %s 0 1 1 %s %s Z",
+		coord(cx), coord(cy-r), coord(r), coord(r), coord(cx), coord(cy+r), coord(r), coord(r), coord(cx), coord(cy-r))
+}
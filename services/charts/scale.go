@@ -0,0 +1,91 @@
+package charts
+
+import "math"
+
+const tickCount = 5
+
+// scale maps the values to the plot area with ticks at round numbers
+type scale struct {
+	min  float64
+	max  float64
+	step float64
+	// decimal places of the ticks
+	decimals int
+}
+
+// newScale extends the range to round ticks; the min and max of the options are kept as given
+func newScale(lo float64, hi float64, opt *Options) scale {
+	if opt.Min != nil {
+		lo = *opt.Min
+	}
+
+	if opt.Max != nil {
+		hi = *opt.Max
+	}
+
+	if hi <= lo {
+		hi = lo + 1
+	}
+
+	step, decimals := niceStep((hi - lo) / (tickCount - 1))
+
+	s := scale{min: lo, max: hi, step: step, decimals: decimals}
+
+	if opt.Min == nil {
+		s.min = math.Floor(lo/step) * step
+	}
+
+	if opt.Max == nil {
+		s.max = math.Ceil(hi/step) * step
+	}
+
+	return s
+}
+
+// niceStep rounds the step to 1, 2, 5 or 10 times a power of ten and returns the decimal places of the step
+func niceStep(step float64) (float64, int) {
+	exp := math.Floor(math.Log10(step))
+	pow := math.Pow(10, exp)
+
+	var nice float64
+	switch f := step / pow; {
+	case f < 1.5:
+		nice = 1
+	case f < 3:
+		nice = 2
+	case f < 7:
+		nice = 5
+	default:
+		nice = 1
+		pow *= 10
+		exp++
+	}
+
+	return nice * pow, max(0, -int(exp))
+}
+
+// ticks returns the multiples of the step within the range
+func (s scale) ticks() []float64 {
+	ticks := []float64{}
+
+	for k := math.Ceil(s.min/s.step - 1e-9); k*s.step <= s.max+s.step*1e-9; k++ {
+		v := k * s.step
+		if v == 0 {
+			// no -0
+			v = 0
+		}
+		ticks = append(ticks, v)
+	}
+
+	return ticks
+}
+
+// ratio returns the position of the value between min (0) and max (1)
+func (s scale) ratio(v float64) float64 {
+	return (v - s.min) / (s.max - s.min)
+}
+
+// baseline returns the value the bars start from (0 if within the range)
+func (s scale) baseline() float64 {
+	return min(max(0, s.min), s.max)
+}
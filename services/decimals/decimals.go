@@ -0,0 +1,156 @@
+package decimals
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	// rounds halves away from zero (2.5 -> 3, -2.5 -> -3)
+	RoundingHalfUp = "half-up"
+	// rounds halves to the even neighbour (2.5 -> 2, 3.5 -> 4), also known as banker's rounding
+	RoundingHalfEven = "half-even"
+)
+
+// highest count of decimal places of exact results
+const maxPlaces = 64
+
+// Parse reads strings, integers and numbers of the model as exact decimal.
+// Numbers of JSON models are float64, they are read by their shortest representation, so 0.1 stays 0.1.
+func Parse(value any) (*big.Rat, error) {
+	var s string
+
+	switch v := value.(type) {
+	case *big.Rat:
+		return new(big.Rat).Set(v), nil
+	case string:
+		s = strings.TrimSpace(v)
+	case json.Number:
+		s = v.String()
+	case float64:
+		s = strconv.FormatFloat(v, 'g', -1, 64)
+	case float32:
+		s = strconv.FormatFloat(float64(v), 'g', -1, 32)
+	default:
+		rv := reflect.ValueOf(value)
+		switch {
+		case rv.CanInt():
+			return new(big.Rat).SetInt64(rv.Int()), nil
+		case rv.CanUint():
+			return new(big.Rat).SetInt(new(big.Int).SetUint64(rv.Uint())), nil
+		}
+		return nil, fmt.Errorf("cant read decimal of type %T", value)
+	}
+
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, fmt.Errorf("cant read decimal '%s'", s)
+	}
+
+	return r, nil
+}
+
+// Sum adds the values of the list. The field is the (dot separated) path of the value in the list items; empty for lists of values.
+func Sum(list any, field string) (*big.Rat, error) {
+	rv := reflect.ValueOf(list)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, fmt.Errorf("cant sum over %T, a list is required", list)
+	}
+
+	sum := new(big.Rat)
+
+	for i := 0; i < rv.Len(); i++ {
+		value, err := GetField(rv.Index(i).Interface(), field)
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
+
+		r, err := Parse(value)
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
+
+		sum.Add(sum, r)
+	}
+
+	return sum, nil
+}
+
+// GetField returns the value of the (dot separated) path of the item; the item itself if the path is empty
+func GetField(item any, path string) (any, error) {
+	if path == "" {
+		return item, nil
+	}
+
+	for _, key := range strings.Split(path, ".") {
+		m, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("cant read field '%s' of %T", key, item)
+		}
+
+		if item, ok = m[key]; !ok {
+			return nil, fmt.Errorf("field '%s' not found", key)
+		}
+	}
+
+	return item, nil
+}
+
+// Round rounds the decimal to the count of decimal places with the rounding mode (half-up or half-even)
+func Round(r *big.Rat, places int, mode string) (*big.Rat, error) {
+	if places < 0 || places > maxPlaces {
+		return nil, fmt.Errorf("decimal places must be between 0 and %d (curr: %d)", maxPlaces, places)
+	}
+
+	if mode != RoundingHalfUp && mode != RoundingHalfEven {
+		return nil, fmt.Errorf("unknown rounding mode '%s' (%s or %s)", mode, RoundingHalfUp, RoundingHalfEven)
+	}
+
+	pow := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(places)), nil)
+
+	scaled := new(big.Rat).Mul(r, new(big.Rat).SetInt(pow))
+
+	quo, rem := new(big.Int).QuoRem(scaled.Num(), scaled.Denom(), new(big.Int))
+
+	// compare the doubled remainder with the denominator to find halves
+	half := new(big.Int).Abs(rem)
+	half.Mul(half, big.NewInt(2))
+
+	cmp := half.Cmp(scaled.Denom())
+	if cmp > 0 || (cmp == 0 && (mode == RoundingHalfUp || quo.Bit(0) == 1)) {
+		quo.Add(quo, big.NewInt(int64(scaled.Sign())))
+	}
+
+	return new(big.Rat).SetFrac(quo, pow), nil
+}
+
+// String formats the decimal with all decimal places (without trailing zeros)
+func String(r *big.Rat) string {
+	if r.IsInt() {
+		return r.Num().String()
+	}
+
+	denom := new(big.Int).Set(r.Denom())
+	places := 0
+
+	// the decimal expansion is finite, if the denominator only has the factors 2 and 5
+	for _, factor := range []int64{2, 5} {
+		f := big.NewInt(factor)
+		count := 0
+		for new(big.Int).Rem(denom, f).Sign() == 0 {
+			denom.Quo(denom, f)
+			count++
+		}
+		places = max(places, count)
+	}
+
+	if denom.Cmp(big.NewInt(1)) != 0 || places > maxPlaces {
+		places = maxPlaces
+	}
+
+	return r.FloatString(places)
+}
@@ -0,0 +1,116 @@
+package decimals
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+)
+
+func parse(t *testing.T, value any) *big.Rat {
+	r, err := Parse(value)
+	if err != nil {
+		t.Fatalf("cant parse %v: %v", value, err)
+	}
+
+	return r
+}
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		value    any
+		expected string
+	}{
+		{0.1, "0.1"},
+		{" 19.99 ", "19.99"},
+		{json.Number("1234.5678"), "1234.5678"},
+		{42, "42"},
+		{1e-7, "0.0000001"},
+	}
+
+	for _, c := range cases {
+		if s := String(parse(t, c.value)); s != c.expected {
+			t.Fatalf("%v should be read as %s (curr: %s)", c.value, c.expected, s)
+		}
+	}
+
+	for _, value := range []any{"abc", nil, []any{}} {
+		if _, err := Parse(value); err == nil {
+			t.Fatalf("%v should fail", value)
+		}
+	}
+}
+
+func TestAddWithoutFloatErrors(t *testing.T) {
+	sum := new(big.Rat).Add(parse(t, 0.1), parse(t, 0.2))
+
+	if s := String(sum); s != "0.3" {
+		t.Fatalf("0.1 + 0.2 should be 0.3 (curr: %s)", s)
+	}
+}
+
+func TestSum(t *testing.T) {
+	items := []any{
+		map[string]any{"price": map[string]any{"net": 0.1}},
+		map[string]any{"price": map[string]any{"net": "0.2"}},
+		map[string]any{"price": map[string]any{"net": 10.0}},
+	}
+
+	sum, err := Sum(items, "price.net")
+	if err != nil {
+		t.Fatalf("cant sum: %v", err)
+	}
+
+	if s := String(sum); s != "10.3" {
+		t.Fatalf("sum should be 10.3 (curr: %s)", s)
+	}
+
+	if _, err := Sum(items, "price.gross"); err == nil {
+		t.Fatal("missing field should fail")
+	}
+
+	if _, err := Sum("1.5", ""); err == nil {
+		t.Fatal("sum over a string should fail")
+	}
+}
+
+func TestRound(t *testing.T) {
+	cases := []struct {
+		value    string
+		places   int
+		mode     string
+		expected string
+	}{
+		{"2.345", 2, RoundingHalfUp, "2.35"},
+		{"2.345", 2, RoundingHalfEven, "2.34"},
+		{"2.355", 2, RoundingHalfEven, "2.36"},
+		{"-2.5", 0, RoundingHalfUp, "-3"},
+		{"-2.5", 0, RoundingHalfEven, "-2"},
+		{"2.344", 2, RoundingHalfUp, "2.34"},
+		{"-2.346", 2, RoundingHalfEven, "-2.35"},
+	}
+
+	for _, c := range cases {
+		rounded, err := Round(parse(t, c.value), c.places, c.mode)
+		if err != nil {
+			t.Fatalf("cant round: %v", err)
+		}
+
+		if s := rounded.FloatString(c.places); s != c.expected {
+			t.Fatalf("%s rounded %s should be %s (curr: %s)", c.value, c.mode, c.expected, s)
+		}
+	}
+
+	if _, err := Round(parse(t, 1), 2, "up"); err == nil {
+		t.Fatal("unknown rounding mode should fail")
+	}
+}
+
+func TestString(t *testing.T) {
+	if s := String(big.NewRat(1, 3)); len(s) != maxPlaces+2 {
+		t.Fatalf("non terminating decimals should be cut after %d places (curr: %s)", maxPlaces, s)
+	}
+
+	if s := String(big.NewRat(-5, 4)); s != "-1.25" {
+		t.Fatalf("-5/4 should be -1.25 (curr: %s)", s)
+	}
+}
@@ -0,0 +1,203 @@
+package formatting
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	// time zones for containers without tzdata
+	_ "time/tzdata"
+
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+const DefaultLocale = "en-US"
+
+// non-breaking space between amount and currency like the percent sign of x/text
+const nbsp = "\u00a0"
+
+// Formatter formats dates, numbers, currencies and relative times for a locale
+type Formatter struct {
+	printer *message.Printer
+	names   *localeNames
+
+	// reference of the relative times
+	now func() time.Time
+}
+
+func NewFormatter(locale string) (*Formatter, error) {
+	if locale == "" {
+		locale = DefaultLocale
+	}
+
+	tag, err := language.Parse(locale)
+	if err != nil {
+		return nil, fmt.Errorf("invalid locale '%s': %w", locale, err)
+	}
+
+	return &Formatter{
+		printer: message.NewPrinter(tag),
+		names:   getLocaleNames(tag),
+		now:     time.Now,
+	}, nil
+}
+
+// FormatDate formats the date with a go layout (e.g. "2. January 2006") and localized month and day names.
+// The date is converted to the time zone (e.g. Europe/Berlin), if given.
+func (f *Formatter) FormatDate(date time.Time, layout string, timezone string) (string, error) {
+	if timezone != "" {
+		loc, err := time.LoadLocation(timezone)
+		if err != nil {
+			return "", err
+		}
+		date = date.In(loc)
+	}
+
+	sb := new(strings.Builder)
+	segmentStart := 0
+
+	for i := 0; i < len(layout); {
+		name, length := f.names.layoutName(layout[i:], date)
+		if length == 0 {
+			i++
+			continue
+		}
+
+		sb.WriteString(date.Format(layout[segmentStart:i]))
+		sb.WriteString(name)
+
+		i += length
+		segmentStart = i
+	}
+
+	sb.WriteString(date.Format(layout[segmentStart:]))
+
+	return sb.String(), nil
+}
+
+// FormatNumber formats the number with the decimal and grouping separators of the locale.
+// Halves are rounded away from zero like on invoices (x/text would round half to even).
+func (f *Formatter) FormatNumber(value float64, decimals int) string {
+	pow := math.Pow10(decimals)
+	rounded := math.Round(value*pow) / pow
+
+	return f.printer.Sprint(number.Decimal(rounded, number.Scale(decimals)))
+}
+
+// FormatPercent formats the ratio as percent (0.19 -> 19 %)
+func (f *Formatter) FormatPercent(value float64, decimals int) string {
+	return f.printer.Sprint(number.Percent(value, number.Scale(decimals)))
+}
+
+// FormatCurrency formats the amount with the decimals and the symbol of the currency (ISO 4217 code)
+func (f *Formatter) FormatCurrency(value float64, code string) (string, error) {
+	unit, err := currency.ParseISO(code)
+	if err != nil {
+		return "", fmt.Errorf("invalid currency '%s': %w", code, err)
+	}
+
+	scale, _ := currency.Standard.Rounding(unit)
+
+	amount := f.FormatNumber(value, scale)
+	symbol := f.printer.Sprint(currency.Symbol(unit))
+
+	if f.names.currencyAfterAmount {
+		return amount + nbsp + symbol, nil
+	}
+
+	if strings.ContainsFunc(symbol, unicode.IsLetter) {
+		// codes like CHF are separated from the amount
+		return symbol + nbsp + amount, nil
+	}
+
+	return symbol + amount, nil
+}
+
+// FormatRelativeTime formats the distance to now in the largest fitting unit (e.g. 3 days ago)
+func (f *Formatter) FormatRelativeTime(date time.Time) string {
+	distance := date.Sub(f.now())
+
+	seconds := int(math.Abs(distance.Seconds()))
+	if seconds == 0 {
+		return f.names.now
+	}
+
+	unit, count := getRelativeTimeUnit(seconds)
+
+	unitName := f.names.units[unit][0]
+	if count != 1 {
+		unitName = f.names.units[unit][1]
+	}
+
+	amount := strconv.Itoa(count) + " " + unitName
+
+	if distance < 0 {
+		return fmt.Sprintf(f.names.past, amount)
+	}
+
+	return fmt.Sprintf(f.names.future, amount)
+}
+
+const (
+	unitSecond = iota
+	unitMinute
+	unitHour
+	unitDay
+	unitWeek
+	unitMonth
+	unitYear
+)
+
+func getRelativeTimeUnit(seconds int) (unit int, count int) {
+	const (
+		minute = 60
+		hour   = 60 * minute
+		day    = 24 * hour
+	)
+
+	switch {
+	case seconds < minute:
+		return unitSecond, seconds
+	case seconds < hour:
+		return unitMinute, seconds / minute
+	case seconds < day:
+		return unitHour, seconds / hour
+	case seconds < 7*day:
+		return unitDay, seconds / day
+	case seconds < 30*day:
+		return unitWeek, seconds / (7 * day)
+	case seconds < 365*day:
+		return unitMonth, seconds / (30 * day)
+	default:
+		return unitYear, seconds / (365 * day)
+	}
+}
+
+// ParseDate reads dates of the model: RFC 3339 strings, dates without time (2006-01-02) and unix timestamps in seconds
+func ParseDate(value any) (time.Time, error) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, nil
+	case float64:
+		return time.Unix(int64(v), 0).UTC(), nil
+	case int:
+		return time.Unix(int64(v), 0).UTC(), nil
+	case int64:
+		return time.Unix(v, 0).UTC(), nil
+	case string:
+		for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02 15:04:05", time.DateOnly} {
+			if date, err := time.Parse(layout, strings.TrimSpace(v)); err == nil {
+				return date, nil
+			}
+		}
+		return time.Time{}, fmt.Errorf("cant parse date '%s'", v)
+	}
+
+	return time.Time{}, fmt.Errorf("cant parse date of type %T", value)
+}
@@ -0,0 +1,151 @@
+package formatting
+
+import (
+	"testing"
+	"time"
+)
+
+func newTestFormatter(t *testing.T, locale string) *Formatter {
+	f, err := NewFormatter(locale)
+	if err != nil {
+		t.Fatalf("cant create formatter: %v", err)
+	}
+
+	return f
+}
+
+func TestFormatDate(t *testing.T) {
+	date := time.Date(2026, time.October, 16, 22, 30, 0, 0, time.UTC)
+
+	cases := []struct {
+		locale   string
+		layout   string
+		timezone string
+		expected string
+	}{
+		{"de-DE", "2. January 2006", "", "16. Oktober 2026"},
+		{"de-DE", "Monday, 02.01.2006 15:04", "Europe/Berlin", "Samstag, 17.10.2026 00:30"},
+		{"en-US", "Mon, Jan 2 2006", "", "Fri, Oct 16 2026"},
+		{"fr-FR", "2 January 2006", "", "16 octobre 2026"},
+		{"sv-SE", "January", "", "October"},
+	}
+
+	for _, c := range cases {
+		formatted, err := newTestFormatter(t, c.locale).FormatDate(date, c.layout, c.timezone)
+		if err != nil {
+			t.Fatalf("cant format date: %v", err)
+		}
+
+		if formatted != c.expected {
+			t.Fatalf("%s date should be '%s' (curr: '%s')", c.locale, c.expected, formatted)
+		}
+	}
+}
+
+func TestNewFormatterInvalidLocale(t *testing.T) {
+	if _, err := NewFormatter("not a locale"); err == nil {
+		t.Fatal("invalid locale should fail")
+	}
+}
+
+func TestFormatDateInvalidTimezone(t *testing.T) {
+	if _, err := newTestFormatter(t, "de").FormatDate(time.Now(), "2006", "Mars/Olympus"); err == nil {
+		t.Fatal("unknown time zone should fail")
+	}
+}
+
+func TestFormatNumbers(t *testing.T) {
+	de := newTestFormatter(t, "de-DE")
+	en := newTestFormatter(t, "en-US")
+
+	cases := []struct {
+		formatted string
+		expected  string
+	}{
+		{de.FormatNumber(1234.567, 2), "1.234,57"},
+		{en.FormatNumber(1234.567, 2), "1,234.57"},
+		{de.FormatPercent(0.19, 0), "19\u00a0%"},
+		{en.FormatPercent(0.195, 1), "19.5%"},
+	}
+
+	for _, c := range cases {
+		if c.formatted != c.expected {
+			t.Fatalf("number should be '%s' (curr: '%s')", c.expected, c.formatted)
+		}
+	}
+}
+
+func TestFormatCurrency(t *testing.T) {
+	cases := []struct {
+		locale   string
+		value    float64
+		code     string
+		expected string
+	}{
+		{"de-DE", 1234.56, "EUR", "1.234,56\u00a0€"},
+		{"en-US", 1234.5, "USD", "$1,234.50"},
+		{"en-US", 1234.5, "CHF", "CHF\u00a01,234.50"},
+		{"en-US", 1234.5, "JPY", "¥1,235"},
+	}
+
+	for _, c := range cases {
+		formatted, err := newTestFormatter(t, c.locale).FormatCurrency(c.value, c.code)
+		if err != nil {
+			t.Fatalf("cant format currency: %v", err)
+		}
+
+		if formatted != c.expected {
+			t.Fatalf("%s amount should be '%s' (curr: '%s')", c.locale, c.expected, formatted)
+		}
+	}
+
+	if _, err := newTestFormatter(t, "de").FormatCurrency(1, "XYZW"); err == nil {
+		t.Fatal("invalid currency code should fail")
+	}
+}
+
+func TestFormatRelativeTime(t *testing.T) {
+	now := time.Date(2026, time.October, 16, 12, 0, 0, 0, time.UTC)
+
+	de := newTestFormatter(t, "de-DE")
+	de.now = func() time.Time { return now }
+
+	en := newTestFormatter(t, "en")
+	en.now = func() time.Time { return now }
+
+	cases := []struct {
+		formatted string
+		expected  string
+	}{
+		{de.FormatRelativeTime(now.AddDate(0, 0, -3)), "vor 3 Tagen"},
+		{de.FormatRelativeTime(now.Add(time.Hour)), "in 1 Stunde"},
+		{en.FormatRelativeTime(now.AddDate(-2, 0, 0)), "2 years ago"},
+		{en.FormatRelativeTime(now.AddDate(0, 0, 14)), "in 2 weeks"},
+		{en.FormatRelativeTime(now), "now"},
+	}
+
+	for _, c := range cases {
+		if c.formatted != c.expected {
+			t.Fatalf("relative time should be '%s' (curr: '%s')", c.expected, c.formatted)
+		}
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	expected := time.Date(2026, time.October, 16, 0, 0, 0, 0, time.UTC)
+
+	for _, value := range []any{"2026-10-16", "2026-10-16T00:00:00Z", float64(expected.Unix())} {
+		date, err := ParseDate(value)
+		if err != nil {
+			t.Fatalf("cant parse %v: %v", value, err)
+		}
+
+		if !date.Equal(expected) {
+			t.Fatalf("%v should be parsed as %v (curr: %v)", value, expected, date)
+		}
+	}
+
+	if _, err := ParseDate("16.10.2026"); err == nil {
+		t.Fatal("unknown date format should fail")
+	}
+}
@@ -0,0 +1,128 @@
+package formatting
+
+import (
+	"strings"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+// localeNames contains the words of a language for dates and relative times
+type localeNames struct {
+	months      [12]string
+	shortMonths [12]string
+	// starting with sunday like time.Weekday
+	days      [7]string
+	shortDays [7]string
+
+	// singular and plural of second, minute, hour, day, week, month and year
+	units  [7][2]string
+	now    string
+	past   string
+	future string
+
+	currencyAfterAmount bool
+}
+
+// layoutName returns the localized name for the month or day element at the start of the go layout and the length of the element
+func (ln *localeNames) layoutName(layout string, date time.Time) (string, int) {
+	switch {
+	case strings.HasPrefix(layout, "January"):
+		return ln.months[date.Month()-1], len("January")
+	case strings.HasPrefix(layout, "Jan"):
+		return ln.shortMonths[date.Month()-1], len("Jan")
+	case strings.HasPrefix(layout, "Monday"):
+		return ln.days[date.Weekday()], len("Monday")
+	case strings.HasPrefix(layout, "Mon"):
+		return ln.shortDays[date.Weekday()], len("Mon")
+	}
+
+	return "", 0
+}
+
+// getLocaleNames returns the names of the language; english is the fallback for unknown languages
+func getLocaleNames(tag language.Tag) *localeNames {
+	base, _ := tag.Base()
+
+	names, ok := localeNamesByLanguage[base.String()]
+	if !ok {
+		names = localeNamesByLanguage["en"]
+	}
+
+	// these regions write the currency symbol before the amount
+	region, _ := tag.Region()
+	if names.currencyAfterAmount && (region.String() == "AT" || region.String() == "CH" || region.String() == "LI") {
+		regionNames := *names
+		regionNames.currencyAfterAmount = false
+		return &regionNames
+	}
+
+	return names
+}
+
+var localeNamesByLanguage = map[string]*localeNames{
+	"en": {
+		months:      [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		shortMonths: [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+		days:        [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		shortDays:   [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+		units:       [7][2]string{{"second", "seconds"}, {"minute", "minutes"}, {"hour", "hours"}, {"day", "days"}, {"week", "weeks"}, {"month", "months"}, {"year", "years"}},
+		now:         "now",
+		past:        "%s ago",
+		future:      "in %s",
+	},
+	"de": {
+		months:              [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		shortMonths:         [12]string{"Jan.", "Feb.", "März", "Apr.", "Mai", "Juni", "Juli", "Aug.", "Sept.", "Okt.", "Nov.", "Dez."},
+		days:                [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		shortDays:           [7]string{"So.", "Mo.", "Di.", "Mi.", "Do.", "Fr.", "Sa."},
+		units:               [7][2]string{{"Sekunde", "Sekunden"}, {"Minute", "Minuten"}, {"Stunde", "Stunden"}, {"Tag", "Tagen"}, {"Woche", "Wochen"}, {"Monat", "Monaten"}, {"Jahr", "Jahren"}},
+		now:                 "jetzt",
+		past:                "vor %s",
+		future:              "in %s",
+		currencyAfterAmount: true,
+	},
+	"fr": {
+		months:              [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		shortMonths:         [12]string{"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.", "nov.", "déc."},
+		days:                [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+		shortDays:           [7]string{"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+		units:               [7][2]string{{"seconde", "secondes"}, {"minute", "minutes"}, {"heure", "heures"}, {"jour", "jours"}, {"semaine", "semaines"}, {"mois", "mois"}, {"an", "ans"}},
+		now:                 "maintenant",
+		past:                "il y a %s",
+		future:              "dans %s",
+		currencyAfterAmount: true,
+	},
+	"es": {
+		months:              [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		shortMonths:         [12]string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sept", "oct", "nov", "dic"},
+		days:                [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+		shortDays:           [7]string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+		units:               [7][2]string{{"segundo", "segundos"}, {"minuto", "minutos"}, {"hora", "horas"}, {"día", "días"}, {"semana", "semanas"}, {"mes", "meses"}, {"año", "años"}},
+		now:                 "ahora",
+		past:                "hace %s",
+		future:              "dentro de %s",
+		currencyAfterAmount: true,
+	},
+	"it": {
+		months:              [12]string{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"},
+		shortMonths:         [12]string{"gen", "feb", "mar", "apr", "mag", "giu", "lug", "ago", "set", "ott", "nov", "dic"},
+		days:                [7]string{"domenica", "lunedì", "martedì", "mercoledì", "giovedì", "venerdì", "sabato"},
+		shortDays:           [7]string{"dom", "lun", "mar", "mer", "gio", "ven", "sab"},
+		units:               [7][2]string{{"secondo", "secondi"}, {"minuto", "minuti"}, {"ora", "ore"}, {"giorno", "giorni"}, {"settimana", "settimane"}, {"mese", "mesi"}, {"anno", "anni"}},
+		now:                 "ora",
+		past:                "%s fa",
+		future:              "tra %s",
+		currencyAfterAmount: true,
+	},
+	"nl": {
+		months:      [12]string{"januari", "februari", "maart", "april", "mei", "juni", "juli", "augustus", "september", "oktober", "november", "december"},
+		shortMonths: [12]string{"jan", "feb", "mrt", "apr", "mei", "jun", "jul", "aug", "sep", "okt", "nov", "dec"},
+		days:        [7]string{"zondag", "maandag", "dinsdag", "woensdag", "donderdag", "vrijdag", "zaterdag"},
+		shortDays:   [7]string{"zo", "ma", "di", "wo", "do", "vr", "za"},
+		units:       [7][2]string{{"seconde", "seconden"}, {"minuut", "minuten"}, {"uur", "uur"}, {"dag", "dagen"}, {"week", "weken"}, {"maand", "maanden"}, {"jaar", "jaar"}},
+		now:         "nu",
+		past:        "%s geleden",
+		future:      "over %s",
+	},
+}
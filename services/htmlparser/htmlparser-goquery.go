@@ -1,8 +1,11 @@
 package htmlparser
 
 import (
+	"fmt"
+	"strconv"
 	"strings"
 
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
 	"github.com/lucas-gaitzsch/pdf-turtle/utils"
 
 	"github.com/PuerkitoBio/goquery"
@@ -58,14 +61,14 @@ func (p *HtmlParserGoQuery) PopHeaderAndFooter() (header string, footer string)
 	header = ""
 	footer = ""
 
-	headerNode := p.doc.Find(HeaderNodeTag).First()
+	headerNode := p.findOutsideOfSections(HeaderNodeTag + ":not([" + PageVariantAttr + "])").First()
 	if headerNode != nil {
 		html, _ := headerNode.Html()
 		header = utils.TrimStrWhitespace(html)
 		headerNode.Remove()
 	}
 
-	footerNode := p.doc.Find(FooterNodeTag).First()
+	footerNode := p.findOutsideOfSections(FooterNodeTag + ":not([" + PageVariantAttr + "])").First()
 	if footerNode != nil {
 		html, _ := footerNode.Html()
 		footer = utils.TrimStrWhitespace(html)
@@ -75,7 +78,291 @@ func (p *HtmlParserGoQuery) PopHeaderAndFooter() (header string, footer string)
 	return
 }
 
+// PopHeaderAndFooterVariants pops the headers and footers for specific pages (e.g. <PdfHeader page="first">)
+func (p *HtmlParserGoQuery) PopHeaderAndFooterVariants() (header models.PageVariants, footer models.PageVariants) {
+	if p.doc == nil {
+		log.Panic().Msg("parsedDoc==nil -> please call .Parse(doc) first")
+	}
+
+	for _, variant := range models.PageVariantNames {
+		header.Set(variant, p.popPageVariant(HeaderNodeTag, variant))
+		footer.Set(variant, p.popPageVariant(FooterNodeTag, variant))
+	}
+
+	return
+}
+
+func (p *HtmlParserGoQuery) popPageVariant(tag string, variant string) *string {
+	nodes := p.findOutsideOfSections(tag + "[" + PageVariantAttr + "]").FilterFunction(func(_ int, s *goquery.Selection) bool {
+		return strings.EqualFold(utils.TrimStrWhitespace(s.AttrOr(PageVariantAttr, "")), variant)
+	})
+
+	if nodes.Length() == 0 {
+		return nil
+	}
+
+	node := nodes.First()
+	html, _ := node.Html()
+	html = utils.TrimStrWhitespace(html)
+	nodes.Remove()
+
+	return &html
+}
+
+// findOutsideOfSections finds the elements not nested in a section (the headers and footers of sections belong to the section)
+func (p *HtmlParserGoQuery) findOutsideOfSections(selector string) *goquery.Selection {
+	return p.doc.Find(selector).FilterFunction(func(_ int, s *goquery.Selection) bool {
+		return s.Closest(SectionNodeTag).Length() == 0
+	})
+}
+
 func (p *HtmlParserGoQuery) GetHtml() (*string, error) {
 	html, err := p.doc.Html()
 	return &html, err
 }
+
+// PrepareToc replaces the toc placeholder with a list of links to all headings matched by the selector.
+// Headings without id get a generated one. The page numbers are empty until SetTocPageNumbers is called.
+func (p *HtmlParserGoQuery) PrepareToc() bool {
+	if p.doc == nil {
+		log.Panic().Msg("parsedDoc==nil -> please call .Parse(doc) first")
+	}
+
+	tocNode := p.doc.Find(TocNodeTag).First()
+	if tocNode.Length() == 0 {
+		return false
+	}
+
+	selector := utils.TrimStrWhitespace(tocNode.AttrOr(TocSelectorAttr, ""))
+	if selector == "" {
+		selector = DefaultTocSelector
+	}
+
+	entries := new(strings.Builder)
+	entryCount := 0
+
+	p.doc.Find(selector).Each(func(_ int, heading *goquery.Selection) {
+		if heading.Closest(TocNodeTag).Length() > 0 {
+			return
+		}
+
+		entryCount++
+
+		id, hasId := heading.Attr("id")
+		if !hasId || id == "" {
+			id = TocIdPrefix + strconv.Itoa(entryCount)
+			heading.SetAttr("id", id)
+		}
+
+		fmt.Fprintf(
+			entries,
+			`<li class="pdf-toc-entry pdf-toc-level-%d"><a href="#%s"><span class="pdf-toc-title">%s</span><span class="%s"></span></a></li>`,
+			getHeadingLevel(heading),
+			html.EscapeString(id),
+			html.EscapeString(strings.Join(strings.Fields(heading.Text()), " ")),
+			TocPageNumberClass,
+		)
+	})
+
+	title, _ := tocNode.Html()
+
+	tocNode.ReplaceWithHtml(fmt.Sprintf(`<nav class="%s">%s<ul>%s</ul></nav>`, TocClass, title, entries.String()))
+
+	return true
+}
+
+func (p *HtmlParserGoQuery) HasToc() bool {
+	return p.doc != nil && p.doc.Find("nav."+TocClass).Length() > 0
+}
+
+// SetTocPageNumbers sets the page numbers of the toc entries by the id of the linked heading
+func (p *HtmlParserGoQuery) SetTocPageNumbers(pageNumbers map[string]int) {
+	if p.doc == nil {
+		return
+	}
+
+	p.doc.Find("nav." + TocClass + " a").Each(func(_ int, link *goquery.Selection) {
+		id := strings.TrimPrefix(link.AttrOr("href", ""), "#")
+
+		pageNumber := ""
+		if nr, ok := pageNumbers[id]; ok {
+			pageNumber = strconv.Itoa(nr)
+		}
+
+		link.Find("." + TocPageNumberClass).SetText(pageNumber)
+	})
+}
+
+// PrepareBookmarks returns the bookmarks for all elements matched by the selector or marked with the bookmark attribute.
+// Chromium only creates named destinations for link targets, so hidden links to all bookmarked elements are appended to the body.
+func (p *HtmlParserGoQuery) PrepareBookmarks(selector string) []models.OutlineEntry {
+	if p.doc == nil {
+		log.Panic().Msg("parsedDoc==nil -> please call .Parse(doc) first")
+	}
+
+	if utils.TrimStrWhitespace(selector) == "" {
+		selector = DefaultBookmarkSelector
+	}
+
+	p.doc.Find("." + BookmarkLinksClass).Remove()
+
+	entries := make([]models.OutlineEntry, 0)
+	links := new(strings.Builder)
+
+	p.doc.Find(selector + ", [" + BookmarkAttr + "]").Each(func(_ int, s *goquery.Selection) {
+		if s.Closest(TocNodeTag+", nav."+TocClass).Length() > 0 {
+			return
+		}
+
+		title := utils.TrimStrWhitespace(s.AttrOr(BookmarkAttr, ""))
+		if title == "" {
+			title = strings.Join(strings.Fields(s.Text()), " ")
+		}
+
+		level := getHeadingLevel(s)
+		if l, err := strconv.Atoi(s.AttrOr(BookmarkLevelAttr, "")); err == nil && l > 0 {
+			level = l
+		}
+
+		id, hasId := s.Attr("id")
+		if !hasId || id == "" {
+			id = BookmarkIdPrefix + strconv.Itoa(len(entries)+1)
+			s.SetAttr("id", id)
+		}
+
+		entries = append(entries, models.OutlineEntry{Title: title, Level: level, Id: id})
+
+		fmt.Fprintf(links, `<a href="#%s"></a>`, html.EscapeString(id))
+	})
+
+	if len(entries) > 0 {
+		p.doc.Find("body").AppendHtml(fmt.Sprintf(`<div class="%s" style="display: none">%s</div>`, BookmarkLinksClass, links.String()))
+	}
+
+	return entries
+}
+
+// PrepareSections returns the sections of page numbers for all elements with the section attribute.
+// Like bookmarks, hidden links to all elements are appended to the body to get named destinations.
+func (p *HtmlParserGoQuery) PrepareSections() []models.PageNumberSection {
+	if p.doc == nil {
+		log.Panic().Msg("parsedDoc==nil -> please call .Parse(doc) first")
+	}
+
+	p.doc.Find("." + SectionLinksClass).Remove()
+
+	sections := make([]models.PageNumberSection, 0)
+	links := new(strings.Builder)
+
+	p.doc.Find("[" + SectionAttr + "]").Each(func(_ int, s *goquery.Selection) {
+		id, hasId := s.Attr("id")
+		if !hasId || id == "" {
+			id = SectionIdPrefix + strconv.Itoa(len(sections)+1)
+			s.SetAttr("id", id)
+		}
+
+		section := models.PageNumberSection{
+			Id:     id,
+			Format: utils.TrimStrWhitespace(s.AttrOr(SectionFormatAttr, "")),
+		}
+
+		if start, err := strconv.Atoi(s.AttrOr(SectionStartAttr, "")); err == nil {
+			section.Start = start
+		}
+
+		sections = append(sections, section)
+
+		fmt.Fprintf(links, `<a href="#%s"></a>`, html.EscapeString(id))
+	})
+
+	if len(sections) > 0 {
+		p.doc.Find("body").AppendHtml(fmt.Sprintf(`<div class="%s" style="display: none">%s</div>`, SectionLinksClass, links.String()))
+	}
+
+	return sections
+}
+
+// PrepareOddPageStarts returns the ids of all elements starting on an odd page and appends hidden links to get their pages
+func (p *HtmlParserGoQuery) PrepareOddPageStarts() []string {
+	if p.doc == nil {
+		log.Panic().Msg("parsedDoc==nil -> please call .Parse(doc) first")
+	}
+
+	p.doc.Find("." + OddPageLinksClass).Remove()
+
+	ids := make([]string, 0)
+	links := new(strings.Builder)
+
+	p.doc.Find("." + OddPageStartClass).Each(func(_ int, s *goquery.Selection) {
+		id, hasId := s.Attr("id")
+		if !hasId || id == "" {
+			id = OddPageStartIdPrefix + strconv.Itoa(len(ids)+1)
+			s.SetAttr("id", id)
+		}
+
+		// the class only breaks the page with the builtin styles (e.g. not with excludeBuiltinStyles or custom themes)
+		addInlineStyle(s, "page-break-before: always")
+
+		ids = append(ids, id)
+
+		fmt.Fprintf(links, `<a href="#%s"></a>`, html.EscapeString(id))
+	})
+
+	if len(ids) > 0 {
+		p.doc.Find("body").AppendHtml(fmt.Sprintf(`<div class="%s" style="display: none">%s</div>`, OddPageLinksClass, links.String()))
+	}
+
+	return ids
+}
+
+// InsertBlankPages inserts a blank page with the html before the elements and optionally at the end. Blank pages of a previous call are removed.
+func (p *HtmlParserGoQuery) InsertBlankPages(beforeIds []string, atEnd bool, blankPageHtml string) {
+	if p.doc == nil {
+		log.Panic().Msg("parsedDoc==nil -> please call .Parse(doc) first")
+	}
+
+	p.doc.Find("." + BlankPageClass).Remove()
+
+	blankPage := fmt.Sprintf(`<div class="%s" style="page-break-before: always; page-break-after: always">%s</div>`, BlankPageClass, blankPageHtml)
+
+	before := make(map[string]bool, len(beforeIds))
+	for _, id := range beforeIds {
+		before[id] = true
+	}
+
+	p.doc.Find("[id]").Each(func(_ int, s *goquery.Selection) {
+		if id := s.AttrOr("id", ""); before[id] {
+			s.BeforeHtml(blankPage)
+			delete(before, id)
+		}
+	})
+
+	if atEnd {
+		p.doc.Find("body").AppendHtml(blankPage)
+	}
+}
+
+// addInlineStyle appends the declaration to the style attribute, if it is not set already
+func addInlineStyle(s *goquery.Selection, declaration string) {
+	style := strings.TrimSpace(s.AttrOr("style", ""))
+	if strings.Contains(style, declaration) {
+		return
+	}
+
+	if style != "" && !strings.HasSuffix(style, ";") {
+		style += ";"
+	}
+
+	s.SetAttr("style", strings.TrimSpace(style+" "+declaration))
+}
+
+// getHeadingLevel returns the level of h1-h6 or 1 for other elements
+func getHeadingLevel(s *goquery.Selection) int {
+	tag := goquery.NodeName(s)
+
+	if len(tag) == 2 && tag[0] == 'h' && tag[1] >= '1' && tag[1] <= '6' {
+		return int(tag[1] - '0')
+	}
+
+	return 1
+}
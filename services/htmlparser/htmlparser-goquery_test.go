@@ -1,8 +1,11 @@
 package htmlparser
 
 import (
+	"reflect"
 	"strings"
 	"testing"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
 )
 
 func TestParseHeaderAndFooterAndCheckRemaining(t *testing.T) {
@@ -133,3 +136,388 @@ func stripWhitespace(html *string) string {
 	stripped = strings.ReplaceAll(stripped, "\t", "")
 	return stripped
 }
+
+func TestPrepareToc(t *testing.T) {
+	p := New()
+
+	doc := `
+	<html>
+		<body>
+			<PdfToc><h2>Contents</h2></PdfToc>
+			<h1 id="intro">Intro</h1>
+			<h2>Details &amp; more</h2>
+			<h4>Not in toc</h4>
+		</body>
+	</html>`
+
+	p.Parse(&doc)
+
+	if !p.PrepareToc() {
+		t.Fatal("toc placeholder should be found")
+	}
+
+	if !p.HasToc() {
+		t.Fatal("toc should be inserted")
+	}
+
+	p.SetTocPageNumbers(map[string]int{"intro": 2, "pdf-toc-2": 3})
+
+	html, _ := p.GetHtml()
+	stripped := stripWhitespace(html)
+
+	shouldContain := []string{
+		`<navclass="pdf-toc"><h2>Contents</h2><ul>`,
+		`<liclass="pdf-toc-entrypdf-toc-level-1"><ahref="#intro"><spanclass="pdf-toc-title">Intro</span><spanclass="pdf-toc-page">2</span></a></li>`,
+		`<liclass="pdf-toc-entrypdf-toc-level-2"><ahref="#pdf-toc-2"><spanclass="pdf-toc-title">Details&amp;more</span><spanclass="pdf-toc-page">3</span></a></li>`,
+		`<h2id="pdf-toc-2">`,
+	}
+
+	for _, s := range shouldContain {
+		if !strings.Contains(stripped, s) {
+			t.Fatalf("html should contain %s (curr: %s)", s, stripped)
+		}
+	}
+
+	if strings.Count(stripped, "pdf-toc-entry") != 2 {
+		t.Fatal("toc should contain only the headings h1-h3 outside of the toc")
+	}
+}
+
+func TestPrepareTocWithSelector(t *testing.T) {
+	p := New()
+
+	doc := `<html><body><PdfToc selector=".chapter"></PdfToc><div class="chapter">A</div><h1>B</h1></body></html>`
+
+	p.Parse(&doc)
+	p.PrepareToc()
+
+	html, _ := p.GetHtml()
+
+	if strings.Count(*html, "pdf-toc-entry") != 1 || !strings.Contains(*html, `<span class="pdf-toc-title">A</span>`) {
+		t.Fatalf("toc should contain only the elements matched by the selector (curr: %s)", *html)
+	}
+}
+
+func TestPrepareTocWithoutPlaceholder(t *testing.T) {
+	p := New()
+
+	doc := `<html><body><h1>A</h1></body></html>`
+
+	p.Parse(&doc)
+
+	if p.PrepareToc() || p.HasToc() {
+		t.Fatal("toc should not be inserted without placeholder")
+	}
+}
+
+func TestHasTocPlaceholder(t *testing.T) {
+	withToc := `<body><pdftoc></pdftoc></body>`
+	withoutToc := `<body>toc</body>`
+
+	if !HasTocPlaceholder(&withToc) {
+		t.Fatal("placeholder should be found case insensitive")
+	}
+
+	if HasTocPlaceholder(&withoutToc) || HasTocPlaceholder(nil) {
+		t.Fatal("placeholder should not be found")
+	}
+}
+
+func TestHasPreparedToc(t *testing.T) {
+	p := New()
+
+	doc := `<html><head></head><body><PdfToc></PdfToc><h1>Intro</h1></body></html>`
+	p.Parse(&doc)
+	p.PrepareToc()
+
+	prepared, err := p.GetHtml()
+	if err != nil {
+		t.Fatalf("cant get html: %v", err)
+	}
+
+	if !HasPreparedToc(prepared) {
+		t.Fatalf("prepared toc should be found (curr: %s)", *prepared)
+	}
+
+	if HasPreparedToc(&doc) || HasPreparedToc(nil) {
+		t.Fatal("prepared toc should not be found")
+	}
+}
+
+func TestPrepareBookmarks(t *testing.T) {
+	p := New()
+
+	doc := `
+	<html>
+		<body>
+			<h1 id="intro">Intro</h1>
+			<h2>Details</h2>
+			<div data-pdf-bookmark="Appendix" data-pdf-bookmark-level="1">appendix content</div>
+			<h5>Not selected</h5>
+		</body>
+	</html>`
+
+	p.Parse(&doc)
+
+	entries := p.PrepareBookmarks("")
+
+	shouldBe := []models.OutlineEntry{
+		{Title: "Intro", Level: 1, Id: "intro"},
+		{Title: "Details", Level: 2, Id: "pdf-bookmark-2"},
+		{Title: "Appendix", Level: 1, Id: "pdf-bookmark-3"},
+	}
+
+	if !reflect.DeepEqual(entries, shouldBe) {
+		t.Fatalf("bookmarks are not as expected (curr: %v)", entries)
+	}
+
+	// prepare again to check the hidden links are not duplicated
+	p.PrepareBookmarks("")
+
+	html, _ := p.GetHtml()
+	stripped := stripWhitespace(html)
+
+	if strings.Count(stripped, BookmarkLinksClass) != 1 || !strings.Contains(stripped, `<ahref="#pdf-bookmark-3"></a>`) {
+		t.Fatalf("hidden links to the bookmarks should be appended once (curr: %s)", stripped)
+	}
+}
+
+func TestPopHeaderAndFooterVariants(t *testing.T) {
+	p := New()
+
+	doc := `
+	<html>
+		<PdfHeader>Default Header</PdfHeader>
+		<PdfHeader page="first"></PdfHeader>
+		<PdfFooter page="odd">Odd Footer</PdfFooter>
+		<PdfFooter page="EVEN">Even Footer</PdfFooter>
+		<body>test</body>
+	</html>`
+
+	p.Parse(&doc)
+
+	headerVariants, footerVariants := p.PopHeaderAndFooterVariants()
+	header, footer := p.PopHeaderAndFooter()
+
+	if header != "Default Header" || footer != "" {
+		t.Fatal("default header and footer should not contain the variants")
+	}
+
+	if headerVariants.First == nil || *headerVariants.First != "" {
+		t.Fatal("empty header for first page was not parsed correctly")
+	}
+
+	if headerVariants.Odd != nil || headerVariants.Last != nil {
+		t.Fatal("undefined header variants should be nil")
+	}
+
+	if *footerVariants.Odd != "Odd Footer" || *footerVariants.Even != "Even Footer" {
+		t.Fatal("footer variants were not parsed correctly")
+	}
+
+	remaining, _ := p.GetHtml()
+
+	if stripWhitespace(remaining) != "<html><head></head><body>test</body></html>" {
+		t.Fatal("header and footer variants were not removed correctly")
+	}
+}
+
+func TestPrepareSections(t *testing.T) {
+	p := New()
+
+	doc := `
+	<html>
+		<body>
+			<section id="preface" data-pdf-section data-pdf-section-format="lower-roman">preface</section>
+			<section data-pdf-section data-pdf-section-start="3">chapter</section>
+		</body>
+	</html>`
+
+	p.Parse(&doc)
+
+	sections := p.PrepareSections()
+
+	shouldBe := []models.PageNumberSection{
+		{Id: "preface", Format: "lower-roman"},
+		{Id: "pdf-section-2", Start: 3},
+	}
+
+	if !reflect.DeepEqual(sections, shouldBe) {
+		t.Fatalf("sections are not as expected (curr: %v)", sections)
+	}
+
+	html, _ := p.GetHtml()
+	stripped := stripWhitespace(html)
+
+	if !strings.Contains(stripped, `<ahref="#preface"></a><ahref="#pdf-section-2"></a>`) {
+		t.Fatalf("hidden links to the sections should be appended (curr: %s)", stripped)
+	}
+}
+
+func TestFillPlaceholders(t *testing.T) {
+	footer := `<div>Page <span class="pdf-section-page-number"></span> of <span class="pdf-section-total-pages">x</span></div>`
+
+	html, err := FillPlaceholders(footer, map[string]string{
+		SectionPageNumberClass: "ii",
+		SectionTotalPagesClass: "iv",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(html, `Page <span class="pdf-section-page-number">ii</span> of <span class="pdf-section-total-pages">iv</span>`) {
+		t.Fatalf("placeholders should be filled (curr: %s)", html)
+	}
+
+	if !HasPageNumberPlaceholder(footer) || HasPageNumberPlaceholder(`<span class="pageNumber"></span>`) {
+		t.Fatal("page number placeholders are not detected correctly")
+	}
+}
+
+func TestPopSections(t *testing.T) {
+	p := New()
+
+	doc := `
+	<html>
+		<head><style>h1 { color: red; }</style></head>
+		<body>
+			<PdfHeader>document header</PdfHeader>
+			<h1 id="intro">Intro</h1>
+			<a href="#plan">see plan</a>
+			<PdfSection options='{"landscape": true, "pageFormat": "A3"}'>
+				<PdfHeader>plan header</PdfHeader>
+				<div id="plan">plan</div>
+			</PdfSection>
+			<p>outro</p>
+		</body>
+	</html>`
+
+	p.Parse(&doc)
+
+	header, _ := p.PopHeaderAndFooter()
+	if header != "document header" {
+		t.Fatalf("header of the section should not be the document header (curr: %s)", header)
+	}
+
+	sections, err := p.PopSections()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(sections) != 3 {
+		t.Fatalf("should have 3 sections (curr: %d)", len(sections))
+	}
+
+	if sections[0].Options != nil || sections[2].Options != nil {
+		t.Fatal("content outside of sections should have no options")
+	}
+
+	if o := sections[1].Options; o == nil || !o.Landscape || o.PageFormat != "A3" {
+		t.Fatalf("options of the section are not as expected (curr: %v)", o)
+	}
+
+	plan := stripWhitespace(sections[1].Html)
+
+	if !strings.Contains(plan, "<style>h1{color:red;}</style>") || !strings.Contains(plan, "<pdfheader>planheader</pdfheader>") {
+		t.Fatalf("section should have the head of the document and its own header (curr: %s)", plan)
+	}
+
+	if !strings.Contains(plan, `<ahref="#plan"></a>`) || strings.Contains(stripWhitespace(sections[0].Html), `<ahref="#plan"></a></div>`) {
+		t.Fatalf("only the section with the link target should have the hidden link (curr: %s)", plan)
+	}
+
+	if body, _ := p.GetHtml(); strings.Contains(*body, "outro") {
+		t.Fatal("the sections should be removed from the document")
+	}
+}
+
+func TestPopSectionsInvalidOptions(t *testing.T) {
+	p := New()
+
+	doc := `<html><body><PdfSection options="{landscape">x</PdfSection></body></html>`
+	p.Parse(&doc)
+
+	if _, err := p.PopSections(); err == nil {
+		t.Fatal("invalid options should fail")
+	}
+}
+
+func TestAddSections(t *testing.T) {
+	p := New()
+
+	doc := `<html><head></head><body></body></html>`
+	p.Parse(&doc)
+
+	html := "<p>appendix</p>"
+	err := p.AddSections([]models.RenderSection{
+		{Html: &html, FooterHtml: "appendix footer", Options: &models.RenderSectionOptions{Landscape: true}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sections, _ := p.PopSections()
+
+	if len(sections) != 1 || sections[0].Options == nil || !sections[0].Options.Landscape {
+		t.Fatalf("added section should be popped with options (curr: %v)", sections)
+	}
+
+	if !strings.Contains(*sections[0].Html, "<pdffooter>appendix footer</pdffooter><p>appendix</p>") {
+		t.Fatalf("added section should contain footer and html (curr: %s)", *sections[0].Html)
+	}
+}
+
+func TestPrepareOddPageStartsWithoutBuiltinStyles(t *testing.T) {
+	p := New()
+
+	// no builtin styles are added, so the breaks must not depend on the class
+	doc := `<html><head></head><body><p>intro</p><h1 class="page-break-before-odd" style="color: red">Chapter 1</h1></body></html>`
+
+	p.Parse(&doc)
+	p.PrepareOddPageStarts()
+	p.PrepareOddPageStarts()
+	p.InsertBlankPages([]string{"pdf-odd-page-1"}, true, "")
+
+	html, _ := p.GetHtml()
+
+	if !strings.Contains(*html, `style="color: red; page-break-before: always"`) {
+		t.Fatalf("odd page start should break the page inline (curr: %s)", *html)
+	}
+
+	if strings.Count(*html, "page-break-before: always") != 3 || strings.Count(*html, "page-break-after: always") != 2 {
+		t.Fatalf("blank pages should break the pages inline (curr: %s)", *html)
+	}
+}
+
+func TestPrepareOddPageStartsAndInsertBlankPages(t *testing.T) {
+	p := New()
+
+	doc := `
+	<html>
+		<body>
+			<h1 class="page-break-before-odd">Chapter 1</h1>
+			<h1 id="chapter-2" class="page-break-before-odd">Chapter 2</h1>
+		</body>
+	</html>`
+
+	p.Parse(&doc)
+
+	ids := p.PrepareOddPageStarts()
+	if !reflect.DeepEqual(ids, []string{"pdf-odd-page-1", "chapter-2"}) {
+		t.Fatalf("ids are not as expected (curr: %v)", ids)
+	}
+
+	p.InsertBlankPages([]string{"pdf-odd-page-1"}, false, "first")
+	p.InsertBlankPages([]string{"chapter-2"}, true, "<i>blank</i>")
+
+	html, _ := p.GetHtml()
+	stripped := stripWhitespace(html)
+
+	if strings.Contains(stripped, "first") {
+		t.Fatalf("blank pages of the previous call should be removed (curr: %s)", stripped)
+	}
+
+	if strings.Count(stripped, BlankPageClass) != 2 || !strings.Contains(stripped, `<i>blank</i></div><h1id="chapter-2"`) {
+		t.Fatalf("blank pages should be inserted before chapter 2 and at the end (curr: %s)", stripped)
+	}
+}
@@ -0,0 +1,161 @@
+package htmlparser
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/net/html"
+)
+
+// AddSections appends the sections as section elements to the body
+func (p *HtmlParserGoQuery) AddSections(sections []models.RenderSection) error {
+	if p.doc == nil {
+		log.Panic().Msg("parsedDoc==nil -> please call .Parse(doc) first")
+	}
+
+	sb := new(strings.Builder)
+
+	for i, s := range sections {
+		options := ""
+		if s.Options != nil {
+			o, err := json.Marshal(s.Options)
+			if err != nil {
+				return fmt.Errorf("cant serialize options of section %d: %w", i+1, err)
+			}
+			options = fmt.Sprintf(` %s="%s"`, SectionOptionsAttr, html.EscapeString(string(o)))
+		}
+
+		fmt.Fprintf(sb, "<%s%s>", SectionNodeTag, options)
+
+		if s.HeaderHtml != "" {
+			fmt.Fprintf(sb, "<%s>%s</%s>", HeaderNodeTag, s.HeaderHtml, HeaderNodeTag)
+		}
+
+		if s.FooterHtml != "" {
+			fmt.Fprintf(sb, "<%s>%s</%s>", FooterNodeTag, s.FooterHtml, FooterNodeTag)
+		}
+
+		if s.Html != nil {
+			sb.WriteString(*s.Html)
+		}
+
+		fmt.Fprintf(sb, "</%s>", SectionNodeTag)
+	}
+
+	p.doc.Find("body").AppendHtml(sb.String())
+
+	return nil
+}
+
+// PopSections splits the body at the section elements into separate documents with the head of the document.
+// Content outside of the sections forms sections without options. Styles and scripts of the body are added to every section.
+// Every section gets hidden links to its link targets, so links and bookmarks across the sections keep working after merging.
+func (p *HtmlParserGoQuery) PopSections() ([]models.RenderSection, error) {
+	if p.doc == nil {
+		log.Panic().Msg("parsedDoc==nil -> please call .Parse(doc) first")
+	}
+
+	body := p.doc.Find("body")
+
+	linkTargets := make(map[string]bool)
+	p.doc.Find(`a[href^="#"]`).Each(func(_ int, a *goquery.Selection) {
+		linkTargets[strings.TrimPrefix(a.AttrOr("href", ""), "#")] = true
+	})
+
+	// the hidden links are replaced by the links of every section
+	body.Find("." + BookmarkLinksClass + ", ." + SectionLinksClass + ", ." + LinkTargetsClass).Remove()
+
+	shared := new(strings.Builder)
+	body.ChildrenFiltered("style, script").Each(func(_ int, s *goquery.Selection) {
+		h, _ := goquery.OuterHtml(s)
+		shared.WriteString(h)
+	}).Remove()
+
+	head, _ := p.doc.Find("head").Html()
+
+	type section struct {
+		content *strings.Builder
+		ids     []string
+		options *models.RenderSectionOptions
+	}
+
+	sections := make([]*section, 0)
+	var outside *section
+
+	addIds := func(sec *section, s *goquery.Selection) {
+		s.Find("[id]").AddSelection(s.Filter("[id]")).Each(func(_ int, e *goquery.Selection) {
+			sec.ids = append(sec.ids, e.AttrOr("id", ""))
+		})
+	}
+
+	var err error
+
+	body.Contents().EachWithBreak(func(i int, s *goquery.Selection) bool {
+		if goquery.NodeName(s) == strings.ToLower(SectionNodeTag) {
+			outside = nil
+
+			sec := &section{content: new(strings.Builder)}
+
+			if o := strings.TrimSpace(s.AttrOr(SectionOptionsAttr, "")); o != "" {
+				sec.options = &models.RenderSectionOptions{}
+				if errJson := json.Unmarshal([]byte(o), sec.options); errJson != nil {
+					err = fmt.Errorf("invalid options of section %d: %w", len(sections)+1, errJson)
+					return false
+				}
+			}
+
+			inner, _ := s.Html()
+			sec.content.WriteString(inner)
+			addIds(sec, s)
+
+			sections = append(sections, sec)
+			return true
+		}
+
+		if s.Nodes[0].Type == html.TextNode && strings.TrimSpace(s.Text()) == "" {
+			return true
+		}
+
+		if outside == nil {
+			outside = &section{content: new(strings.Builder)}
+			sections = append(sections, outside)
+		}
+
+		h, _ := goquery.OuterHtml(s)
+		outside.content.WriteString(h)
+		addIds(outside, s)
+
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]models.RenderSection, len(sections))
+
+	for i, sec := range sections {
+		links := new(strings.Builder)
+		for _, id := range sec.ids {
+			if linkTargets[id] {
+				fmt.Fprintf(links, `<a href="#%s"></a>`, html.EscapeString(id))
+			}
+		}
+
+		doc := fmt.Sprintf(
+			`<html><head>%s</head><body>%s%s<div class="%s" style="display: none">%s</div></body></html>`,
+			head, shared.String(), sec.content.String(), LinkTargetsClass, links.String(),
+		)
+
+		res[i] = models.RenderSection{Html: &doc, Options: sec.options}
+	}
+
+	body.Empty()
+
+	return res, nil
+}
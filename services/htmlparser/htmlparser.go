@@ -1,15 +1,138 @@
 package htmlparser
 
+import (
+	"strings"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
 const (
-	HeaderNodeTag = "PdfHeader"
-	FooterNodeTag = "PdfFooter"
+	HeaderNodeTag  = "PdfHeader"
+	FooterNodeTag  = "PdfFooter"
+	TocNodeTag     = "PdfToc"
+	SectionNodeTag = "PdfSection"
+
+	// attribute of the section with the page options as json
+	SectionOptionsAttr = "options"
+	// hidden links to all link targets of a section to get named destinations in every section
+	LinkTargetsClass = "pdf-link-targets"
+
+	// attribute of header and footer for the page variant (first, odd, even or last)
+	PageVariantAttr = "page"
+
+	// attribute of the toc placeholder to select the headings
+	TocSelectorAttr    = "selector"
+	DefaultTocSelector = "h1, h2, h3"
+	TocIdPrefix        = "pdf-toc-"
+	TocClass           = "pdf-toc"
+	TocPageNumberClass = "pdf-toc-page"
+
+	// attribute to mark elements as bookmark; the value overrides the title
+	BookmarkAttr = "data-pdf-bookmark"
+	// attribute to override the level of the bookmark
+	BookmarkLevelAttr       = "data-pdf-bookmark-level"
+	DefaultBookmarkSelector = DefaultTocSelector
+	// selector matching the outline generated by chromium
+	HeadingsBookmarkSelector = "h1, h2, h3, h4, h5, h6"
+	BookmarkIdPrefix         = "pdf-bookmark-"
+	BookmarkLinksClass       = "pdf-bookmark-links"
+
+	// placeholder classes in header and footer filled after the layout
+	PageNumberClass        = "pdf-page-number"
+	TotalPagesClass        = "pdf-total-pages"
+	SectionPageNumberClass = "pdf-section-page-number"
+	SectionTotalPagesClass = "pdf-section-total-pages"
+
+	// attribute to start a new section of page numbers on the page of the element
+	SectionAttr = "data-pdf-section"
+	// attribute to override the number of the first page of the section (default 1)
+	SectionStartAttr = "data-pdf-section-start"
+	// attribute to override the format of the page numbers of the section
+	SectionFormatAttr = "data-pdf-section-format"
+	SectionIdPrefix   = "pdf-section-"
+	SectionLinksClass = "pdf-section-links"
+
+	// class of elements starting on an odd page
+	OddPageStartClass    = "page-break-before-odd"
+	OddPageStartIdPrefix = "pdf-odd-page-"
+	OddPageLinksClass    = "pdf-odd-page-links"
+	BlankPageClass       = "pdf-blank-page"
 )
 
+var PageNumberPlaceholderClasses = []string{PageNumberClass, TotalPagesClass, SectionPageNumberClass, SectionTotalPagesClass}
+
 type HtmlParser interface {
 	Parse(document *string) error
 	PopHeaderAndFooter() (header string, footer string)
+	PopHeaderAndFooterVariants() (header models.PageVariants, footer models.PageVariants)
 	AddStyles(cssStyles *string)
 	GetHtml() (*string, error)
+	PrepareToc() bool
+	HasToc() bool
+	SetTocPageNumbers(pageNumbers map[string]int)
+	PrepareBookmarks(selector string) []models.OutlineEntry
+	PrepareSections() []models.PageNumberSection
+	PrepareOddPageStarts() []string
+	InsertBlankPages(beforeIds []string, atEnd bool, html string)
+	AddSections(sections []models.RenderSection) error
+	PopSections() ([]models.RenderSection, error)
+}
+
+// HasTocPlaceholder checks the raw html for the toc placeholder without parsing the dom
+func HasTocPlaceholder(html *string) bool {
+	return html != nil && strings.Contains(strings.ToLower(*html), "<"+strings.ToLower(TocNodeTag))
+}
+
+// HasPreparedToc checks the raw html for a toc already prepared by PrepareToc (e.g. in the html of a section) without parsing the dom
+func HasPreparedToc(html *string) bool {
+	return html != nil && strings.Contains(*html, `<nav class="`+TocClass+`">`)
+}
+
+// HasOddPageStarts checks the raw html for the class of elements starting on an odd page without parsing the dom
+func HasOddPageStarts(html *string) bool {
+	return html != nil && strings.Contains(*html, OddPageStartClass)
+}
+
+// HasSectionPlaceholder checks the raw html for section elements without parsing the dom
+func HasSectionPlaceholder(html *string) bool {
+	return html != nil && strings.Contains(strings.ToLower(*html), "<"+strings.ToLower(SectionNodeTag))
+}
+
+// HasPageNumberPlaceholder checks the raw html of a header or footer for the page number placeholder classes
+func HasPageNumberPlaceholder(html string) bool {
+	for _, class := range PageNumberPlaceholderClasses {
+		if strings.Contains(html, class) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasBookmarkAttributes checks the raw html for elements marked as bookmark without parsing the dom
+func HasBookmarkAttributes(html *string) bool {
+	return html != nil && strings.Contains(*html, BookmarkAttr)
+}
+
+// HasPageNumberSections checks the raw html for elements starting a section of page numbers without parsing the dom
+func HasPageNumberSections(html *string) bool {
+	return html != nil && strings.Contains(*html, SectionAttr)
+}
+
+// FillPlaceholders sets the text of all elements with the class to the value
+func FillPlaceholders(html string, values map[string]string) (string, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return "", err
+	}
+
+	for class, value := range values {
+		doc.Find("." + class).SetText(value)
+	}
+
+	return doc.Html()
 }
 
 func New() HtmlParser {
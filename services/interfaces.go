@@ -23,5 +23,6 @@ type BundleProviderService interface {
 type RendererBackgroundService interface {
 	Init(outerCtx context.Context)
 	RenderAndReceive(job models.Job) (io.Reader, error)
+	MeasureHtmlHeight(ctx context.Context, html string, options *models.RenderOptions) (float64, error)
 	Close()
 }
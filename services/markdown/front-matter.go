@@ -0,0 +1,107 @@
+package markdown
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
+	"go.yaml.in/yaml/v3"
+)
+
+// FrontMatter is the yaml block at the beginning of the markdown, enclosed by lines with ---
+type FrontMatter struct {
+	Title    string   `yaml:"title"`
+	Author   string   `yaml:"author"`
+	Subject  string   `yaml:"subject"`
+	Keywords keywords `yaml:"keywords"`
+	Theme    string   `yaml:"theme"`
+
+	// render options with the keys of the json api, e.g. pageFormat, margins or outline
+	Options map[string]any `yaml:"options"`
+}
+
+// keywords are given as list or comma separated
+type keywords []string
+
+func (k *keywords) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*k = nil
+
+		for keyword := range strings.SplitSeq(value.Value, ",") {
+			if keyword = strings.TrimSpace(keyword); keyword != "" {
+				*k = append(*k, keyword)
+			}
+		}
+
+		return nil
+	}
+
+	return value.Decode((*[]string)(k))
+}
+
+var frontMatterRegex = regexp.MustCompile(`\A(?:\x{FEFF})?---[ \t]*\r?\n(?s:(.*?)\r?\n)?---[ \t]*(?:\r?\n|\z)`)
+
+// ParseFrontMatter returns the front matter and the markdown without it. The front matter is empty if the markdown has none.
+func ParseFrontMatter(markdown string) (*FrontMatter, string, error) {
+	fm := &FrontMatter{}
+
+	m := frontMatterRegex.FindStringSubmatchIndex(markdown)
+	if m == nil {
+		return fm, markdown, nil
+	}
+
+	if m[2] >= 0 {
+		if err := yaml.Unmarshal([]byte(markdown[m[2]:m[3]]), fm); err != nil {
+			return nil, "", fmt.Errorf("invalid front matter: %w", err)
+		}
+	}
+
+	return fm, markdown[m[1]:], nil
+}
+
+// ApplyTo overrides the options and the metadata with the values of the front matter
+func (fm *FrontMatter) ApplyTo(opt *models.RenderOptions) error {
+	if len(fm.Options) > 0 {
+		options, err := json.Marshal(fm.Options)
+		if err != nil {
+			return fmt.Errorf("invalid options in front matter: %w", err)
+		}
+
+		if err := json.Unmarshal(options, opt); err != nil {
+			return fmt.Errorf("invalid options in front matter: %w", err)
+		}
+	}
+
+	metadata := models.RenderOptionsMetadata{
+		Title:    fm.Title,
+		Author:   fm.Author,
+		Subject:  fm.Subject,
+		Keywords: fm.Keywords,
+	}
+
+	if metadata.IsEmpty() {
+		return nil
+	}
+
+	if opt.Metadata == nil {
+		opt.Metadata = &models.RenderOptionsMetadata{}
+	}
+
+	setIfNotEmpty(&opt.Metadata.Title, metadata.Title)
+	setIfNotEmpty(&opt.Metadata.Author, metadata.Author)
+	setIfNotEmpty(&opt.Metadata.Subject, metadata.Subject)
+
+	if len(metadata.Keywords) > 0 {
+		opt.Metadata.Keywords = metadata.Keywords
+	}
+
+	return nil
+}
+
+func setIfNotEmpty(target *string, value string) {
+	if value != "" {
+		*target = value
+	}
+}
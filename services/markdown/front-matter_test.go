@@ -0,0 +1,99 @@
+package markdown
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
+)
+
+const markdownWithFrontMatter = `---
+title: Release Notes 2.0
+author: PDF Turtle
+keywords: release, changelog
+theme: github
+options:
+  pageFormat: A5
+  landscape: true
+  margins:
+    top: 10
+---
+# Release Notes
+`
+
+func TestParseFrontMatter(t *testing.T) {
+	fm, md, err := ParseFrontMatter(markdownWithFrontMatter)
+	if err != nil {
+		t.Fatalf("cant parse front matter: %v", err)
+	}
+
+	if md != "# Release Notes\n" {
+		t.Fatalf("front matter should be removed from markdown (curr: %q)", md)
+	}
+
+	if fm.Title != "Release Notes 2.0" || fm.Author != "PDF Turtle" || fm.Theme != "github" {
+		t.Fatalf("front matter should be parsed (curr: %+v)", fm)
+	}
+
+	if !slices.Equal(fm.Keywords, []string{"release", "changelog"}) {
+		t.Fatalf("comma separated keywords should be split (curr: %v)", fm.Keywords)
+	}
+
+	fm, _, err = ParseFrontMatter("---\nkeywords: [a, b]\n---\n")
+	if err != nil || !slices.Equal(fm.Keywords, []string{"a", "b"}) {
+		t.Fatalf("keywords should be read as list (curr: %v, err: %v)", fm.Keywords, err)
+	}
+}
+
+func TestParseFrontMatterWithout(t *testing.T) {
+	for _, md := range []string{"# Heading\n", "text\n---\ntitle: no front matter\n---\n"} {
+		fm, res, err := ParseFrontMatter(md)
+		if err != nil || res != md || fm.Title != "" {
+			t.Fatalf("markdown without front matter should be unchanged (curr: %q, %+v, err: %v)", res, fm, err)
+		}
+	}
+
+	fm, res, err := ParseFrontMatter("---\n---\ntext")
+	if err != nil || res != "text" || fm.Title != "" {
+		t.Fatalf("empty front matter should be removed (curr: %q, err: %v)", res, err)
+	}
+
+	if _, _, err := ParseFrontMatter("---\ntitle: [\n---\n"); err == nil {
+		t.Fatal("invalid yaml should fail")
+	}
+}
+
+func TestFrontMatterApplyTo(t *testing.T) {
+	fm, _, err := ParseFrontMatter(markdownWithFrontMatter)
+	if err != nil {
+		t.Fatalf("cant parse front matter: %v", err)
+	}
+
+	opt := models.RenderOptions{
+		PageFormat: "A4",
+		Locale:     "de-DE",
+		Metadata:   &models.RenderOptionsMetadata{Subject: "Changelog", Author: "Request"},
+	}
+
+	if err := fm.ApplyTo(&opt); err != nil {
+		t.Fatalf("cant apply front matter: %v", err)
+	}
+
+	if opt.PageFormat != "A5" || !opt.Landscape || opt.Margins == nil || opt.Margins.Top != 10 {
+		t.Fatalf("options should be overridden by the front matter (curr: %+v)", opt)
+	}
+
+	if opt.Locale != "de-DE" {
+		t.Fatal("options not set in the front matter should be kept")
+	}
+
+	m := opt.Metadata
+	if m.Title != "Release Notes 2.0" || m.Author != "PDF Turtle" || m.Subject != "Changelog" || len(m.Keywords) != 2 {
+		t.Fatalf("metadata should be merged with the front matter (curr: %+v)", m)
+	}
+
+	fm = &FrontMatter{Options: map[string]any{"landscape": "yes"}}
+	if err := fm.ApplyTo(&opt); err == nil {
+		t.Fatal("invalid options should fail")
+	}
+}
@@ -0,0 +1,51 @@
+package markdown
+
+import (
+	"bytes"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/renderer/html"
+)
+
+var (
+	// raw html is rendered as is, e.g. <PdfHeader> or page breaks in documents
+	documentConverter = newConverter(html.WithUnsafe())
+	// raw html is replaced by a comment; used for markdown of (untrusted) models
+	fragmentConverter = newConverter()
+)
+
+// newConverter creates a converter with github flavored markdown (tables, task lists, strikethrough and autolinks),
+// footnotes and ids on the headings as anchors for links, the table of contents and the outline
+func newConverter(options ...renderer.Option) goldmark.Markdown {
+	return goldmark.New(
+		goldmark.WithExtensions(
+			extension.GFM,
+			extension.Footnote,
+		),
+		goldmark.WithParserOptions(
+			parser.WithAutoHeadingID(),
+			// custom heading ids: # Heading {#custom-id}
+			parser.WithAttribute(),
+		),
+		goldmark.WithRendererOptions(options...),
+	)
+}
+
+// ToHtml converts the markdown to html. Raw html is only rendered if allowed.
+func ToHtml(markdown string, allowHtml bool) (string, error) {
+	converter := fragmentConverter
+	if allowHtml {
+		converter = documentConverter
+	}
+
+	var buf bytes.Buffer
+
+	if err := converter.Convert([]byte(markdown), &buf); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
@@ -0,0 +1,67 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestToHtml(t *testing.T) {
+	md := `# Release Notes {#notes}
+
+## Fixed bugs
+
+| Version | Date       |
+|---------|------------|
+| 2.0     | 2026-10-18 |
+
+- [x] done
+- [ ] open
+
+Footnote[^1] and ~~removed~~
+
+[^1]: The note.
+`
+
+	html, err := ToHtml(md, false)
+	if err != nil {
+		t.Fatalf("cant convert markdown: %v", err)
+	}
+
+	for _, expected := range []string{
+		`<h1 id="notes">Release Notes</h1>`,
+		`<h2 id="fixed-bugs">Fixed bugs</h2>`,
+		`<th>Version</th>`,
+		`<td>2026-10-18</td>`,
+		`<input checked="" disabled="" type="checkbox"> done`,
+		`<input disabled="" type="checkbox"> open`,
+		`<a href="#fn:1" class="footnote-ref" role="doc-noteref">1</a>`,
+		`<li id="fn:1">`,
+		`<del>removed</del>`,
+	} {
+		if !strings.Contains(html, expected) {
+			t.Fatalf("html should contain '%s' (curr: %s)", expected, html)
+		}
+	}
+}
+
+func TestToHtmlRawHtml(t *testing.T) {
+	md := "<PdfFooter>footer</PdfFooter>\n\n**bold**"
+
+	allowed, err := ToHtml(md, true)
+	if err != nil {
+		t.Fatalf("cant convert markdown: %v", err)
+	}
+
+	if !strings.Contains(allowed, "<PdfFooter>footer</PdfFooter>") {
+		t.Fatalf("raw html should be rendered if allowed (curr: %s)", allowed)
+	}
+
+	escaped, err := ToHtml(md, false)
+	if err != nil {
+		t.Fatalf("cant convert markdown: %v", err)
+	}
+
+	if strings.Contains(escaped, "<PdfFooter>") || !strings.Contains(escaped, "<strong>bold</strong>") {
+		t.Fatalf("raw html should be omitted if not allowed (curr: %s)", escaped)
+	}
+}
@@ -0,0 +1,76 @@
+package markdown
+
+import (
+	"html"
+	"strings"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
+)
+
+// NewRenderData converts the markdown to a html document with the theme and applies the front matter to the options
+func NewRenderData(data *models.RenderMarkdownData) (*models.RenderData, error) {
+	fm, markdown, err := ParseFrontMatter(data.Markdown)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := data.RenderOptions
+	if err := fm.ApplyTo(&opt); err != nil {
+		return nil, err
+	}
+
+	theme := data.Theme
+	if fm.Theme != "" {
+		theme = fm.Theme
+	}
+
+	css, err := getThemeCss(theme, data.Themes)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := ToHtml(markdown, true)
+	if err != nil {
+		return nil, err
+	}
+
+	title := ""
+	if opt.Metadata != nil {
+		title = opt.Metadata.Title
+	}
+
+	doc := newHtmlDocument(title, opt.Locale, css, content)
+
+	return &models.RenderData{
+		Html:          &doc,
+		HeaderHtml:    data.HeaderHtml,
+		FooterHtml:    data.FooterHtml,
+		RenderOptions: opt,
+	}, nil
+}
+
+// newHtmlDocument wraps the converted markdown into an article with the class markdown-body used by the themes
+func newHtmlDocument(title string, lang string, css string, content string) string {
+	var sb strings.Builder
+
+	sb.WriteString("<!DOCTYPE html>\n<html")
+	if lang != "" {
+		// hyphenation of the themes depends on the language
+		sb.WriteString(` lang="` + html.EscapeString(lang) + `"`)
+	}
+	sb.WriteString(">\n<head>\n<meta charset=\"utf-8\">\n")
+
+	if title != "" {
+		sb.WriteString("<title>" + html.EscapeString(title) + "</title>\n")
+	}
+
+	if css != "" {
+		sb.WriteString("<style>\n" + css + "</style>\n")
+	}
+
+	sb.WriteString("</head>\n<body>\n<article class=\"markdown-body\">\n")
+	sb.WriteString(content)
+	sb.WriteString("</article>\n</body>\n</html>\n")
+
+	return sb.String()
+}
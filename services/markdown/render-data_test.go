@@ -0,0 +1,80 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
+)
+
+type testThemes map[string]string
+
+func (tt testThemes) GetTheme(name string) (*string, bool) {
+	css, ok := tt[name]
+	return &css, ok
+}
+
+func TestNewRenderData(t *testing.T) {
+	data, err := NewRenderData(&models.RenderMarkdownData{
+		Markdown:      markdownWithFrontMatter,
+		Theme:         "academic",
+		FooterHtml:    "<p>footer</p>",
+		RenderOptions: models.RenderOptions{Locale: "de-DE"},
+	})
+	if err != nil {
+		t.Fatalf("cant create render data: %v", err)
+	}
+
+	html := *data.Html
+
+	for _, expected := range []string{
+		`<html lang="de-DE">`,
+		"<title>Release Notes 2.0</title>",
+		"color: #1f2328",
+		`<article class="markdown-body">`,
+		`<h1 id="release-notes">Release Notes</h1>`,
+	} {
+		if !strings.Contains(html, expected) {
+			t.Fatalf("html should contain '%s' (curr: %s)", expected, html)
+		}
+	}
+
+	if data.FooterHtml != "<p>footer</p>" || data.RenderOptions.PageFormat != "A5" || data.RenderOptions.Metadata.Author != "PDF Turtle" {
+		t.Fatalf("footer, options and metadata should be set (curr: %+v)", data)
+	}
+}
+
+func TestThemes(t *testing.T) {
+	themes := testThemes{"corporate": ".markdown-body { color: navy; }", "github": ".markdown-body { color: red; }"}
+
+	cases := []struct {
+		theme    string
+		expected string
+	}{
+		{"", "Helvetica Neue"},
+		{"academic", "Latin Modern Roman"},
+		{"corporate", "color: navy"},
+		{"github", "color: red"},
+	}
+
+	for _, c := range cases {
+		css, err := getThemeCss(c.theme, themes)
+		if err != nil {
+			t.Fatalf("cant get theme '%s': %v", c.theme, err)
+		}
+
+		if !strings.Contains(css, c.expected) {
+			t.Fatalf("theme '%s' should contain '%s' (curr: %s)", c.theme, c.expected, css)
+		}
+	}
+
+	if css, err := getThemeCss(ThemeNone, themes); err != nil || css != "" {
+		t.Fatalf("theme none should be empty (curr: %s, err: %v)", css, err)
+	}
+
+	for _, theme := range []string{"unknown", "../default"} {
+		if _, err := getThemeCss(theme, nil); err == nil {
+			t.Fatalf("theme '%s' should fail", theme)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package markdown
+
+import (
+	"fmt"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
+	"github.com/lucas-gaitzsch/pdf-turtle/static-files/embed"
+)
+
+const (
+	ThemeDefault = "default"
+	// no theme, only the builtin pdf styles are used
+	ThemeNone = "none"
+
+	builtinThemesDir = "markdown-themes/"
+)
+
+// getThemeCss returns the css of the theme. Custom themes override builtin themes with the same name.
+func getThemeCss(name string, themes models.ThemesReader) (string, error) {
+	if name == "" {
+		name = ThemeDefault
+	}
+
+	if name == ThemeNone {
+		return "", nil
+	}
+
+	if themes != nil {
+		if css, found := themes.GetTheme(name); found {
+			return *css, nil
+		}
+	}
+
+	css, err := embed.BuiltinFS.ReadFile(builtinThemesDir + name + ".css")
+	if err != nil {
+		return "", fmt.Errorf("unknown markdown theme '%s'", name)
+	}
+
+	return string(css), nil
+}
@@ -0,0 +1,114 @@
+package modelcontract
+
+import (
+	"slices"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
+)
+
+// GetMissingFields returns the required paths which are not set in the model.
+// Fields of list items are missing, if any item lacks them. Values below a missing optional value are not reported.
+func GetMissingFields(paths []models.ModelPath, model any) []string {
+	optional := map[string]bool{}
+	for _, p := range paths {
+		optional[p.Path] = p.Optional
+	}
+
+	missing := []string{}
+
+	for _, p := range paths {
+		if !p.Optional && isMissing(model, splitPath(p.Path), "", optional) {
+			missing = append(missing, p.Path)
+		}
+	}
+
+	return missing
+}
+
+func isMissing(value any, segments []string, path string, optional map[string]bool) bool {
+	if len(segments) == 0 {
+		return false
+	}
+
+	path = joinPath(path, segments[0])
+
+	if segments[0] == listItems {
+		list, ok := value.([]any)
+		if !ok {
+			return true
+		}
+
+		for _, item := range list {
+			if isMissing(item, segments[1:], path, optional) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	m, ok := value.(map[string]any)
+	if !ok {
+		return true
+	}
+
+	v, found := m[segments[0]]
+
+	// null is a value, but nothing can be read below it
+	if !found || (v == nil && len(segments) > 1) {
+		return !optional[path] || len(segments) == 1
+	}
+
+	return isMissing(v, segments[1:], path, optional)
+}
+
+// GetUnusedFields returns the fields of the model which are not referenced by the paths.
+// Only the highest unused field is reported (e.g. company instead of company.name).
+func GetUnusedFields(paths []models.ModelPath, model any) []string {
+	used := map[string]bool{}
+	traversed := map[string]bool{"": true}
+
+	for _, p := range paths {
+		used[p.Path] = true
+
+		path := ""
+		for _, segment := range splitPath(p.Path) {
+			traversed[path] = true
+			path = joinPath(path, segment)
+		}
+	}
+
+	unused := map[string]bool{}
+	collectUnused(model, "", used, traversed, unused)
+
+	result := []string{}
+	for path := range unused {
+		result = append(result, path)
+	}
+	slices.Sort(result)
+
+	return result
+}
+
+func collectUnused(value any, path string, used map[string]bool, traversed map[string]bool, unused map[string]bool) {
+	// the value is used as a whole (e.g. printed or marshaled)
+	if used[path] && !traversed[path] {
+		return
+	}
+
+	if !used[path] && !traversed[path] {
+		unused[path] = true
+		return
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		for key, child := range v {
+			collectUnused(child, joinPath(path, key), used, traversed, unused)
+		}
+	case []any:
+		for _, item := range v {
+			collectUnused(item, joinPath(path, listItems), used, traversed, unused)
+		}
+	}
+}
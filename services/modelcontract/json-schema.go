@@ -0,0 +1,92 @@
+package modelcontract
+
+import (
+	"slices"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
+)
+
+type schemaNode struct {
+	properties map[string]*schemaNode
+	items      *schemaNode
+	required   bool
+}
+
+// NewJsonSchema creates a JSON Schema (draft 2020-12) of the model described by the paths.
+// Values which are only tested by conditions are not required, the type of leaf values is unknown.
+func NewJsonSchema(paths []models.ModelPath) map[string]any {
+	optional := map[string]bool{}
+	for _, p := range paths {
+		optional[p.Path] = p.Optional
+	}
+
+	root := &schemaNode{}
+
+	for _, p := range paths {
+		node, path := root, ""
+
+		for _, segment := range splitPath(p.Path) {
+			path = joinPath(path, segment)
+
+			if segment == listItems {
+				if node.items == nil {
+					node.items = &schemaNode{}
+				}
+				node = node.items
+				continue
+			}
+
+			if node.properties == nil {
+				node.properties = map[string]*schemaNode{}
+			}
+			if node.properties[segment] == nil {
+				node.properties[segment] = &schemaNode{}
+			}
+			node = node.properties[segment]
+
+			// parents of required values are required, except they are tested by a condition
+			if !p.Optional && !optional[path] {
+				node.required = true
+			}
+		}
+	}
+
+	schema := root.toJsonSchema()
+	schema["$schema"] = jsonSchemaDraft
+
+	return schema
+}
+
+func (n *schemaNode) toJsonSchema() map[string]any {
+	switch {
+	case n.items != nil:
+		return map[string]any{
+			"type":  "array",
+			"items": n.items.toJsonSchema(),
+		}
+	case len(n.properties) > 0:
+		properties := map[string]any{}
+		required := []string{}
+
+		for name, property := range n.properties {
+			properties[name] = property.toJsonSchema()
+			if property.required {
+				required = append(required, name)
+			}
+		}
+
+		slices.Sort(required)
+
+		schema := map[string]any{
+			"type":       "object",
+			"properties": properties,
+		}
+		if len(required) > 0 {
+			schema["required"] = required
+		}
+
+		return schema
+	}
+
+	return map[string]any{}
+}
@@ -0,0 +1,81 @@
+package modelcontract
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
+)
+
+const jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// listItems is the path segment of list items (e.g. items[].price)
+const listItems = "[]"
+
+// NewModelContract creates the JSON Schema of the paths and compares it with the sample model (skipped if nil)
+func NewModelContract(paths []models.ModelPath, model any) *models.ModelContract {
+	contract := &models.ModelContract{
+		Paths:         paths,
+		Schema:        NewJsonSchema(paths),
+		MissingFields: []string{},
+		UnusedFields:  []string{},
+	}
+
+	if model != nil {
+		contract.MissingFields = GetMissingFields(paths, model)
+		contract.UnusedFields = GetUnusedFields(paths, model)
+	}
+
+	return contract
+}
+
+// MergePaths merges the paths of several templates; a path stays optional, if it is optional in all templates
+func MergePaths(pathLists ...[]models.ModelPath) []models.ModelPath {
+	optional := map[string]bool{}
+
+	for _, paths := range pathLists {
+		for _, p := range paths {
+			if o, found := optional[p.Path]; found {
+				optional[p.Path] = o && p.Optional
+			} else {
+				optional[p.Path] = p.Optional
+			}
+		}
+	}
+
+	merged := make([]models.ModelPath, 0, len(optional))
+	for path, o := range optional {
+		merged = append(merged, models.ModelPath{Path: path, Optional: o})
+	}
+
+	slices.SortFunc(merged, func(a, b models.ModelPath) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	return merged
+}
+
+// splitPath splits the path in fields and list items: items[].price -> items, [], price
+func splitPath(path string) []string {
+	segments := []string{}
+
+	for _, field := range strings.Split(path, ".") {
+		name, _, _ := strings.Cut(field, listItems)
+		segments = append(segments, name)
+
+		for range strings.Count(field, listItems) {
+			segments = append(segments, listItems)
+		}
+	}
+
+	return segments
+}
+
+// joinPath appends a segment (field or list items) to the path
+func joinPath(path string, segment string) string {
+	if path == "" || segment == listItems {
+		return path + segment
+	}
+
+	return path + "." + segment
+}
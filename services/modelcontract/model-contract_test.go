@@ -0,0 +1,104 @@
+package modelcontract
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
+)
+
+var paths = []models.ModelPath{
+	{Path: "currency"},
+	{Path: "customer.address", Optional: true},
+	{Path: "customer.address.street"},
+	{Path: "customer.name"},
+	{Path: "items"},
+	{Path: "items[]"},
+	{Path: "items[].price"},
+	{Path: "items[].title"},
+}
+
+const jsonModel = `
+{
+	"customer": { "name": "Bruno", "vatId": "DE123" },
+	"items": [
+		{ "title": "Turtle", "price": 9.5 },
+		{ "title": "Shell", "sku": "S-1" }
+	],
+	"notes": { "internal": "x" }
+}
+`
+
+func getModel(t *testing.T) any {
+	var model any
+	if err := json.Unmarshal([]byte(jsonModel), &model); err != nil {
+		t.Fatalf("cant read model: %v", err)
+	}
+
+	return model
+}
+
+func TestSplitPath(t *testing.T) {
+	expected := []string{"rows", "[]", "[]", "cell"}
+
+	if segments := splitPath("rows[][].cell"); !reflect.DeepEqual(segments, expected) {
+		t.Fatalf("segments should be %v (curr: %v)", expected, segments)
+	}
+}
+
+func TestNewJsonSchema(t *testing.T) {
+	schema, _ := json.Marshal(NewJsonSchema(paths))
+
+	expected := `{"$schema":"https://json-schema.org/draft/2020-12/schema","properties":{` +
+		`"currency":{},` +
+		`"customer":{"properties":{"address":{"properties":{"street":{}},"required":["street"],"type":"object"},"name":{}},"required":["name"],"type":"object"},` +
+		`"items":{"items":{"properties":{"price":{},"title":{}},"required":["price","title"],"type":"object"},"type":"array"}},` +
+		`"required":["currency","customer","items"],"type":"object"}`
+
+	if string(schema) != expected {
+		t.Fatalf("schema should be\n%s\n(curr:\n%s)", expected, schema)
+	}
+}
+
+func TestGetMissingFields(t *testing.T) {
+	expected := []string{"currency", "items[].price"}
+
+	if missing := GetMissingFields(paths, getModel(t)); !reflect.DeepEqual(missing, expected) {
+		t.Fatalf("missing fields should be %v (curr: %v)", expected, missing)
+	}
+}
+
+func TestGetUnusedFields(t *testing.T) {
+	expected := []string{"customer.vatId", "items[].sku", "notes"}
+
+	if unused := GetUnusedFields(paths, getModel(t)); !reflect.DeepEqual(unused, expected) {
+		t.Fatalf("unused fields should be %v (curr: %v)", expected, unused)
+	}
+}
+
+func TestGetUnusedFieldsOfValueUsedAsWhole(t *testing.T) {
+	if unused := GetUnusedFields([]models.ModelPath{{Path: "customer"}}, getModel(t)); !reflect.DeepEqual(unused, []string{"items", "notes"}) {
+		t.Fatalf("fields of customer should be used (curr: %v)", unused)
+	}
+}
+
+func TestMergePaths(t *testing.T) {
+	merged := MergePaths(
+		[]models.ModelPath{{Path: "b", Optional: true}, {Path: "a", Optional: true}},
+		[]models.ModelPath{{Path: "b"}, {Path: "c", Optional: true}},
+	)
+
+	expected := []models.ModelPath{{Path: "a", Optional: true}, {Path: "b"}, {Path: "c", Optional: true}}
+	if !reflect.DeepEqual(merged, expected) {
+		t.Fatalf("merged paths should be %v (curr: %v)", expected, merged)
+	}
+}
+
+func TestNewModelContractWithoutModel(t *testing.T) {
+	contract := NewModelContract(paths, nil)
+
+	if len(contract.MissingFields) != 0 || len(contract.UnusedFields) != 0 || contract.Schema == nil {
+		t.Fatalf("contract without model should only contain the schema (curr: %+v)", contract)
+	}
+}
@@ -0,0 +1,102 @@
+package modelcontract
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// location of the schema for the compiler
+const schemaUrl = "model-schema.json"
+
+var violationPrinter = message.NewPrinter(language.English)
+
+// ValidationError is returned if the model does not match the JSON Schema
+type ValidationError struct {
+	Violations []models.ModelViolation
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		msgs[i] = fmt.Sprintf("%s: %s", v.Pointer, v.Message)
+	}
+
+	return "model does not match the schema: " + strings.Join(msgs, "; ")
+}
+
+// ValidateModel validates the model against the JSON Schema (decoded JSON). The violations are returned as *ValidationError.
+// References to other resources (files or urls) are not loaded.
+func ValidateModel(schema any, model any) error {
+	compiler := jsonschema.NewCompiler()
+	compiler.UseLoader(jsonschema.SchemeURLLoader{})
+
+	if err := compiler.AddResource(schemaUrl, schema); err != nil {
+		return fmt.Errorf("cant read model schema: %w", err)
+	}
+
+	s, err := compiler.Compile(schemaUrl)
+	if err != nil {
+		return fmt.Errorf("invalid model schema: %w", err)
+	}
+
+	err = s.Validate(model)
+
+	var schemaErr *jsonschema.ValidationError
+	if !errors.As(err, &schemaErr) {
+		return err
+	}
+
+	violations := []models.ModelViolation{}
+	collectViolations(schemaErr, &violations)
+
+	slices.SortStableFunc(violations, func(a, b models.ModelViolation) int {
+		return strings.Compare(a.Pointer, b.Pointer)
+	})
+
+	return &ValidationError{Violations: violations}
+}
+
+// collectViolations collects the causes of the error; missing properties are reported with their own pointer
+func collectViolations(err *jsonschema.ValidationError, violations *[]models.ModelViolation) {
+	if len(err.Causes) > 0 {
+		for _, cause := range err.Causes {
+			collectViolations(cause, violations)
+		}
+		return
+	}
+
+	if required, ok := err.ErrorKind.(*kind.Required); ok {
+		for _, name := range required.Missing {
+			*violations = append(*violations, models.ModelViolation{
+				Pointer: toJsonPointer(append(slices.Clone(err.InstanceLocation), name)),
+				Message: "missing property",
+			})
+		}
+		return
+	}
+
+	*violations = append(*violations, models.ModelViolation{
+		Pointer: toJsonPointer(err.InstanceLocation),
+		Message: err.ErrorKind.LocalizedString(violationPrinter),
+	})
+}
+
+func toJsonPointer(tokens []string) string {
+	escaper := strings.NewReplacer("~", "~0", "/", "~1")
+
+	var sb strings.Builder
+	for _, token := range tokens {
+		sb.WriteByte('/')
+		sb.WriteString(escaper.Replace(token))
+	}
+
+	return sb.String()
+}
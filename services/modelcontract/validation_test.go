@@ -0,0 +1,100 @@
+package modelcontract
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
+)
+
+const jsonSchema = `
+{
+	"type": "object",
+	"required": ["customer", "items"],
+	"properties": {
+		"customer": {
+			"type": "object",
+			"required": ["name", "a/b"]
+		},
+		"items": {
+			"type": "array",
+			"items": {
+				"type": "object",
+				"properties": { "price": { "type": "number", "minimum": 0 } }
+			}
+		}
+	}
+}
+`
+
+func getSchema(t *testing.T) any {
+	var schema any
+	if err := json.Unmarshal([]byte(jsonSchema), &schema); err != nil {
+		t.Fatalf("cant read schema: %v", err)
+	}
+
+	return schema
+}
+
+func TestValidateModel(t *testing.T) {
+	err := ValidateModel(getSchema(t), getModel(t))
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("validation error expected (curr: %v)", err)
+	}
+
+	expected := []models.ModelViolation{
+		{Pointer: "/customer/a~1b", Message: "missing property"},
+	}
+
+	if !reflect.DeepEqual(validationErr.Violations, expected) {
+		t.Fatalf("violations should be %v (curr: %v)", expected, validationErr.Violations)
+	}
+}
+
+func TestValidateModelTypes(t *testing.T) {
+	model := map[string]any{
+		"customer": map[string]any{"name": "Bruno", "a/b": true},
+		"items":    []any{map[string]any{"price": "9.50"}, map[string]any{"price": -1.0}},
+	}
+
+	var validationErr *ValidationError
+	if !errors.As(ValidateModel(getSchema(t), model), &validationErr) {
+		t.Fatal("validation error expected")
+	}
+
+	pointers := []string{}
+	for _, v := range validationErr.Violations {
+		pointers = append(pointers, v.Pointer)
+	}
+
+	if !reflect.DeepEqual(pointers, []string{"/items/0/price", "/items/1/price"}) {
+		t.Fatalf("price of both items should be invalid (curr: %v)", validationErr.Violations)
+	}
+}
+
+func TestValidateModelValid(t *testing.T) {
+	model := map[string]any{"customer": map[string]any{"name": "Bruno", "a/b": 1.0}, "items": []any{}}
+
+	if err := ValidateModel(getSchema(t), model); err != nil {
+		t.Fatalf("model should be valid: %v", err)
+	}
+}
+
+func TestValidateModelInvalidSchema(t *testing.T) {
+	err := ValidateModel(map[string]any{"type": 5}, map[string]any{})
+
+	var validationErr *ValidationError
+	if err == nil || errors.As(err, &validationErr) {
+		t.Fatalf("invalid schema should fail without violations (curr: %v)", err)
+	}
+}
+
+func TestValidateModelDoesNotLoadReferences(t *testing.T) {
+	if err := ValidateModel(map[string]any{"$ref": "file:///etc/hostname"}, map[string]any{}); err == nil {
+		t.Fatal("referenced files should not be loaded")
+	}
+}
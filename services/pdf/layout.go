@@ -0,0 +1,192 @@
+package pdf
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
+	"github.com/lucas-gaitzsch/pdf-turtle/services/postprocessing"
+	"github.com/lucas-gaitzsch/pdf-turtle/utils/logging"
+)
+
+// documentLayout is the rendered body of a document or section
+type documentLayout struct {
+	data *models.RenderData
+	// header and footer rendered after the layout with page numbers; nil if rendered by chromium
+	headerFooter *models.RenderData
+
+	pdf              []byte
+	pageCount        int
+	destinationPages map[string]int
+}
+
+func (l *documentLayout) setPdf(pdfData io.Reader) error {
+	pdfBytes, err := io.ReadAll(pdfData)
+	if err != nil {
+		return err
+	}
+
+	pageCount, err := postprocessing.GetPageCount(pdfBytes)
+	if err != nil {
+		return err
+	}
+
+	destinationPages, err := postprocessing.GetNamedDestinationPages(pdfBytes)
+	if err != nil {
+		return fmt.Errorf("cant read named destinations: %w", err)
+	}
+
+	l.pdf = pdfBytes
+	l.pageCount = pageCount
+	l.destinationPages = destinationPages
+
+	return nil
+}
+
+// layoutBody prepares the headers and footers and renders the body
+func (ps *PdfService) layoutBody(renderCtx context.Context, data *models.RenderData) (*documentLayout, error) {
+	logging.LogExecutionTime("add styles", ps.ctx, func() {
+		ps.addDefaultStyleToHeaderAndFooter(data)
+		ps.addDefaultStyleToPageVariants(&data.HeaderHtmlPages)
+		ps.addDefaultStyleToPageVariants(&data.FooterHtmlPages)
+	})
+
+	if data.RenderOptions.AutoMargins != nil {
+		if err := ps.setAutoMargins(renderCtx, data); err != nil {
+			return nil, err
+		}
+	}
+
+	if printOptions := data.RenderOptions.Print; printOptions != nil {
+		// the layout keeps the size of the final page, the bleed is rendered around it
+		printOptions.ApplyBleed(&data.RenderOptions)
+	}
+
+	layout := &documentLayout{data: data}
+
+	if hasPageNumberPlaceholders(data) {
+		// chromium cant fill other page numbers, so the header and footer are rendered after the layout of the body
+		if data.RenderOptions.PageNumbers == nil {
+			data.RenderOptions.PageNumbers = &models.RenderOptionsPageNumbers{}
+			data.RenderOptions.PageNumbers.SetDefaults()
+		}
+
+		if err := data.RenderOptions.PageNumbers.Validate(); err != nil {
+			return nil, err
+		}
+
+		layout.headerFooter = detachHeaderAndFooter(data)
+	}
+
+	pdfData, err := ps.renderBody(renderCtx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return layout, layout.setPdf(pdfData)
+}
+
+// layoutWithToc renders the body again with the page numbers of the toc headings
+func (ps *PdfService) layoutWithToc(renderCtx context.Context, layout *documentLayout, pageNumbers map[string]int) error {
+	if err := renderCtx.Err(); err != nil {
+		return fmt.Errorf("no time left to render toc: %w", err)
+	}
+
+	ps.htmlParser.SetTocPageNumbers(pageNumbers)
+
+	html, err := ps.htmlParser.GetHtml()
+	if err != nil {
+		return err
+	}
+	layout.data.Html = html
+
+	pdfData, err := ps.renderBody(renderCtx, layout.data)
+	if err != nil {
+		return err
+	}
+
+	return layout.setPdf(pdfData)
+}
+
+// layoutBlankPages renders the body again with blank pages, so the elements with the odd page class start on odd pages and the page count is even if required.
+// pageOffset is the count of the pages before in the merged document. Returns true if blank pages were inserted.
+func (ps *PdfService) layoutBlankPages(renderCtx context.Context, layout *documentLayout, pageOffset int) (bool, error) {
+	blankPages := layout.data.RenderOptions.BlankPages
+	if blankPages == nil {
+		return false, nil
+	}
+
+	beforeIds, atEnd := blankPages.GetBlankPagePositions(layout.pageCount, layout.destinationPages, pageOffset)
+	if len(beforeIds) == 0 && !atEnd {
+		return false, nil
+	}
+
+	if err := renderCtx.Err(); err != nil {
+		return false, fmt.Errorf("no time left to render blank pages: %w", err)
+	}
+
+	ps.htmlParser.InsertBlankPages(beforeIds, atEnd, blankPages.Html)
+
+	html, err := ps.htmlParser.GetHtml()
+	if err != nil {
+		return false, err
+	}
+	layout.data.Html = html
+
+	pdfData, err := logging.LogExecutionTimeWithResults("render blank pages", ps.ctx, func() (io.Reader, error) {
+		return ps.renderBody(renderCtx, layout.data)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, layout.setPdf(pdfData)
+}
+
+// layoutHeaderAndFooterVariants renders the body again for every used header and footer variant and composes the pages.
+// The body is the same for every pass, so pageNumber and totalPages stay correct.
+func (ps *PdfService) layoutHeaderAndFooterVariants(renderCtx context.Context, layout *documentLayout) error {
+	data := layout.data
+
+	if !data.HasHeaderOrFooterVariants() {
+		return nil
+	}
+
+	passes := [][]byte{layout.pdf}
+	passIndexByVariant := map[string]int{"": 0}
+	pageSources := make([]int, layout.pageCount)
+
+	for pageNr := 1; pageNr <= layout.pageCount; pageNr++ {
+		variant := models.GetPageVariant(pageNr, layout.pageCount, &data.HeaderHtmlPages, &data.FooterHtmlPages)
+
+		passIndex, ok := passIndexByVariant[variant]
+		if !ok {
+			variantPdf, err := ps.renderPageVariant(renderCtx, data, variant)
+			if err != nil {
+				return fmt.Errorf("cant render header and footer for %s page: %w", variant, err)
+			}
+
+			passIndex = len(passes)
+			passes = append(passes, variantPdf)
+			passIndexByVariant[variant] = passIndex
+		}
+
+		pageSources[pageNr-1] = passIndex
+	}
+
+	if len(passes) == 1 {
+		return nil
+	}
+
+	composed, err := logging.LogExecutionTimeWithResults("compose header and footer variants", ps.ctx, func() ([]byte, error) {
+		return postprocessing.ComposePages(passes, pageSources)
+	})
+	if err != nil {
+		return err
+	}
+
+	layout.pdf = composed
+
+	return nil
+}
@@ -0,0 +1,187 @@
+package pdf
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
+	"github.com/lucas-gaitzsch/pdf-turtle/services/htmlparser"
+	"github.com/lucas-gaitzsch/pdf-turtle/utils/logging"
+)
+
+// same layout as the header and footer templates of chromium
+const pageNumbersFrameCss = `
+	html, body {
+		margin: 0;
+		padding: 0;
+	}
+	#header, #footer {
+		box-sizing: border-box;
+		width: 100%%;
+		height: 100%%;
+	}
+	#header > div, #footer > div {
+		box-sizing: border-box;
+		padding-left: %dmm;
+		padding-right: %dmm;
+	}
+`
+
+func hasPageNumberPlaceholders(data *models.RenderData) bool {
+	htmls := []string{data.HeaderHtml, data.FooterHtml}
+
+	for _, variant := range models.PageVariantNames {
+		for _, v := range []*string{data.HeaderHtmlPages.Get(variant), data.FooterHtmlPages.Get(variant)} {
+			if v != nil {
+				htmls = append(htmls, *v)
+			}
+		}
+	}
+
+	for _, h := range htmls {
+		if htmlparser.HasPageNumberPlaceholder(h) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// detachHeaderAndFooter removes the header and footer (including all page variants) from the data and returns them
+func detachHeaderAndFooter(data *models.RenderData) *models.RenderData {
+	headerFooter := &models.RenderData{
+		HeaderHtml:      data.HeaderHtml,
+		FooterHtml:      data.FooterHtml,
+		HeaderHtmlPages: data.HeaderHtmlPages,
+		FooterHtmlPages: data.FooterHtmlPages,
+	}
+
+	data.HeaderHtml = ""
+	data.FooterHtml = ""
+	data.HeaderHtmlPages = models.PageVariants{}
+	data.FooterHtmlPages = models.PageVariants{}
+
+	return headerFooter
+}
+
+// renderPageNumbers renders the header and footer of every page of the layout with the page numbers filled in.
+// The result is stamped on the pages while post-processing.
+func (ps *PdfService) renderPageNumbers(renderCtx context.Context, layout *documentLayout, pageNumbers []models.PageNumbers) ([]byte, error) {
+	headerFooter := layout.headerFooter
+	if headerFooter == nil {
+		// blank pages for sections with header and footer rendered by chromium
+		headerFooter = &models.RenderData{}
+	}
+
+	options := &layout.data.RenderOptions
+
+	pageNumbersHtml, err := buildPageNumbersHtml(options, headerFooter, pageNumbers)
+	if err != nil {
+		return nil, err
+	}
+
+	headerFooterData := &models.RenderData{
+		Html: &pageNumbersHtml,
+		RenderOptions: models.RenderOptions{
+			Landscape: options.Landscape,
+			PageSize:  options.PageSize,
+			Margins:   &models.RenderOptionsMargins{},
+			BasePath:  options.BasePath,
+		},
+	}
+
+	headerFooterPdf, err := logging.LogExecutionTimeWithResults("render header and footer with page numbers", ps.ctx, func() (io.Reader, error) {
+		return ps.rendererService.RenderAndReceive(*models.NewJob(renderCtx, headerFooterData))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return io.ReadAll(headerFooterPdf)
+}
+
+// buildPageNumbersHtml creates a document with one page for every page of the body. Header and footer are isolated in frames like the templates of chromium.
+func buildPageNumbersHtml(options *models.RenderOptions, headerFooter *models.RenderData, pageNumbers []models.PageNumbers) (string, error) {
+	width, height := options.PageSize.Width, options.PageSize.Height
+	if options.Landscape {
+		width, height = height, width
+	}
+
+	margins := options.Margins
+	frameCss := fmt.Sprintf(pageNumbersFrameCss, margins.Left, margins.Right)
+
+	sb := new(strings.Builder)
+	sb.WriteString(`<html><head><style>html, body { margin: 0; padding: 0; }</style></head><body>`)
+
+	pageCount := len(pageNumbers)
+
+	for i, n := range pageNumbers {
+		pageNr := i + 1
+
+		values := map[string]string{
+			"pageNumber":                      strconv.Itoa(pageNr),
+			"totalPages":                      strconv.Itoa(pageCount),
+			htmlparser.PageNumberClass:        n.PageNumber,
+			htmlparser.TotalPagesClass:        n.TotalPages,
+			htmlparser.SectionPageNumberClass: n.SectionPageNumber,
+			htmlparser.SectionTotalPagesClass: n.SectionTotalPages,
+		}
+
+		variant := models.GetPageVariant(pageNr, pageCount, &headerFooter.HeaderHtmlPages, &headerFooter.FooterHtmlPages)
+
+		headerHtml := headerFooter.HeaderHtml
+		if v := headerFooter.HeaderHtmlPages.Get(variant); v != nil {
+			headerHtml = *v
+		}
+
+		footerHtml := headerFooter.FooterHtml
+		if v := headerFooter.FooterHtmlPages.Get(variant); v != nil {
+			footerHtml = *v
+		}
+
+		pageBreak := ""
+		if pageNr > 1 {
+			pageBreak = " break-before: page;"
+		}
+
+		fmt.Fprintf(sb, `<div style="position: relative; width: %dmm; height: %dmm; overflow: hidden;%s">`, width, height, pageBreak)
+
+		if err := writePageNumbersFrame(sb, "header", headerHtml, frameCss, values, margins.Top); err != nil {
+			return "", err
+		}
+
+		if err := writePageNumbersFrame(sb, "footer", footerHtml, frameCss, values, margins.Bottom); err != nil {
+			return "", err
+		}
+
+		sb.WriteString(`</div>`)
+	}
+
+	sb.WriteString(`</body></html>`)
+
+	return sb.String(), nil
+}
+
+func writePageNumbersFrame(sb *strings.Builder, id string, content string, frameCss string, values map[string]string, heightInMm int) error {
+	if content == "" {
+		return nil
+	}
+
+	frameHtml, err := htmlparser.FillPlaceholders(fmt.Sprintf(`<style>%s</style><div id="%s">%s</div>`, frameCss, id, content), values)
+	if err != nil {
+		return fmt.Errorf("cant fill page numbers of %s: %w", id, err)
+	}
+
+	position := "top"
+	if id == "footer" {
+		position = "bottom"
+	}
+
+	fmt.Fprintf(sb, `<iframe srcdoc="%s" style="position: absolute; left: 0; %s: 0; width: 100%%; height: %dmm; border: 0"></iframe>`, html.EscapeString(frameHtml), position, heightInMm)
+
+	return nil
+}
@@ -1,10 +1,12 @@
 package pdf
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/lucas-gaitzsch/pdf-turtle/config"
 	"github.com/lucas-gaitzsch/pdf-turtle/loopback"
@@ -13,6 +15,9 @@ import (
 	"github.com/lucas-gaitzsch/pdf-turtle/services/assetsprovider"
 	"github.com/lucas-gaitzsch/pdf-turtle/services/bundles"
 	"github.com/lucas-gaitzsch/pdf-turtle/services/htmlparser"
+	"github.com/lucas-gaitzsch/pdf-turtle/services/markdown"
+	"github.com/lucas-gaitzsch/pdf-turtle/services/modelcontract"
+	"github.com/lucas-gaitzsch/pdf-turtle/services/postprocessing"
 	"github.com/lucas-gaitzsch/pdf-turtle/utils"
 	"github.com/lucas-gaitzsch/pdf-turtle/utils/logging"
 
@@ -24,6 +29,7 @@ type PdfServiceAbstraction interface {
 	PdfFromHtml(data *models.RenderData) (io.Reader, error)
 	PdfFromHtmlTemplate(templateData *models.RenderTemplateData) (io.Reader, error)
 	PdfFromBundle(bundle *bundles.Bundle, jsonModel string, templateEngine string) (io.Reader, error)
+	PdfFromMarkdown(markdownData *models.RenderMarkdownData) (io.Reader, error)
 }
 
 type PdfService struct {
@@ -33,6 +39,7 @@ type PdfService struct {
 	bundleProviderService services.BundleProviderService
 	templateService       templating.TemplateServiceAbstraction
 	htmlParser            htmlparser.HtmlParser
+	postProcessingService postprocessing.PostProcessingServiceAbstraction
 }
 
 func NewPdfService(requestctx context.Context) PdfServiceAbstraction {
@@ -43,11 +50,11 @@ func NewPdfService(requestctx context.Context) PdfServiceAbstraction {
 		bundleProviderService: getBundleProviderService(requestctx),
 		templateService:       templating.NewTemplateService(),
 		htmlParser:            htmlparser.New(),
+		postProcessingService: postprocessing.NewPostProcessingService(),
 	}
 }
 
 func (ps *PdfService) PdfFromHtml(data *models.RenderData) (io.Reader, error) {
-	ps.preProcessHtmlData(data)
 	return ps.renderPdf(data)
 }
 
@@ -55,6 +62,12 @@ func (ps *PdfService) PdfFromHtmlTemplate(templateData *models.RenderTemplateDat
 
 	templateData.ParseJsonModelDataFromDoubleEncodedString()
 
+	if templateData.ModelSchema != nil {
+		if err := modelcontract.ValidateModel(templateData.ModelSchema, templateData.Model); err != nil {
+			return nil, err
+		}
+	}
+
 	data, err := logging.LogExecutionTimeWithResults("exec template", ps.ctx, func() (*models.RenderData, error) {
 		return ps.templateService.ExecuteTemplate(templateData)
 	})
@@ -66,6 +79,18 @@ func (ps *PdfService) PdfFromHtmlTemplate(templateData *models.RenderTemplateDat
 	return ps.renderPdf(data)
 }
 
+func (ps *PdfService) PdfFromMarkdown(markdownData *models.RenderMarkdownData) (io.Reader, error) {
+	data, err := logging.LogExecutionTimeWithResults("convert markdown", ps.ctx, func() (*models.RenderData, error) {
+		return markdown.NewRenderData(markdownData)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ps.renderPdf(data)
+}
+
 func (ps *PdfService) PdfFromBundle(bundle *bundles.Bundle, jsonModel string, templateEngine string) (io.Reader, error) {
 	conf := config.Get(ps.ctx)
 
@@ -75,9 +100,39 @@ func (ps *PdfService) PdfFromBundle(bundle *bundles.Bundle, jsonModel string, te
 	opt := bundle.GetOptions()
 	opt.BasePath = fmt.Sprintf("http://127.0.0.1:%d%s/%s/", conf.LoopbackPort, loopback.BundlePath, id)
 
+	attachments, err := ps.getBundleAttachments(bundle, &opt, jsonModel != "", templateEngine)
+	if err != nil {
+		return nil, err
+	}
+	opt.Attachments = attachments
+
+	if opt.Watermark != nil {
+		if err := bundle.LoadWatermarkImage(opt.Watermark); err != nil {
+			return nil, err
+		}
+	}
+
+	if opt.Stationery != nil {
+		if err := bundle.LoadStationery(opt.Stationery); err != nil {
+			return nil, err
+		}
+	}
+
 	var pdfData io.Reader
 	var errRender error
 
+	if bundle.IsMarkdown() {
+		log.Debug().Msg("got index.md in bundle -> render markdown (the model is not used)")
+
+		return ps.PdfFromMarkdown(&models.RenderMarkdownData{
+			Markdown:      bundle.GetMarkdown(),
+			HeaderHtml:    bundle.GetHeaderHtml(),
+			FooterHtml:    bundle.GetFooterHtml(),
+			RenderOptions: opt,
+			Themes:        bundle,
+		})
+	}
+
 	hasModel := jsonModel != ""
 	hasModelLoggingPreparation := log.Debug().Bool("hasModel", hasModel)
 
@@ -90,11 +145,18 @@ func (ps *PdfService) PdfFromBundle(bundle *bundles.Bundle, jsonModel string, te
 				Msg("got templateEngine in form data")
 		}
 
+		modelSchema, err := bundle.GetModelSchema()
+		if err != nil {
+			return nil, err
+		}
+
 		templateData := &models.RenderTemplateData{
 			HtmlTemplate:       bundle.GetBodyHtml(),
 			HeaderHtmlTemplate: bundle.GetHeaderHtml(),
 			FooterHtmlTemplate: bundle.GetFooterHtml(),
 			TemplateEngine:     templateEngine,
+			ModelSchema:        modelSchema,
+			Partials:           bundle,
 			RenderOptions:      opt,
 		}
 
@@ -117,35 +179,271 @@ func (ps *PdfService) PdfFromBundle(bundle *bundles.Bundle, jsonModel string, te
 	return pdfData, errRender
 }
 
+// getBundleAttachments reads the attachments of the bundle. Without template execution (no model or markdown) the attachment templates are executed with an empty model.
+func (ps *PdfService) getBundleAttachments(bundle *bundles.Bundle, opt *models.RenderOptions, hasModel bool, templateEngine string) ([]models.Attachment, error) {
+	attachments, err := bundle.GetAttachments(opt.Attachments)
+	if err != nil {
+		return nil, err
+	}
+
+	if hasModel && !bundle.IsMarkdown() {
+		// executed with the html templates
+		return attachments, nil
+	}
+
+	return ps.templateService.ExecuteAttachmentTemplates(attachments, templateEngine, opt.Locale, nil)
+}
+
 func (ps *PdfService) renderPdf(data *models.RenderData) (io.Reader, error) {
-	ps.preProcessHtmlData(data)
+	if err := ps.preProcessHtmlData(data); err != nil {
+		return nil, err
+	}
 
 	data.SetDefaults()
 
-	logging.LogExecutionTime("add styles", ps.ctx, func() {
-		ps.addDefaultStyleToHeaderAndFooter(data)
+	// all render passes share the render timeout
+	renderCtx, cancel := context.WithTimeout(ps.ctx, time.Duration(config.Get(ps.ctx).RenderTimeoutInSeconds)*time.Second)
+	defer cancel()
 
-		if !data.RenderOptions.ExcludeBuiltinStyles {
-			data.Html = utils.AppendStyleToHtml(data.Html, ps.assetsProviderService.GetMergedCss())
-		}
+	var pdfData io.Reader
+	var err error
+
+	if len(data.Sections) > 0 {
+		pdfData, err = ps.renderSections(renderCtx, data)
+	} else {
+		pdfData, err = ps.renderDocument(renderCtx, data)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ps.renderWatermarkHtml(renderCtx, data); err != nil {
+		return nil, err
+	}
+
+	return logging.LogExecutionTimeWithResults("post-process pdf", ps.ctx, func() (io.Reader, error) {
+		return ps.postProcessingService.Process(ps.ctx, pdfData, data)
 	})
+}
+
+// renderDocument renders the body with the table of contents and the headers and footers
+func (ps *PdfService) renderDocument(renderCtx context.Context, data *models.RenderData) (io.Reader, error) {
+	layout, err := ps.layoutBody(renderCtx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if ps.htmlParser.HasToc() {
+		// the page numbers are taken from the first render pass
+		if err := ps.layoutWithToc(renderCtx, layout, layout.destinationPages); err != nil {
+			return nil, err
+		}
+	}
+
+	hasBlankPages, err := ps.layoutBlankPages(renderCtx, layout, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if hasBlankPages && ps.htmlParser.HasToc() {
+		if err := ps.layoutWithToc(renderCtx, layout, layout.destinationPages); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := ps.layoutHeaderAndFooterVariants(renderCtx, layout); err != nil {
+		return nil, err
+	}
+
+	if layout.headerFooter != nil {
+		pageNumbers := data.RenderOptions.PageNumbers.GetPageNumbers(layout.pageCount, layout.destinationPages)
+
+		if data.RenderOptions.PageNumbers.HeaderFooterPdf, err = ps.renderPageNumbers(renderCtx, layout, pageNumbers); err != nil {
+			return nil, err
+		}
+	}
+
+	return bytes.NewReader(layout.pdf), nil
+}
+
+// setAutoMargins measures the headers and footers (including all page variants) and sets the margins top and bottom.
+// The same margins are used for all render passes, so the layout of the body does not change.
+func (ps *PdfService) setAutoMargins(renderCtx context.Context, data *models.RenderData) error {
+	autoMargins := data.RenderOptions.AutoMargins
+
+	headerHeight, err := ps.measureMaxHtmlHeight(renderCtx, data, data.HeaderHtml, &data.HeaderHtmlPages)
+	if err != nil {
+		return fmt.Errorf("cant measure header: %w", err)
+	}
+
+	footerHeight, err := ps.measureMaxHtmlHeight(renderCtx, data, data.FooterHtml, &data.FooterHtmlPages)
+	if err != nil {
+		return fmt.Errorf("cant measure footer: %w", err)
+	}
+
+	warnIfTooHigh := func(name string, height float64) {
+		if height+float64(autoMargins.Spacing) > float64(autoMargins.Max) {
+			logging.Warn(ps.ctx, fmt.Sprintf("%s (%.0f mm) does not fit into the max auto margin (%d mm)", name, height, autoMargins.Max))
+		}
+	}
+	warnIfTooHigh("header", headerHeight)
+	warnIfTooHigh("footer", footerHeight)
+
+	margins := *data.RenderOptions.Margins
+	margins.Top = autoMargins.MarginForHeight(headerHeight)
+	margins.Bottom = autoMargins.MarginForHeight(footerHeight)
+	data.RenderOptions.Margins = &margins
+
+	log.Ctx(ps.ctx).Debug().
+		Float64("headerHeight", headerHeight).
+		Float64("footerHeight", footerHeight).
+		Int("marginTop", margins.Top).
+		Int("marginBottom", margins.Bottom).
+		Msg("set auto margins")
+
+	return nil
+}
+
+func (ps *PdfService) measureMaxHtmlHeight(renderCtx context.Context, data *models.RenderData, html string, variants *models.PageVariants) (float64, error) {
+	htmls := []string{html}
+
+	for _, variant := range models.PageVariantNames {
+		if v := variants.Get(variant); v != nil {
+			htmls = append(htmls, *v)
+		}
+	}
+
+	maxHeight := 0.0
+
+	for _, h := range htmls {
+		if h == "" {
+			continue
+		}
+
+		height, err := logging.LogExecutionTimeWithResults("measure html height", ps.ctx, func() (float64, error) {
+			return ps.rendererService.MeasureHtmlHeight(renderCtx, h, &data.RenderOptions)
+		})
+		if err != nil {
+			return 0, err
+		}
+
+		maxHeight = max(maxHeight, height)
+	}
+
+	return maxHeight, nil
+}
+
+// renderBody renders the data with the builtin styles appended to the body html
+func (ps *PdfService) renderBody(renderCtx context.Context, data *models.RenderData) (io.Reader, error) {
+	bodyData := *data
+
+	if !data.RenderOptions.ExcludeBuiltinStyles {
+		bodyData.Html = utils.AppendStyleToHtml(data.Html, ps.assetsProviderService.GetMergedCss())
+	}
 
 	return logging.LogExecutionTimeWithResults("render pdf", ps.ctx, func() (io.Reader, error) {
-		return ps.rendererService.RenderAndReceive(*models.NewJob(ps.ctx, data))
+		return ps.rendererService.RenderAndReceive(*models.NewJob(renderCtx, &bodyData))
+	})
+}
+
+func (ps *PdfService) renderPageVariant(renderCtx context.Context, data *models.RenderData, variant string) ([]byte, error) {
+	variantData := *data
+
+	if headerHtml := data.HeaderHtmlPages.Get(variant); headerHtml != nil {
+		variantData.HeaderHtml = *headerHtml
+	}
+
+	if footerHtml := data.FooterHtmlPages.Get(variant); footerHtml != nil {
+		variantData.FooterHtml = *footerHtml
+	}
+
+	pdfData, err := ps.renderBody(renderCtx, &variantData)
+	if err != nil {
+		return nil, err
+	}
+
+	return io.ReadAll(pdfData)
+}
+
+// renderWatermarkHtml renders the html of the watermark without margins and background to stamp it on the pages
+func (ps *PdfService) renderWatermarkHtml(renderCtx context.Context, data *models.RenderData) error {
+	wm := data.RenderOptions.Watermark
+	if wm == nil || wm.Html == "" {
+		return nil
+	}
+
+	html := wm.Html
+	if !data.RenderOptions.ExcludeBuiltinStyles {
+		html = *utils.AppendStyleToHtml(&html, ps.assetsProviderService.GetMergedCss())
+	}
+
+	wmData := &models.RenderData{
+		Html: &html,
+		RenderOptions: models.RenderOptions{
+			Landscape: data.RenderOptions.Landscape,
+			PageSize:  data.RenderOptions.PageSize,
+			Margins:   &models.RenderOptionsMargins{},
+			BasePath:  data.RenderOptions.BasePath,
+		},
+	}
+
+	pdfData, err := logging.LogExecutionTimeWithResults("render watermark html", ps.ctx, func() (io.Reader, error) {
+		return ps.rendererService.RenderAndReceive(*models.NewJob(renderCtx, wmData))
 	})
+	if err != nil {
+		return err
+	}
+
+	wm.HtmlPdf, err = io.ReadAll(pdfData)
+
+	return err
 }
 
-func (ps *PdfService) preProcessHtmlData(data *models.RenderData) {
+func (ps *PdfService) preProcessHtmlData(data *models.RenderData) error {
 	if data.Html == nil {
-		return
+		if len(data.Sections) == 0 {
+			return nil
+		}
+
+		// the html is optional for sections
+		empty := ""
+		data.Html = &empty
 	}
 
-	if !data.HasHeaderOrFooterHtml() || !data.RenderOptions.ExcludeBuiltinStyles {
+	hasTocPlaceholder := htmlparser.HasTocPlaceholder(data.Html)
+	// the toc of a section is prepared for the whole document and its page numbers are set on the parsed dom
+	hasPreparedToc := htmlparser.HasPreparedToc(data.Html)
+
+	outline := data.RenderOptions.Outline
+	hasBookmarks := outline != nil
+
+	hasPageNumberSections := htmlparser.HasPageNumberSections(data.Html)
+
+	hasOddPageStarts := htmlparser.HasOddPageStarts(data.Html)
+	// blank pages are inserted into the parsed dom
+	hasBlankPages := hasOddPageStarts || data.RenderOptions.BlankPages != nil
+
+	hasSections := len(data.Sections) > 0 || htmlparser.HasSectionPlaceholder(data.Html)
+
+	if !data.HasHeaderOrFooterHtml() || !data.RenderOptions.ExcludeBuiltinStyles || hasTocPlaceholder || hasPreparedToc || hasBookmarks || hasPageNumberSections || hasBlankPages || hasSections {
 
 		logging.LogExecutionTime("parse dom", ps.ctx, func() {
 			ps.htmlParser.Parse(data.Html)
 		})
 
+		if len(data.Sections) > 0 {
+			if err := ps.htmlParser.AddSections(data.Sections); err != nil {
+				return err
+			}
+		}
+
+		if !data.HasHeaderOrFooterVariants() {
+			logging.LogExecutionTime("pop header and footer variants from html", ps.ctx, func() {
+				data.HeaderHtmlPages, data.FooterHtmlPages = ps.htmlParser.PopHeaderAndFooterVariants()
+			})
+		}
+
 		if !data.HasHeaderOrFooterHtml() {
 			// parse header and footer from main html
 			logging.LogExecutionTime("pop header and footer from html", ps.ctx, func() {
@@ -155,6 +453,56 @@ func (ps *PdfService) preProcessHtmlData(data *models.RenderData) {
 			})
 		}
 
+		if hasTocPlaceholder {
+			logging.LogExecutionTime("prepare toc", ps.ctx, func() {
+				ps.htmlParser.PrepareToc()
+			})
+		}
+
+		if hasBookmarks {
+			logging.LogExecutionTime("prepare bookmarks", ps.ctx, func() {
+				// the entries are prepared anyway as fallback for chromium versions without document outline
+				outline.GeneratedByChromium = utils.TrimStrWhitespace(outline.Selector) == "" && !hasBookmarkAttributes(data)
+
+				selector := outline.Selector
+				if outline.GeneratedByChromium {
+					selector = htmlparser.HeadingsBookmarkSelector
+				}
+
+				outline.Entries = ps.htmlParser.PrepareBookmarks(selector)
+			})
+		}
+
+		if hasPageNumberSections {
+			logging.LogExecutionTime("prepare page number sections", ps.ctx, func() {
+				if data.RenderOptions.PageNumbers == nil {
+					data.RenderOptions.PageNumbers = &models.RenderOptionsPageNumbers{}
+				}
+				data.RenderOptions.PageNumbers.Sections = ps.htmlParser.PrepareSections()
+			})
+		}
+
+		if hasOddPageStarts {
+			logging.LogExecutionTime("prepare odd page starts", ps.ctx, func() {
+				if data.RenderOptions.BlankPages == nil {
+					data.RenderOptions.BlankPages = &models.RenderOptionsBlankPages{}
+				}
+				data.RenderOptions.BlankPages.OddPageStarts = ps.htmlParser.PrepareOddPageStarts()
+			})
+		}
+
+		if hasSections {
+			// toc, bookmarks and page number sections are prepared for the whole document before splitting
+			sections, err := logging.LogExecutionTimeWithResults("pop sections", ps.ctx, func() ([]models.RenderSection, error) {
+				return ps.htmlParser.PopSections()
+			})
+			if err != nil {
+				return err
+			}
+
+			data.Sections = sections
+		}
+
 		body, err := logging.LogExecutionTimeWithResults("parse dom", ps.ctx, func() (*string, error) {
 			return ps.htmlParser.GetHtml()
 		})
@@ -162,9 +510,11 @@ func (ps *PdfService) preProcessHtmlData(data *models.RenderData) {
 		if err == nil {
 			data.Html = body
 		} else {
-			log.Ctx(ps.ctx).Warn().Err(err).Msg("cant get html from parsed dom")
+			logging.Warn(ps.ctx, "cant get html from parsed dom: "+err.Error())
 		}
 	}
+
+	return nil
 }
 
 func (ps *PdfService) addDefaultStyleToHeaderAndFooter(data HtmlModels) {
@@ -178,10 +528,37 @@ func (ps *PdfService) addDefaultStyleToHeaderAndFooter(data HtmlModels) {
 			data.SetFooterHtml(*utils.AppendStyleToHtml(&footerHtml, defaultCss))
 		}
 	} else {
-		log.Ctx(ps.ctx).Warn().Msg("could not load default styles")
+		logging.Warn(ps.ctx, "could not load default styles")
+	}
+}
+
+func (ps *PdfService) addDefaultStyleToPageVariants(variants *models.PageVariants) {
+	defaultCss, ok := ps.assetsProviderService.GetCssByKey(assetsprovider.DefaultPdfStyles)
+	if !ok {
+		return
+	}
+
+	for _, variant := range models.PageVariantNames {
+		if html := variants.Get(variant); html != nil && *html != "" {
+			variants.Set(variant, utils.AppendStyleToHtml(html, defaultCss))
+		}
 	}
 }
 
+func hasBookmarkAttributes(data *models.RenderData) bool {
+	if htmlparser.HasBookmarkAttributes(data.Html) {
+		return true
+	}
+
+	for _, s := range data.Sections {
+		if htmlparser.HasBookmarkAttributes(s.Html) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func getRendererService(ctx context.Context) services.RendererBackgroundService {
 	return ctx.Value(config.ContextKeyRendererService).(services.RendererBackgroundService)
 }
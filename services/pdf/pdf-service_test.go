@@ -0,0 +1,51 @@
+package pdf
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/services/bundles"
+	"github.com/lucas-gaitzsch/pdf-turtle/services/templating"
+)
+
+type stringOpener string
+
+func (s stringOpener) Open() (io.ReadCloser, error) {
+	return io.NopCloser(strings.NewReader(string(s))), nil
+}
+
+func TestGetBundleAttachmentsWithoutTemplateExecution(t *testing.T) {
+	ps := &PdfService{ctx: context.Background(), templateService: templating.NewTemplateService()}
+
+	htmlBundle := &bundles.Bundle{}
+	htmlBundle.AddFile(bundles.BundleIndexFile, stringOpener("<p>invoice</p>"))
+	htmlBundle.AddFile(bundles.BundleEInvoiceFile, stringOpener(`<invoice>{{if .number}}{{.number}}{{else}}draft{{end}}</invoice>`))
+
+	markdownBundle := &bundles.Bundle{}
+	markdownBundle.AddFile(bundles.BundleMarkdownIndexFile, stringOpener("# Invoice"))
+	markdownBundle.AddFile(bundles.BundleEInvoiceFile, stringOpener(`<invoice>{{if .number}}{{.number}}{{else}}draft{{end}}</invoice>`))
+
+	cases := []struct {
+		name     string
+		bundle   *bundles.Bundle
+		hasModel bool
+	}{
+		{"html bundle without model", htmlBundle, false},
+		{"markdown bundle", markdownBundle, true},
+	}
+
+	for _, c := range cases {
+		opt := c.bundle.GetOptions()
+
+		attachments, err := ps.getBundleAttachments(c.bundle, &opt, c.hasModel, "")
+		if err != nil {
+			t.Fatalf("%s: cant get attachments: %v", c.name, err)
+		}
+
+		if len(attachments) != 1 || attachments[0].IsTemplate || string(attachments[0].Content) != "<invoice>draft</invoice>" {
+			t.Fatalf("%s: e-invoice template should be executed with an empty model (curr: %v)", c.name, attachments)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package pdf
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
+	"github.com/lucas-gaitzsch/pdf-turtle/services/htmlparser"
+	"github.com/lucas-gaitzsch/pdf-turtle/services/postprocessing"
+	"github.com/lucas-gaitzsch/pdf-turtle/utils/logging"
+)
+
+// renderSections renders every section with its own page options and merges them.
+// The table of contents and the page numbers use the pages of the merged document.
+func (ps *PdfService) renderSections(renderCtx context.Context, data *models.RenderData) (io.Reader, error) {
+	sectionServices := make([]*PdfService, len(data.Sections))
+	layouts := make([]*documentLayout, len(data.Sections))
+
+	for i := range data.Sections {
+		sectionService := ps.newSectionService()
+
+		sectionData, err := sectionService.newSectionData(data, &data.Sections[i])
+		if err != nil {
+			return nil, fmt.Errorf("cant prepare section %d: %w", i+1, err)
+		}
+
+		layout, err := sectionService.layoutBody(renderCtx, sectionData)
+		if err != nil {
+			return nil, fmt.Errorf("cant render section %d: %w", i+1, err)
+		}
+
+		sectionServices[i] = sectionService
+		layouts[i] = layout
+	}
+
+	hasBlankPages := false
+	pageOffset := 0
+
+	for i, layout := range layouts {
+		if sectionServices[i].htmlParser.HasToc() {
+			if err := sectionServices[i].layoutWithToc(renderCtx, layout, getMergedDestinationPages(layouts)); err != nil {
+				return nil, fmt.Errorf("cant render toc of section %d: %w", i+1, err)
+			}
+		}
+
+		inserted, err := sectionServices[i].layoutBlankPages(renderCtx, layout, pageOffset)
+		if err != nil {
+			return nil, fmt.Errorf("cant render blank pages of section %d: %w", i+1, err)
+		}
+
+		hasBlankPages = hasBlankPages || inserted
+		pageOffset += layout.pageCount
+	}
+
+	for i, layout := range layouts {
+		if hasBlankPages && sectionServices[i].htmlParser.HasToc() {
+			if err := sectionServices[i].layoutWithToc(renderCtx, layout, getMergedDestinationPages(layouts)); err != nil {
+				return nil, fmt.Errorf("cant render toc of section %d: %w", i+1, err)
+			}
+		}
+
+		if err := sectionServices[i].layoutHeaderAndFooterVariants(renderCtx, layout); err != nil {
+			return nil, fmt.Errorf("cant render section %d: %w", i+1, err)
+		}
+	}
+
+	pdfs := make([][]byte, len(layouts))
+	for i, layout := range layouts {
+		pdfs[i] = layout.pdf
+	}
+
+	merged, err := logging.LogExecutionTimeWithResults("merge sections", ps.ctx, func() ([]byte, error) {
+		return postprocessing.MergePdfs(pdfs)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ps.renderSectionPageNumbers(renderCtx, data, sectionServices, layouts); err != nil {
+		return nil, err
+	}
+
+	return bytes.NewReader(merged), nil
+}
+
+// renderSectionPageNumbers renders the header and footer with page numbers of all sections, if any section uses page number placeholders
+func (ps *PdfService) renderSectionPageNumbers(renderCtx context.Context, data *models.RenderData, sectionServices []*PdfService, layouts []*documentLayout) error {
+	hasPageNumbers := false
+	pageCount := 0
+
+	for _, layout := range layouts {
+		hasPageNumbers = hasPageNumbers || layout.headerFooter != nil
+		pageCount += layout.pageCount
+	}
+
+	if !hasPageNumbers {
+		return nil
+	}
+
+	if data.RenderOptions.PageNumbers == nil {
+		data.RenderOptions.PageNumbers = &models.RenderOptionsPageNumbers{}
+		data.RenderOptions.PageNumbers.SetDefaults()
+	}
+
+	pageNumbers := data.RenderOptions.PageNumbers.GetPageNumbers(pageCount, getMergedDestinationPages(layouts))
+
+	headerFooterPdfs := make([][]byte, len(layouts))
+	offset := 0
+
+	for i, layout := range layouts {
+		pdf, err := sectionServices[i].renderPageNumbers(renderCtx, layout, pageNumbers[offset:offset+layout.pageCount])
+		if err != nil {
+			return fmt.Errorf("cant render header and footer of section %d: %w", i+1, err)
+		}
+
+		headerFooterPdfs[i] = pdf
+		offset += layout.pageCount
+	}
+
+	headerFooterPdf, err := postprocessing.MergePdfs(headerFooterPdfs)
+	if err != nil {
+		return err
+	}
+
+	data.RenderOptions.PageNumbers.HeaderFooterPdf = headerFooterPdf
+
+	return nil
+}
+
+// newSectionService returns a service with its own html parser for the section
+func (ps *PdfService) newSectionService() *PdfService {
+	sectionService := *ps
+	sectionService.htmlParser = htmlparser.New()
+
+	return &sectionService
+}
+
+// newSectionData parses the header and footer of the section. Without own header or footer the ones of the document are used.
+func (ps *PdfService) newSectionData(data *models.RenderData, section *models.RenderSection) (*models.RenderData, error) {
+	options, err := section.GetRenderOptions(&data.RenderOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	sectionData := &models.RenderData{
+		Html:          section.Html,
+		HeaderHtml:    section.HeaderHtml,
+		FooterHtml:    section.FooterHtml,
+		RenderOptions: options,
+	}
+
+	if err := ps.preProcessHtmlData(sectionData); err != nil {
+		return nil, err
+	}
+
+	if sectionData.HeaderHtml == "" {
+		sectionData.HeaderHtml = data.HeaderHtml
+
+		if sectionData.HeaderHtmlPages.IsEmpty() {
+			sectionData.HeaderHtmlPages = data.HeaderHtmlPages
+		}
+	}
+
+	if sectionData.FooterHtml == "" {
+		sectionData.FooterHtml = data.FooterHtml
+
+		if sectionData.FooterHtmlPages.IsEmpty() {
+			sectionData.FooterHtmlPages = data.FooterHtmlPages
+		}
+	}
+
+	sectionData.SetDefaults()
+
+	return sectionData, nil
+}
+
+// getMergedDestinationPages returns the pages of the named destinations of all sections in the merged document
+func getMergedDestinationPages(layouts []*documentLayout) map[string]int {
+	res := make(map[string]int)
+	offset := 0
+
+	for _, layout := range layouts {
+		for name, pageNr := range layout.destinationPages {
+			res[name] = offset + pageNr
+		}
+
+		offset += layout.pageCount
+	}
+
+	return res
+}
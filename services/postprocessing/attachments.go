@@ -0,0 +1,154 @@
+package postprocessing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"path"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+const defaultAttachmentMimeType = "application/octet-stream"
+
+type attachmentsProcessor struct{}
+
+func (p *attachmentsProcessor) name() string {
+	return "attachments"
+}
+
+func (p *attachmentsProcessor) isRequired(data *models.RenderData) bool {
+	return len(data.RenderOptions.Attachments) > 0
+}
+
+func (p *attachmentsProcessor) process(ctx context.Context, doc *document, data *models.RenderData) error {
+	eInvoice := data.RenderOptions.EInvoice
+
+	for _, a := range data.RenderOptions.Attachments {
+		if a.IsTemplate {
+			// only the template and bundle endpoints execute templates
+			return fmt.Errorf("attachment '%s' is a template, but was not executed", a.Name)
+		}
+
+		relationship := a.Relationship
+
+		if relationship == "" {
+			if eInvoice != nil && a.Name == eInvoice.DocumentFileName {
+				relationship = eInvoice.Relationship()
+			} else {
+				relationship = models.AttachmentRelationshipUnspecified
+			}
+		}
+
+		if err := addAttachment(doc, a, relationship); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addAttachment embeds the file as associated file (PDF/A-3) and registers it in the EmbeddedFiles name tree and the AF array of the catalog
+func addAttachment(doc *document, a models.Attachment, relationship string) error {
+	if a.Name == "" {
+		return errors.New("attachment without name")
+	}
+
+	xRefTable := doc.XRefTable
+
+	mimeType := a.MimeType
+	if mimeType == "" {
+		mimeType = getMimeTypeByFileName(a.Name)
+	}
+
+	sd, err := xRefTable.NewStreamDictForBuf(a.Content)
+	if err != nil {
+		return err
+	}
+
+	params := types.NewDict()
+	params.InsertInt("Size", len(a.Content))
+	params.InsertString("ModDate", types.DateString(doc.date))
+
+	sd.InsertName("Type", "EmbeddedFile")
+	sd.InsertName("Subtype", mimeType)
+	sd.Insert("Params", params)
+
+	if err := sd.Encode(); err != nil {
+		return err
+	}
+
+	sdIndRef, err := xRefTable.IndRefForNewObject(*sd)
+	if err != nil {
+		return err
+	}
+
+	fileSpec, err := xRefTable.NewFileSpecDict(a.Name, a.Name, a.Description, *sdIndRef)
+	if err != nil {
+		return err
+	}
+
+	// collection items are not used
+	fileSpec.Delete("CI")
+	fileSpec.InsertName("AFRelationship", relationship)
+
+	fileSpecIndRef, err := xRefTable.IndRefForNewObject(fileSpec)
+	if err != nil {
+		return err
+	}
+
+	if err := xRefTable.LocateNameTree("EmbeddedFiles", true); err != nil {
+		return err
+	}
+
+	m := model.NameMap{a.Name: []types.Dict{fileSpec}}
+
+	if err := xRefTable.Names["EmbeddedFiles"].Add(xRefTable, a.Name, *fileSpecIndRef, m, []string{"F", "UF"}); err != nil {
+		return err
+	}
+
+	return appendToAssociatedFiles(doc, *fileSpecIndRef)
+}
+
+func appendToAssociatedFiles(doc *document, fileSpecIndRef types.IndirectRef) error {
+	catalog, err := doc.catalog()
+	if err != nil {
+		return err
+	}
+
+	af := types.Array{}
+
+	if o, found := catalog.Find("AF"); found {
+		existing, err := doc.DereferenceArray(o)
+		if err != nil {
+			return err
+		}
+		af = append(af, existing...)
+	}
+
+	af = append(af, fileSpecIndRef)
+
+	catalog.Update("AF", af)
+
+	return nil
+}
+
+func getMimeTypeByFileName(fileName string) string {
+	mimeType := mime.TypeByExtension(path.Ext(fileName))
+
+	if mimeType == "" {
+		return defaultAttachmentMimeType
+	}
+
+	// parameters like charset are not allowed in the subtype of embedded files
+	mediaType, _, err := mime.ParseMediaType(mimeType)
+	if err != nil {
+		return defaultAttachmentMimeType
+	}
+
+	return mediaType
+}
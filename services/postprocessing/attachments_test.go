@@ -0,0 +1,111 @@
+package postprocessing
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+)
+
+func TestAddAttachments(t *testing.T) {
+	data := &models.RenderData{
+		RenderOptions: models.RenderOptions{
+			Attachments: []models.Attachment{
+				{Name: "data.json", Content: []byte(`{"foo":"bar"}`), Relationship: models.AttachmentRelationshipSource},
+				{Name: "notes.txt", Content: []byte("notes"), Description: "some notes"},
+			},
+		},
+	}
+
+	res := processTestPdf(t, 1, data)
+
+	attachments, err := api.Attachments(bytes.NewReader(res), newConfiguration())
+	if err != nil {
+		t.Fatalf("cant list attachments: %v", err)
+	}
+
+	if len(attachments) != 2 {
+		t.Fatalf("pdf should contain 2 attachments (curr: %d)", len(attachments))
+	}
+
+	doc := readTestDocument(t, res)
+	catalog, _ := doc.catalog()
+
+	af, err := doc.DereferenceArray(catalog["AF"])
+	if err != nil || len(af) != 2 {
+		t.Fatalf("catalog should reference 2 associated files: %v", err)
+	}
+
+	expectedRelationships := []string{models.AttachmentRelationshipSource, models.AttachmentRelationshipUnspecified}
+
+	for i, o := range af {
+		fileSpec, err := doc.DereferenceDict(o)
+		if err != nil {
+			t.Fatalf("cant dereference file spec: %v", err)
+		}
+
+		if r := fileSpec.NameEntry("AFRelationship"); r == nil || *r != expectedRelationships[i] {
+			t.Fatalf("relationship should be %s (curr: %v)", expectedRelationships[i], r)
+		}
+	}
+}
+
+func TestAddAttachmentWithoutName(t *testing.T) {
+	doc := readTestDocument(t, newTestPdf(t, 1))
+
+	if err := addAttachment(doc, models.Attachment{Content: []byte("test")}, models.AttachmentRelationshipData); err == nil {
+		t.Fatal("attachment without name should fail")
+	}
+}
+
+func TestAttachmentMimeType(t *testing.T) {
+	doc := readTestDocument(t, newTestPdf(t, 1))
+
+	if err := addAttachment(doc, models.Attachment{Name: "factur-x.xml", Content: []byte("<xml/>")}, models.AttachmentRelationshipData); err != nil {
+		t.Fatalf("err should be nil: %v", err)
+	}
+
+	catalog, _ := doc.catalog()
+	af, _ := doc.DereferenceArray(catalog["AF"])
+	fileSpec, _ := doc.DereferenceDict(af[0])
+	ef := fileSpec.DictEntry("EF")
+	sd, _, _ := doc.DereferenceStreamDict(ef["F"])
+
+	if subtype := sd.NameEntry("Subtype"); subtype == nil || *subtype != "text/xml" {
+		t.Fatalf("mime type should be detected by file extension (curr: %v)", subtype)
+	}
+}
+
+var mimeTypesByFileName = []struct {
+	fileName string
+	mimeType string
+}{
+	{"factur-x.xml", "text/xml"},
+	{"data.json", "application/json"},
+	{"image.png", "image/png"},
+	{"unknown", defaultAttachmentMimeType},
+}
+
+func TestGetMimeTypeByFileName(t *testing.T) {
+	for _, d := range mimeTypesByFileName {
+		t.Run(d.fileName, func(t *testing.T) {
+			if mimeType := getMimeTypeByFileName(d.fileName); mimeType != d.mimeType {
+				t.Fatalf("mime type of %s should be %s (curr: %s)", d.fileName, d.mimeType, mimeType)
+			}
+		})
+	}
+}
+
+func TestAttachmentTemplateIsNotEmbedded(t *testing.T) {
+	doc := readTestDocument(t, newTestPdf(t, 1))
+
+	data := &models.RenderData{}
+	data.RenderOptions.Attachments = []models.Attachment{{Name: "factur-x.xml", Content: []byte("<invoice>{{.number}}</invoice>"), IsTemplate: true}}
+
+	if err := (&attachmentsProcessor{}).process(context.Background(), doc, data); err == nil {
+		t.Fatal("unexecuted attachment template should fail")
+	}
+}
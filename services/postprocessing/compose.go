@@ -0,0 +1,205 @@
+package postprocessing
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// page entries replaced while composing
+var composedPageKeys = []string{"Contents", "Resources"}
+
+func GetPageCount(pdfBytes []byte) (int, error) {
+	return api.PageCount(bytes.NewReader(pdfBytes), newConfiguration())
+}
+
+// GetPageSizes returns the size of the media box of every page in mm
+func GetPageSizes(pdfBytes []byte) ([]models.PageSize, error) {
+	dims, err := api.PageDims(bytes.NewReader(pdfBytes), newConfiguration())
+	if err != nil {
+		return nil, err
+	}
+
+	sizes := make([]models.PageSize, len(dims))
+	for i, d := range dims {
+		sizes[i] = models.PageSize{
+			Width:  int(math.Round(d.Width * 25.4 / 72)),
+			Height: int(math.Round(d.Height * 25.4 / 72)),
+		}
+	}
+
+	return sizes, nil
+}
+
+// MergePdfs appends the pages of all pdfs to the first one. Named destinations are kept, so links between the pdfs keep working.
+func MergePdfs(pdfs [][]byte) ([]byte, error) {
+	if len(pdfs) == 1 {
+		return pdfs[0], nil
+	}
+
+	readers := make([]io.ReadSeeker, len(pdfs))
+	for i, pdf := range pdfs {
+		readers[i] = bytes.NewReader(pdf)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := api.MergeRaw(readers, buf, false, newConfiguration()); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// ComposePages replaces the content of the pages of the first pdf with the content of the same page of other renderings of the same document
+// (e.g. with another header and footer). Links, destinations and the outline of the first pdf are kept.
+// sources contains the index of the pdf for every page.
+func ComposePages(pdfs [][]byte, sources []int) ([]byte, error) {
+	doc, err := readDocument(pdfs[0])
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sources) != doc.PageCount {
+		return nil, fmt.Errorf("page sources (%d) do not match the page count (%d)", len(sources), doc.PageCount)
+	}
+
+	srcDocs := make(map[int]*document)
+	importedBySrc := make(map[int]map[int]types.IndirectRef)
+
+	for i, src := range sources {
+		if src == 0 {
+			continue
+		}
+
+		if src < 0 || src >= len(pdfs) {
+			return nil, fmt.Errorf("invalid page source %d for page %d", src, i+1)
+		}
+
+		srcDoc, ok := srcDocs[src]
+		if !ok {
+			if srcDoc, err = readDocument(pdfs[src]); err != nil {
+				return nil, err
+			}
+
+			if srcDoc.PageCount != doc.PageCount {
+				return nil, fmt.Errorf("page count of pdf %d (%d) does not match (%d)", src, srcDoc.PageCount, doc.PageCount)
+			}
+
+			srcDocs[src] = srcDoc
+			importedBySrc[src] = make(map[int]types.IndirectRef)
+		}
+
+		if err := doc.replacePageContent(i+1, srcDoc, importedBySrc[src]); err != nil {
+			return nil, err
+		}
+	}
+
+	return doc.write()
+}
+
+func (doc *document) replacePageContent(pageNr int, srcDoc *document, imported map[int]types.IndirectRef) error {
+	pageDict, _, _, err := doc.PageDict(pageNr, false)
+	if err != nil {
+		return err
+	}
+
+	// inherited resources are consolidated into the page dict
+	srcPageDict, _, _, err := srcDoc.PageDict(pageNr, true)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range composedPageKeys {
+		o, found := srcPageDict.Find(key)
+		if !found {
+			pageDict.Delete(key)
+			continue
+		}
+
+		copied, err := doc.importObject(srcDoc.Context, o, imported)
+		if err != nil {
+			return err
+		}
+
+		pageDict.Update(key, copied)
+	}
+
+	return nil
+}
+
+// importObject deep copies an object of another document. Already imported objects are reused.
+func (doc *document) importObject(src *model.Context, o types.Object, imported map[int]types.IndirectRef) (types.Object, error) {
+	switch obj := o.(type) {
+	case types.IndirectRef:
+		if indRef, ok := imported[obj.ObjectNumber.Value()]; ok {
+			return indRef, nil
+		}
+
+		entry, found := src.FindTableEntryForIndRef(&obj)
+		if !found || entry.Free || entry.Object == nil {
+			return nil, nil
+		}
+
+		// reserve the object number first to support cyclic references
+		indRef, err := doc.IndRefForNewObject(types.Dict{})
+		if err != nil {
+			return nil, err
+		}
+		imported[obj.ObjectNumber.Value()] = *indRef
+
+		copied, err := doc.importObject(src, entry.Object, imported)
+		if err != nil {
+			return nil, err
+		}
+
+		destEntry, _ := doc.FindTableEntryForIndRef(indRef)
+		destEntry.Object = copied
+
+		return *indRef, nil
+
+	case types.Dict:
+		d := types.NewDict()
+		for k, v := range obj {
+			copied, err := doc.importObject(src, v, imported)
+			if err != nil {
+				return nil, err
+			}
+			if copied != nil {
+				d[k] = copied
+			}
+		}
+		return d, nil
+
+	case types.StreamDict:
+		d, err := doc.importObject(src, obj.Dict, imported)
+		if err != nil {
+			return nil, err
+		}
+
+		sd := obj
+		sd.Dict = d.(types.Dict)
+		sd.StreamLengthObjNr = nil
+		return sd, nil
+
+	case types.Array:
+		arr := make(types.Array, len(obj))
+		for i, v := range obj {
+			copied, err := doc.importObject(src, v, imported)
+			if err != nil {
+				return nil, err
+			}
+			arr[i] = copied
+		}
+		return arr, nil
+
+	default:
+		return o, nil
+	}
+}
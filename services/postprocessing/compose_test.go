@@ -0,0 +1,94 @@
+package postprocessing
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestComposePages(t *testing.T) {
+	base := newTestDocumentWithDestinations(t, map[string]int{"chapter": 2, "end": 3})
+
+	basePdf, err := base.write()
+	if err != nil {
+		t.Fatalf("cant write pdf: %v", err)
+	}
+
+	composed, err := ComposePages([][]byte{basePdf, newTestPdfWithText(t, 3, "Variant")}, []int{0, 1, 0})
+	if err != nil {
+		t.Fatalf("err should be nil: %v", err)
+	}
+
+	doc := readTestDocument(t, composed)
+
+	for pageNr, text := range map[int]string{1: "Page 1", 2: "Variant 2", 3: "Page 3"} {
+		if content := pageContent(t, doc, pageNr); !bytes.Contains(content, []byte(text)) {
+			t.Fatalf("page %d should contain %s (curr: %s)", pageNr, text, content)
+		}
+	}
+
+	pages, err := doc.namedDestinationPages()
+	if err != nil || pages["chapter"] != 2 || pages["end"] != 3 {
+		t.Fatalf("destinations of the base pdf should be kept (curr: %v, err: %v)", pages, err)
+	}
+}
+
+func TestComposePagesWithDifferentPageCount(t *testing.T) {
+	_, err := ComposePages([][]byte{newTestPdf(t, 2), newTestPdf(t, 1)}, []int{0, 1})
+	if err == nil {
+		t.Fatal("pdfs with different page count should fail")
+	}
+}
+
+func TestGetPageCount(t *testing.T) {
+	if pageCount, err := GetPageCount(newTestPdf(t, 3)); err != nil || pageCount != 3 {
+		t.Fatalf("page count should be 3 (curr: %d, err: %v)", pageCount, err)
+	}
+}
+
+func TestGetPageSizes(t *testing.T) {
+	sizes, err := GetPageSizes(newTestPdf(t, 2))
+	if err != nil || len(sizes) != 2 {
+		t.Fatalf("should return the size of 2 pages (curr: %v, err: %v)", sizes, err)
+	}
+
+	if sizes[0].Width != 210 || sizes[0].Height != 297 {
+		t.Fatalf("test pdf should be A4 (curr: %v)", sizes[0])
+	}
+}
+
+func pageContent(t *testing.T, doc *document, pageNr int) []byte {
+	pageDict, _, _, err := doc.PageDict(pageNr, false)
+	if err != nil {
+		t.Fatalf("cant read page %d: %v", pageNr, err)
+	}
+
+	content, err := doc.PageContent(pageDict, pageNr)
+	if err != nil {
+		t.Fatalf("cant read content of page %d: %v", pageNr, err)
+	}
+
+	return content
+}
+
+func TestMergePdfs(t *testing.T) {
+	appendix := newTestDocumentWithDestinations(t, map[string]int{"appendix": 1})
+
+	appendixPdf, err := appendix.write()
+	if err != nil {
+		t.Fatalf("cant write pdf: %v", err)
+	}
+
+	merged, err := MergePdfs([][]byte{newTestPdf(t, 2), appendixPdf})
+	if err != nil {
+		t.Fatalf("err should be nil: %v", err)
+	}
+
+	pages, err := GetNamedDestinationPages(merged)
+	if err != nil || pages["appendix"] != 3 {
+		t.Fatalf("destination should point to the page in the merged pdf (curr: %v, err: %v)", pages, err)
+	}
+
+	if pageCount, _ := GetPageCount(merged); pageCount != 3 {
+		t.Fatalf("merged pdf should have 3 pages (curr: %d)", pageCount)
+	}
+}
@@ -0,0 +1,128 @@
+package postprocessing
+
+import (
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// GetNamedDestinationPages returns the page number of every named destination of the pdf.
+// Chromium creates a named destination (the id of the element) for every target of an internal link.
+func GetNamedDestinationPages(pdfBytes []byte) (map[string]int, error) {
+	doc, err := readDocument(pdfBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return doc.namedDestinationPages()
+}
+
+func (doc *document) namedDestinationPages() (map[string]int, error) {
+	dests, err := doc.namedDestinations()
+	if err != nil {
+		return nil, err
+	}
+
+	pageNrsByObjNr, err := doc.pageNumbersByObjectNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]int, len(dests))
+
+	for name, dest := range dests {
+		switch page := dest[0].(type) {
+		case types.IndirectRef:
+			if pageNr, ok := pageNrsByObjNr[page.ObjectNumber.Value()]; ok {
+				res[name] = pageNr
+			}
+		case types.Integer:
+			// remote destinations use the zero based page index
+			res[name] = page.Value() + 1
+		}
+	}
+
+	return res, nil
+}
+
+// namedDestinations returns the explicit destination ([page /XYZ left top zoom]) of every named destination
+func (doc *document) namedDestinations() (map[string]types.Array, error) {
+	res := make(map[string]types.Array)
+
+	addDestination := func(name string, o types.Object) {
+		if dest, ok := doc.explicitDestination(o); ok {
+			res[name] = dest
+		}
+	}
+
+	catalog, err := doc.catalog()
+	if err != nil {
+		return nil, err
+	}
+
+	// PDF 1.1 style destinations
+	if o, found := catalog.Find("Dests"); found {
+		dests, err := doc.DereferenceDict(o)
+		if err != nil {
+			return nil, err
+		}
+
+		for name, dest := range dests {
+			addDestination(name, dest)
+		}
+	}
+
+	if err := doc.LocateNameTree("Dests", false); err != nil {
+		return nil, err
+	}
+
+	if tree := doc.Names["Dests"]; tree != nil {
+		err := tree.Process(doc.XRefTable, func(_ *model.XRefTable, name string, o *types.Object) error {
+			addDestination(name, *o)
+			return nil
+		})
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+func (doc *document) pageNumbersByObjectNumber() (map[int]int, error) {
+	res := make(map[int]int, doc.PageCount)
+
+	for pageNr := 1; pageNr <= doc.PageCount; pageNr++ {
+		_, indRef, _, err := doc.PageDict(pageNr, false)
+		if err != nil {
+			return nil, err
+		}
+
+		if indRef != nil {
+			res[indRef.ObjectNumber.Value()] = pageNr
+		}
+	}
+
+	return res, nil
+}
+
+// explicitDestination resolves the destination array or a dict with the destination as D entry
+func (doc *document) explicitDestination(o types.Object) (types.Array, bool) {
+	o, err := doc.Dereference(o)
+	if err != nil || o == nil {
+		return nil, false
+	}
+
+	if d, ok := o.(types.Dict); ok {
+		if o, ok = d.Find("D"); !ok {
+			return nil, false
+		}
+	}
+
+	arr, err := doc.DereferenceArray(o)
+	if err != nil || len(arr) == 0 {
+		return nil, false
+	}
+
+	return arr, true
+}
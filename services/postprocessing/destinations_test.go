@@ -0,0 +1,36 @@
+package postprocessing
+
+import (
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+func TestGetNamedDestinationPages(t *testing.T) {
+	doc := readTestDocument(t, newTestPdf(t, 3))
+
+	_, page2, _, _ := doc.PageDict(2, false)
+	_, page3, _, _ := doc.PageDict(3, false)
+
+	dests := types.NewDict()
+	dests.Insert("chapter-1", types.Array{*page2, types.Name("XYZ"), types.Integer(0), types.Integer(700), types.Integer(0)})
+	dests.Insert("chapter-2", types.Dict{"D": types.Array{*page3, types.Name("Fit")}})
+	dests.Insert("invalid", types.Array{})
+
+	catalog, _ := doc.catalog()
+	catalog.Insert("Dests", dests)
+
+	pdfBytes, err := doc.write()
+	if err != nil {
+		t.Fatalf("cant write pdf: %v", err)
+	}
+
+	pages, err := GetNamedDestinationPages(pdfBytes)
+	if err != nil {
+		t.Fatalf("err should be nil: %v", err)
+	}
+
+	if len(pages) != 2 || pages["chapter-1"] != 2 || pages["chapter-2"] != 3 {
+		t.Fatalf("destinations should be resolved to pages (curr: %v)", pages)
+	}
+}
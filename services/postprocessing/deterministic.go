@@ -0,0 +1,31 @@
+package postprocessing
+
+import (
+	"context"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
+)
+
+type deterministicProcessor struct{}
+
+func (p *deterministicProcessor) name() string {
+	return "deterministic"
+}
+
+func (p *deterministicProcessor) isRequired(data *models.RenderData) bool {
+	return data.RenderOptions.Deterministic != nil
+}
+
+// process sets the date of the document used for the info dict, the attachments and the xmp metadata.
+// The file identifier is replaced while writing.
+func (p *deterministicProcessor) process(ctx context.Context, doc *document, data *models.RenderData) error {
+	timestamp, err := data.RenderOptions.Deterministic.GetTimestamp()
+	if err != nil {
+		return err
+	}
+
+	doc.date = timestamp
+	doc.deterministic = true
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package postprocessing
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
+)
+
+func TestDeterministicOutput(t *testing.T) {
+	data := &models.RenderData{}
+	data.RenderOptions.Deterministic = &models.RenderOptionsDeterministic{Timestamp: "2024-01-01T00:00:00Z"}
+
+	first := processTestPdf(t, 2, data)
+	second := processTestPdf(t, 2, data)
+
+	if sha256.Sum256(first) != sha256.Sum256(second) {
+		t.Fatal("the same input should produce the same bytes")
+	}
+
+	if !bytes.Contains(first, []byte("D:20240101000000+00'00'")) {
+		t.Fatal("dates should be set to the timestamp")
+	}
+
+	if bytes.Equal(first, processTestPdf(t, 2, &models.RenderData{RenderOptions: models.RenderOptions{Deterministic: &models.RenderOptionsDeterministic{}}})) {
+		t.Fatal("another timestamp should produce other bytes")
+	}
+}
+
+func TestDeterministicFileIdDependsOnContent(t *testing.T) {
+	data := &models.RenderData{}
+	data.RenderOptions.Deterministic = &models.RenderOptionsDeterministic{}
+
+	first := pdfFileIdRegex.FindSubmatch(processTestPdf(t, 1, data))
+	second := pdfFileIdRegex.FindSubmatch(processTestPdf(t, 2, data))
+
+	if first == nil || second == nil {
+		t.Fatal("pdf should have a file identifier")
+	}
+
+	if bytes.Equal(first[1], second[1]) {
+		t.Fatalf("documents with different content should have different identifiers (curr: %s)", first[1])
+	}
+}
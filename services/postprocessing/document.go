@@ -0,0 +1,174 @@
+package postprocessing
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+func init() {
+	// do not read or create a pdfcpu config file in the users home directory
+	api.DisableConfigDir()
+}
+
+// document is the parsed pdf shared by all post processors of one render request
+type document struct {
+	*model.Context
+
+	// date used for the info dict and the xmp metadata
+	date time.Time
+	// replace the random file identifier with a hash of the document while writing
+	deterministic bool
+}
+
+func newConfiguration() *model.Configuration {
+	conf := model.NewDefaultConfiguration()
+	conf.Offline = true
+	conf.ValidationMode = model.ValidationRelaxed
+
+	return conf
+}
+
+func readDocument(pdfBytes []byte) (*document, error) {
+	pdfCtx, err := api.ReadValidateAndOptimize(bytes.NewReader(pdfBytes), newConfiguration())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := pdfCtx.EnsurePageCount(); err != nil {
+		return nil, err
+	}
+
+	return &document{
+		Context: pdfCtx,
+		date:    time.Now(),
+	}, nil
+}
+
+func (doc *document) write() ([]byte, error) {
+	buf := new(bytes.Buffer)
+
+	if err := api.WriteContext(doc.Context, buf); err != nil {
+		return nil, err
+	}
+
+	b := buf.Bytes()
+
+	patchDates(b, doc.date)
+
+	if doc.deterministic {
+		patchFileId(b)
+	}
+
+	return b, nil
+}
+
+var pdfDateRegex = regexp.MustCompile(`/(CreationDate|ModDate)\s*\((D:\d{14}[+\-Z]\d{2}'\d{2}')\)`)
+
+// patchDates overwrites the dates set by pdfcpu while writing with the date of the document.
+// The replacement has the same length, so the offsets of the xref table stay valid.
+func patchDates(pdfBytes []byte, date time.Time) {
+	dateStr := []byte(types.DateString(date))
+
+	for _, m := range pdfDateRegex.FindAllSubmatchIndex(pdfBytes, -1) {
+		start, end := m[4], m[5]
+
+		if end-start == len(dateStr) {
+			copy(pdfBytes[start:end], dateStr)
+		}
+	}
+}
+
+var pdfFileIdRegex = regexp.MustCompile(`/ID\s*\[\s*<([0-9A-Fa-f]+)>\s*<([0-9A-Fa-f]+)>\s*\]`)
+
+// patchFileId overwrites the file identifiers with the hash of the document without the identifiers.
+// Like the dates the replacement has the same length.
+func patchFileId(pdfBytes []byte) {
+	matches := pdfFileIdRegex.FindAllSubmatchIndex(pdfBytes, -1)
+
+	for _, m := range matches {
+		for group := 1; group <= 2; group++ {
+			start, end := m[2*group], m[2*group+1]
+			copy(pdfBytes[start:end], bytes.Repeat([]byte("0"), end-start))
+		}
+	}
+
+	sum := sha256.Sum256(pdfBytes)
+	hash := []byte(strings.ToUpper(hex.EncodeToString(sum[:])))
+
+	for _, m := range matches {
+		for group := 1; group <= 2; group++ {
+			start, end := m[2*group], m[2*group+1]
+			copy(pdfBytes[start:end], bytes.Repeat(hash, (end-start)/len(hash)+1))
+		}
+	}
+}
+
+// selectPages parses a page selection like "1-3,odd,!5" (pdfcpu syntax); all pages are selected if empty
+func (doc *document) selectPages(selection string) (types.IntSet, error) {
+	var pageSelection []string
+
+	if selection != "" {
+		var err error
+		pageSelection, err = api.ParsePageSelection(selection)
+		if err != nil {
+			return nil, fmt.Errorf("invalid page selection '%s': %w", selection, err)
+		}
+	}
+
+	return api.PagesForPageSelection(doc.PageCount, pageSelection, true, false)
+}
+
+func (doc *document) catalog() (types.Dict, error) {
+	return doc.XRefTable.Catalog()
+}
+
+// infoDict returns the document info dict; it is created if the document has none
+func (doc *document) infoDict() (types.Dict, error) {
+	if doc.Info == nil {
+		d := types.NewDict()
+
+		indRef, err := doc.IndRefForNewObject(d)
+		if err != nil {
+			return nil, err
+		}
+
+		doc.Info = indRef
+
+		return d, nil
+	}
+
+	return doc.DereferenceDict(*doc.Info)
+}
+
+// infoText returns the text of an entry of the document info dict
+func (doc *document) infoText(key string) string {
+	if doc.Info == nil {
+		return ""
+	}
+
+	d, err := doc.DereferenceDict(*doc.Info)
+	if err != nil || d == nil {
+		return ""
+	}
+
+	o, found := d.Find(key)
+	if !found {
+		return ""
+	}
+
+	s, err := doc.DereferenceText(o)
+	if err != nil {
+		return ""
+	}
+
+	return s
+}
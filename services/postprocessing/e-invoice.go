@@ -0,0 +1,237 @@
+package postprocessing
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
+	"github.com/lucas-gaitzsch/pdf-turtle/static-files/embed"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+const (
+	outputIntentProfileFile = "srgb.icc"
+	outputIntentCondition   = "sRGB IEC61966-2.1"
+)
+
+type eInvoiceProcessor struct{}
+
+func (p *eInvoiceProcessor) name() string {
+	return "e-invoice"
+}
+
+func (p *eInvoiceProcessor) isRequired(data *models.RenderData) bool {
+	return data.RenderOptions.EInvoice != nil
+}
+
+func (p *eInvoiceProcessor) process(ctx context.Context, doc *document, data *models.RenderData) error {
+	eInvoice := data.RenderOptions.EInvoice
+
+	hasInvoiceXml := slices.ContainsFunc(data.RenderOptions.Attachments, func(a models.Attachment) bool {
+		return a.Name == eInvoice.DocumentFileName
+	})
+
+	if !hasInvoiceXml {
+		return fmt.Errorf("e-invoice requires an attachment with name '%s'", eInvoice.DocumentFileName)
+	}
+
+	if err := addXmpMetadata(doc, eInvoice); err != nil {
+		return err
+	}
+
+	return addOutputIntent(doc)
+}
+
+type xmpMetadata struct {
+	Title       string
+	Author      string
+	Subject     string
+	Keywords    string
+	CreatorTool string
+	Producer    string
+	Date        string
+
+	EInvoice *models.RenderOptionsEInvoice
+}
+
+var xmpTemplate = template.Must(template.New("xmp").Funcs(template.FuncMap{"xml": xmlEscape}).Parse(`<?xpacket begin="` + "\ufeff" + `" id="W5M0MpCehiHzreSzNTczkc9d"?>
+<x:xmpmeta xmlns:x="adobe:ns:meta/">
+<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+<rdf:Description rdf:about="" xmlns:dc="http://purl.org/dc/elements/1.1/">
+<dc:format>application/pdf</dc:format>
+{{- if .Title}}
+<dc:title><rdf:Alt><rdf:li xml:lang="x-default">{{xml .Title}}</rdf:li></rdf:Alt></dc:title>
+{{- end}}
+{{- if .Author}}
+<dc:creator><rdf:Seq><rdf:li>{{xml .Author}}</rdf:li></rdf:Seq></dc:creator>
+{{- end}}
+{{- if .Subject}}
+<dc:description><rdf:Alt><rdf:li xml:lang="x-default">{{xml .Subject}}</rdf:li></rdf:Alt></dc:description>
+{{- end}}
+</rdf:Description>
+<rdf:Description rdf:about="" xmlns:pdf="http://ns.adobe.com/pdf/1.3/">
+<pdf:Producer>{{xml .Producer}}</pdf:Producer>
+{{- if .Keywords}}
+<pdf:Keywords>{{xml .Keywords}}</pdf:Keywords>
+{{- end}}
+</rdf:Description>
+<rdf:Description rdf:about="" xmlns:xmp="http://ns.adobe.com/xap/1.0/">
+<xmp:CreateDate>{{.Date}}</xmp:CreateDate>
+<xmp:ModifyDate>{{.Date}}</xmp:ModifyDate>
+<xmp:MetadataDate>{{.Date}}</xmp:MetadataDate>
+{{- if .CreatorTool}}
+<xmp:CreatorTool>{{xml .CreatorTool}}</xmp:CreatorTool>
+{{- end}}
+</rdf:Description>
+<rdf:Description rdf:about="" xmlns:pdfaExtension="http://www.aiim.org/pdfa/ns/extension/" xmlns:pdfaSchema="http://www.aiim.org/pdfa/ns/schema#" xmlns:pdfaProperty="http://www.aiim.org/pdfa/ns/property#">
+<pdfaExtension:schemas>
+<rdf:Bag>
+<rdf:li rdf:parseType="Resource">
+<pdfaSchema:schema>Factur-X PDFA Extension Schema</pdfaSchema:schema>
+<pdfaSchema:namespaceURI>urn:factur-x:pdfa:CrossIndustryDocument:invoice:1p0#</pdfaSchema:namespaceURI>
+<pdfaSchema:prefix>fx</pdfaSchema:prefix>
+<pdfaSchema:property>
+<rdf:Seq>
+<rdf:li rdf:parseType="Resource">
+<pdfaProperty:name>DocumentFileName</pdfaProperty:name>
+<pdfaProperty:valueType>Text</pdfaProperty:valueType>
+<pdfaProperty:category>external</pdfaProperty:category>
+<pdfaProperty:description>The name of the embedded XML document</pdfaProperty:description>
+</rdf:li>
+<rdf:li rdf:parseType="Resource">
+<pdfaProperty:name>DocumentType</pdfaProperty:name>
+<pdfaProperty:valueType>Text</pdfaProperty:valueType>
+<pdfaProperty:category>external</pdfaProperty:category>
+<pdfaProperty:description>The type of the hybrid document in capital letters, e.g. INVOICE or ORDER</pdfaProperty:description>
+</rdf:li>
+<rdf:li rdf:parseType="Resource">
+<pdfaProperty:name>Version</pdfaProperty:name>
+<pdfaProperty:valueType>Text</pdfaProperty:valueType>
+<pdfaProperty:category>external</pdfaProperty:category>
+<pdfaProperty:description>The actual version of the standard applying to the embedded XML document</pdfaProperty:description>
+</rdf:li>
+<rdf:li rdf:parseType="Resource">
+<pdfaProperty:name>ConformanceLevel</pdfaProperty:name>
+<pdfaProperty:valueType>Text</pdfaProperty:valueType>
+<pdfaProperty:category>external</pdfaProperty:category>
+<pdfaProperty:description>The conformance level of the embedded XML document</pdfaProperty:description>
+</rdf:li>
+</rdf:Seq>
+</pdfaSchema:property>
+</rdf:li>
+</rdf:Bag>
+</pdfaExtension:schemas>
+</rdf:Description>
+<rdf:Description rdf:about="" xmlns:fx="urn:factur-x:pdfa:CrossIndustryDocument:invoice:1p0#">
+<fx:DocumentType>{{xml .EInvoice.DocumentType}}</fx:DocumentType>
+<fx:DocumentFileName>{{xml .EInvoice.DocumentFileName}}</fx:DocumentFileName>
+<fx:Version>{{xml .EInvoice.Version}}</fx:Version>
+<fx:ConformanceLevel>{{xml .EInvoice.ConformanceLevel}}</fx:ConformanceLevel>
+</rdf:Description>
+</rdf:RDF>
+</x:xmpmeta>
+<?xpacket end="w"?>`))
+
+// addXmpMetadata adds the Factur-X extension schema.
+// PDF/A-3 conformance is not declared, because the fonts and the transparency of the chromium output are not checked.
+// The document info is mirrored, because PDF/A requires the info dict and the xmp metadata to be equivalent.
+func addXmpMetadata(doc *document, eInvoice *models.RenderOptionsEInvoice) error {
+	metadata := xmpMetadata{
+		Title:       doc.infoText("Title"),
+		Author:      doc.infoText("Author"),
+		Subject:     doc.infoText("Subject"),
+		Keywords:    doc.infoText("Keywords"),
+		CreatorTool: doc.infoText("Creator"),
+		// pdfcpu sets itself as producer while writing
+		Producer: "pdfcpu " + model.VersionStr,
+		Date:     doc.date.Format(time.RFC3339),
+		EInvoice: eInvoice,
+	}
+
+	sb := new(strings.Builder)
+	if err := xmpTemplate.Execute(sb, metadata); err != nil {
+		return err
+	}
+
+	xmp := []byte(sb.String())
+
+	// PDF/A forbids filters for the metadata stream
+	sd := &types.StreamDict{
+		Dict:    types.NewDict(),
+		Content: xmp,
+	}
+	sd.InsertName("Type", "Metadata")
+	sd.InsertName("Subtype", "XML")
+
+	if err := sd.Encode(); err != nil {
+		return err
+	}
+
+	indRef, err := doc.IndRefForNewObject(*sd)
+	if err != nil {
+		return err
+	}
+
+	catalog, err := doc.catalog()
+	if err != nil {
+		return err
+	}
+
+	catalog.Update("Metadata", *indRef)
+
+	return nil
+}
+
+func addOutputIntent(doc *document) error {
+	catalog, err := doc.catalog()
+	if err != nil {
+		return err
+	}
+
+	if _, found := catalog.Find("OutputIntents"); found {
+		return nil
+	}
+
+	profile, err := embed.BuiltinFS.ReadFile(outputIntentProfileFile)
+	if err != nil {
+		return err
+	}
+
+	sd, err := doc.NewStreamDictForBuf(profile)
+	if err != nil {
+		return err
+	}
+	sd.InsertInt("N", 3)
+
+	if err := sd.Encode(); err != nil {
+		return err
+	}
+
+	profileIndRef, err := doc.IndRefForNewObject(*sd)
+	if err != nil {
+		return err
+	}
+
+	outputIntent := types.NewDict()
+	outputIntent.InsertName("Type", "OutputIntent")
+	outputIntent.InsertName("S", "GTS_PDFA1")
+	outputIntent.InsertString("OutputConditionIdentifier", outputIntentCondition)
+	outputIntent.InsertString("Info", outputIntentCondition)
+	outputIntent.Insert("DestOutputProfile", *profileIndRef)
+
+	catalog.Insert("OutputIntents", types.Array{outputIntent})
+
+	return nil
+}
+
+func xmlEscape(s string) string {
+	sb := new(strings.Builder)
+	template.HTMLEscape(sb, []byte(s))
+	return sb.String()
+}
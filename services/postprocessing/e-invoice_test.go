@@ -0,0 +1,99 @@
+package postprocessing
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+func TestEInvoice(t *testing.T) {
+	opt := models.RenderOptions{
+		Attachments: []models.Attachment{{Name: "factur-x.xml", Content: []byte("<rsm:CrossIndustryInvoice/>")}},
+		EInvoice:    &models.RenderOptionsEInvoice{},
+	}
+	opt.SetDefaults()
+
+	res := processTestPdf(t, 1, &models.RenderData{RenderOptions: opt})
+
+	doc := readTestDocument(t, res)
+	catalog, _ := doc.catalog()
+
+	sd, _, err := doc.DereferenceStreamDict(catalog["Metadata"])
+	if err != nil || sd == nil {
+		t.Fatalf("catalog should contain xmp metadata: %v", err)
+	}
+
+	if sd.FilterPipeline != nil {
+		t.Fatal("xmp metadata should not be filtered")
+	}
+
+	sd.Decode()
+
+	xmp := string(sd.Content)
+
+	for _, expected := range []string{
+		"<fx:DocumentFileName>factur-x.xml</fx:DocumentFileName>",
+		"<fx:ConformanceLevel>EN 16931</fx:ConformanceLevel>",
+	} {
+		if !strings.Contains(xmp, expected) {
+			t.Fatalf("xmp metadata should contain %s", expected)
+		}
+	}
+
+	if strings.Contains(xmp, "pdfaid:") {
+		t.Fatal("xmp metadata should not declare PDF/A conformance")
+	}
+
+	if _, found := catalog.Find("OutputIntents"); !found {
+		t.Fatal("catalog should contain output intents")
+	}
+
+	af, _ := doc.DereferenceArray(catalog["AF"])
+	fileSpec, _ := doc.DereferenceDict(af[0])
+
+	if r := fileSpec.NameEntry("AFRelationship"); r == nil || *r != models.AttachmentRelationshipAlternative {
+		t.Fatalf("relationship of invoice xml with profile EN 16931 should be Alternative (curr: %v)", r)
+	}
+}
+
+func TestEInvoiceWithoutInvoiceXml(t *testing.T) {
+	opt := models.RenderOptions{
+		EInvoice: &models.RenderOptionsEInvoice{},
+	}
+	opt.SetDefaults()
+
+	_, err := NewPostProcessingService().Process(context.Background(), bytes.NewReader(newTestPdf(t, 1)), &models.RenderData{RenderOptions: opt})
+	if err == nil {
+		t.Fatal("e-invoice without invoice xml attachment should fail")
+	}
+}
+
+func TestEInvoiceInfoDatesMatchXmp(t *testing.T) {
+	opt := models.RenderOptions{
+		Attachments: []models.Attachment{{Name: "factur-x.xml", Content: []byte("<xml/>")}},
+		EInvoice:    &models.RenderOptionsEInvoice{},
+	}
+	opt.SetDefaults()
+
+	res := processTestPdf(t, 1, &models.RenderData{RenderOptions: opt})
+
+	doc := readTestDocument(t, res)
+	catalog, _ := doc.catalog()
+	sd, _, _ := doc.DereferenceStreamDict(catalog["Metadata"])
+	sd.Decode()
+
+	creationDate, ok := types.DateTime(doc.infoText("CreationDate"), true)
+	if !ok {
+		t.Fatal("info dict should contain creation date")
+	}
+
+	if !strings.Contains(string(sd.Content), "<xmp:CreateDate>"+creationDate.Format(time.RFC3339)+"</xmp:CreateDate>") {
+		t.Fatal("xmp create date should match the creation date of the info dict")
+	}
+}
@@ -0,0 +1,229 @@
+package postprocessing
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
+	"github.com/lucas-gaitzsch/pdf-turtle/utils"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+type impositionProcessor struct{}
+
+func (p *impositionProcessor) name() string {
+	return "imposition"
+}
+
+func (p *impositionProcessor) isRequired(data *models.RenderData) bool {
+	return data.RenderOptions.Imposition != nil
+}
+
+// process places the pages as form xobjects on new sheets and replaces the page tree with the sheets.
+// Links, named destinations and the outline point to the replaced pages, so they are removed.
+func (p *impositionProcessor) process(ctx context.Context, doc *document, data *models.RenderData) error {
+	imposition := data.RenderOptions.Imposition
+
+	if err := imposition.Validate(); err != nil {
+		return err
+	}
+
+	forms := make([]*pageForm, doc.PageCount+1)
+	for pageNr := 1; pageNr <= doc.PageCount; pageNr++ {
+		form, err := doc.newPageForm(pageNr)
+		if err != nil {
+			return err
+		}
+		forms[pageNr] = form
+	}
+
+	sheetSize := imposition.GetSheetSize()
+	sheetBox := types.NewRectangle(0, 0, utils.MmToPoints(sheetSize.Width), utils.MmToPoints(sheetSize.Height))
+
+	sheets := imposition.GetSheets(doc.PageCount)
+
+	pagesDict := types.Dict{
+		"Type":  types.Name("Pages"),
+		"Count": types.Integer(len(sheets)),
+	}
+
+	pagesIndRef, err := doc.IndRefForNewObject(pagesDict)
+	if err != nil {
+		return err
+	}
+
+	kids := make(types.Array, 0, len(sheets))
+
+	for _, sheet := range sheets {
+		xObjects := types.Dict{}
+		content := new(strings.Builder)
+		cutLines := new(strings.Builder)
+
+		for cell, pageNr := range sheet {
+			if pageNr == 0 {
+				continue
+			}
+
+			form := forms[pageNr]
+			placed := getPlacedPage(imposition, sheetBox, form.box, cell)
+
+			formName := fmt.Sprintf("P%d", pageNr)
+			xObjects.Insert(formName, *form.indRef)
+
+			scale := placed.Width() / form.box.Width()
+			fmt.Fprintf(content, "q %.4f 0 0 %.4f %.2f %.2f cm /%s Do Q\n",
+				scale, scale, placed.LL.X-scale*form.box.LL.X, placed.LL.Y-scale*form.box.LL.Y, formName)
+
+			if imposition.CutLines {
+				fmt.Fprintf(cutLines, "%.2f %.2f %.2f %.2f re S\n", placed.LL.X, placed.LL.Y, placed.Width(), placed.Height())
+			}
+		}
+
+		if cutLines.Len() > 0 {
+			fmt.Fprintf(content, "q\n%.2f w\n0 G\n%sQ\n", markLineWidth, cutLines.String())
+		}
+
+		contentIndRef, err := doc.newContentStream(content.String())
+		if err != nil {
+			return err
+		}
+
+		sheetIndRef, err := doc.IndRefForNewObject(types.Dict{
+			"Type":      types.Name("Page"),
+			"Parent":    *pagesIndRef,
+			"MediaBox":  sheetBox.Array(),
+			"Resources": types.Dict{"XObject": xObjects},
+			"Contents":  *contentIndRef,
+		})
+		if err != nil {
+			return err
+		}
+
+		kids = append(kids, *sheetIndRef)
+	}
+
+	pagesDict.Update("Kids", kids)
+
+	if err := doc.removePageReferences(); err != nil {
+		return err
+	}
+
+	catalog, err := doc.catalog()
+	if err != nil {
+		return err
+	}
+
+	catalog.Update("Pages", *pagesIndRef)
+	doc.PageCount = len(sheets)
+
+	return nil
+}
+
+// getPlacedPage returns the rectangle of the page scaled to fit into the cell of the sheet.
+// Pages of booklets are aligned to the fold, all others are centered in the cell.
+func getPlacedPage(imposition *models.RenderOptionsImposition, sheetBox, pageBox *types.Rectangle, cell int) *types.Rectangle {
+	columns, rows := imposition.GetGrid()
+	column, row := cell%columns, cell/columns
+
+	gutter := utils.MmToPoints(imposition.Gutter)
+
+	cellWidth := (sheetBox.Width() - float64(columns-1)*gutter) / float64(columns)
+	cellHeight := (sheetBox.Height() - float64(rows-1)*gutter) / float64(rows)
+
+	cellX := float64(column) * (cellWidth + gutter)
+	cellY := sheetBox.Height() - float64(row+1)*cellHeight - float64(row)*gutter
+
+	scale := math.Min(cellWidth/pageBox.Width(), cellHeight/pageBox.Height())
+	width, height := scale*pageBox.Width(), scale*pageBox.Height()
+
+	x := cellX + (cellWidth-width)/2
+	if imposition.Layout == models.ImpositionLayoutBooklet {
+		if column == 0 {
+			x = cellX + cellWidth - width
+		} else {
+			x = cellX
+		}
+	}
+
+	y := cellY + (cellHeight-height)/2
+
+	return types.NewRectangle(x, y, x+width, y+height)
+}
+
+type pageForm struct {
+	indRef *types.IndirectRef
+	box    *types.Rectangle
+}
+
+// newPageForm creates a form xobject with the content and the resources of the page
+func (doc *document) newPageForm(pageNr int) (*pageForm, error) {
+	pageDict, _, inherited, err := doc.PageDict(pageNr, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if inherited == nil || inherited.MediaBox == nil {
+		return nil, fmt.Errorf("page %d has no media box", pageNr)
+	}
+
+	content, err := doc.PageContent(pageDict, pageNr)
+	if err != nil {
+		return nil, fmt.Errorf("cant read content of page %d: %w", pageNr, err)
+	}
+
+	sd, err := doc.NewStreamDictForBuf(content)
+	if err != nil {
+		return nil, err
+	}
+
+	sd.Insert("Type", types.Name("XObject"))
+	sd.Insert("Subtype", types.Name("Form"))
+	sd.Insert("BBox", inherited.MediaBox.Array())
+
+	for _, key := range []string{"Resources", "Group"} {
+		if o, found := pageDict.Find(key); found {
+			sd.Insert(key, o)
+		}
+	}
+
+	if err := sd.Encode(); err != nil {
+		return nil, err
+	}
+
+	indRef, err := doc.IndRefForNewObject(*sd)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pageForm{indRef: indRef, box: inherited.MediaBox}, nil
+}
+
+// removePageReferences removes the outline and the named destinations
+func (doc *document) removePageReferences() error {
+	catalog, err := doc.catalog()
+	if err != nil {
+		return err
+	}
+
+	catalog.Delete("Outlines")
+	catalog.Delete("Dests")
+
+	if pageMode := catalog.NameEntry("PageMode"); pageMode != nil && *pageMode == "UseOutlines" {
+		catalog.Delete("PageMode")
+	}
+
+	if err := doc.LocateNameTree("Dests", false); err != nil {
+		return err
+	}
+
+	if doc.Names["Dests"] == nil {
+		return nil
+	}
+
+	delete(doc.Names, "Dests")
+
+	return doc.RemoveNameTree("Dests")
+}
@@ -0,0 +1,77 @@
+package postprocessing
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
+	"github.com/lucas-gaitzsch/pdf-turtle/utils"
+)
+
+func TestImpositionGrid(t *testing.T) {
+	data := &models.RenderData{}
+	data.RenderOptions.Imposition = &models.RenderOptionsImposition{Columns: 2, Rows: 2, SheetFormat: "A3", Gutter: 5, CutLines: true}
+	data.RenderOptions.Imposition.SetDefaults()
+
+	doc := readTestDocument(t, processTestPdf(t, 5, data))
+
+	if doc.PageCount != 2 {
+		t.Fatalf("5 pages should be placed on 2 sheets (curr: %d)", doc.PageCount)
+	}
+
+	pageDict, _, _, _ := doc.PageDict(1, false)
+	if mediaBox := pageBox(t, doc, pageDict, "MediaBox"); !almostEqual(mediaBox.Width(), utils.MmToPoints(297)) || !almostEqual(mediaBox.Height(), utils.MmToPoints(420)) {
+		t.Fatalf("sheet should have the size of A3 (curr: %v)", mediaBox)
+	}
+
+	content := pageContent(t, doc, 1)
+	for _, form := range []string{"/P1 Do", "/P2 Do", "/P3 Do", "/P4 Do"} {
+		if !bytes.Contains(content, []byte(form)) {
+			t.Fatalf("first sheet should contain %s (curr: %s)", form, content)
+		}
+	}
+
+	if bytes.Count(content, []byte(" re S")) != 4 {
+		t.Fatalf("first sheet should have cut lines around 4 pages (curr: %s)", content)
+	}
+
+	if content := pageContent(t, doc, 2); !bytes.Contains(content, []byte("/P5 Do")) || bytes.Contains(content, []byte("/P4 Do")) {
+		t.Fatalf("second sheet should only contain page 5 (curr: %s)", content)
+	}
+}
+
+func TestImpositionBooklet(t *testing.T) {
+	doc := newTestDocumentWithDestinations(t, map[string]int{"chapter": 3})
+
+	data := &models.RenderData{}
+	data.RenderOptions.Imposition = &models.RenderOptionsImposition{Layout: models.ImpositionLayoutBooklet, SheetLandscape: true}
+	data.RenderOptions.Imposition.SetDefaults()
+
+	if err := (&impositionProcessor{}).process(context.Background(), doc, data); err != nil {
+		t.Fatalf("err should be nil: %v", err)
+	}
+
+	pdf, err := doc.write()
+	if err != nil {
+		t.Fatalf("cant write pdf: %v", err)
+	}
+
+	doc = readTestDocument(t, pdf)
+
+	if doc.PageCount != 2 {
+		t.Fatalf("3 pages should be filled up to one booklet sheet with two sides (curr: %d)", doc.PageCount)
+	}
+
+	if content := pageContent(t, doc, 1); !bytes.Contains(content, []byte("/P1 Do")) || bytes.Count(content, []byte(" Do")) != 1 {
+		t.Fatalf("front should contain the blank last page and page 1 (curr: %s)", content)
+	}
+
+	if content := pageContent(t, doc, 2); !bytes.Contains(content, []byte("/P2 Do")) || !bytes.Contains(content, []byte("/P3 Do")) {
+		t.Fatalf("back should contain page 2 and 3 (curr: %s)", content)
+	}
+
+	if pages, _ := doc.namedDestinationPages(); len(pages) != 0 {
+		t.Fatalf("destinations of the replaced pages should be removed (curr: %v)", pages)
+	}
+}
@@ -0,0 +1,130 @@
+package postprocessing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
+	"github.com/lucas-gaitzsch/pdf-turtle/services/barcodes"
+	"github.com/lucas-gaitzsch/pdf-turtle/utils"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// thickness of the omr marks in points (about 0.5 mm)
+const omrMarkThickness = 1.5
+
+type mailingMarksProcessor struct{}
+
+func (p *mailingMarksProcessor) name() string {
+	return "mailing marks"
+}
+
+func (p *mailingMarksProcessor) isRequired(data *models.RenderData) bool {
+	return data.RenderOptions.MailingMarks != nil
+}
+
+// process draws the omr marks or the data matrix code on the first page of every sheet
+func (p *mailingMarksProcessor) process(ctx context.Context, doc *document, data *models.RenderData) error {
+	options := data.RenderOptions.MailingMarks
+
+	if err := options.Validate(); err != nil {
+		return err
+	}
+
+	marks, err := options.GetMarks(doc.PageCount)
+	if err != nil {
+		return err
+	}
+
+	for _, mark := range marks {
+		pageDict, _, inherited, err := doc.PageDict(mark.PageNr, false)
+		if err != nil {
+			return err
+		}
+
+		box, err := doc.trimBox(pageDict, inherited)
+		if err != nil {
+			return fmt.Errorf("page %d: %w", mark.PageNr, err)
+		}
+
+		left := box.LL.X + utils.MmToPoints(options.Left)
+		top := box.UR.Y - utils.MmToPoints(options.Top)
+
+		content := new(strings.Builder)
+		content.WriteString("0 g\n")
+
+		if options.Type == models.MailingMarksTypeDataMatrix {
+			err = writeDataMatrix(content, mark.DataMatrixContent(), left, top, utils.MmToPoints(options.Size))
+		} else {
+			writeOmrMarks(content, mark.OmrMarks(), left, top, utils.MmToPoints(options.Size), utils.MmToPoints(options.Spacing))
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if err := doc.appendPageContent(pageDict, content.String()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// trimBox returns the trim box of the page (the final page without bleed and slug) or the media box without a trim box
+func (doc *document) trimBox(pageDict types.Dict, inherited *model.InheritedPageAttrs) (*types.Rectangle, error) {
+	if obj, ok := pageDict.Find("TrimBox"); ok {
+		arr, err := doc.DereferenceArray(obj)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(arr) == 4 {
+			return types.RectForArray(arr), nil
+		}
+	}
+
+	if inherited == nil || inherited.MediaBox == nil {
+		return nil, errors.New("no media box")
+	}
+
+	return inherited.MediaBox, nil
+}
+
+func writeOmrMarks(sb *strings.Builder, marks []bool, left, top, length, spacing float64) {
+	for i, dark := range marks {
+		if dark {
+			y := top - float64(i)*spacing - omrMarkThickness/2
+			fmt.Fprintf(sb, "%.2f %.2f %.2f %.2f re\n", left, y, length, omrMarkThickness)
+		}
+	}
+
+	sb.WriteString("f\n")
+}
+
+// writeDataMatrix draws the dark modules of the code with the top left corner at the position
+func writeDataMatrix(sb *strings.Builder, content string, left, top, size float64) error {
+	code, err := barcodes.NewDataMatrix(content)
+	if err != nil {
+		return err
+	}
+
+	modules := code.Modules()
+	moduleSize := size / float64(max(len(modules), len(modules[0])))
+
+	for row, rowModules := range modules {
+		for column, dark := range rowModules {
+			if dark {
+				fmt.Fprintf(sb, "%.3f %.3f %.3f %.3f re\n", left+float64(column)*moduleSize, top-float64(row+1)*moduleSize, moduleSize, moduleSize)
+			}
+		}
+	}
+
+	sb.WriteString("f\n")
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package postprocessing
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
+	"github.com/lucas-gaitzsch/pdf-turtle/utils"
+)
+
+func TestOmrMailingMarks(t *testing.T) {
+	data := &models.RenderData{}
+	data.RenderOptions.MailingMarks = &models.RenderOptionsMailingMarks{Duplex: true}
+	data.RenderOptions.MailingMarks.SetDefaults()
+
+	doc := readTestDocument(t, processTestPdf(t, 3, data))
+
+	for pageNr, hasMarks := range map[int]bool{1: true, 2: false, 3: true} {
+		if content := pageContent(t, doc, pageNr); bytes.Contains(content, []byte(" re\n")) != hasMarks {
+			t.Fatalf("only the front pages should have marks (page %d: %s)", pageNr, content)
+		}
+	}
+}
+
+func TestDataMatrixMailingMarks(t *testing.T) {
+	data := &models.RenderData{}
+	data.RenderOptions.MailingMarks = &models.RenderOptionsMailingMarks{Type: models.MailingMarksTypeDataMatrix, DocumentIndex: 7}
+	data.RenderOptions.MailingMarks.SetDefaults()
+
+	doc := readTestDocument(t, processTestPdf(t, 2, data))
+
+	for pageNr := 1; pageNr <= doc.PageCount; pageNr++ {
+		content := pageContent(t, doc, pageNr)
+
+		// a data matrix code has more dark modules than the omr marks
+		if bytes.Count(content, []byte(" re\n")) < 20 || !bytes.Contains(content, []byte("Page")) {
+			t.Fatalf("page %d should have a data matrix code and keep its content (curr: %s)", pageNr, content)
+		}
+	}
+}
+
+func TestMailingMarksWithBleed(t *testing.T) {
+	data := &models.RenderData{}
+	data.RenderOptions.MailingMarks = &models.RenderOptionsMailingMarks{}
+	data.RenderOptions.MailingMarks.SetDefaults()
+	data.RenderOptions.Print = &models.RenderOptionsPrint{CropMarks: true}
+	data.RenderOptions.Print.SetDefaults()
+
+	doc := readTestDocument(t, processTestPdf(t, 1, data))
+
+	pageDict, _, _, err := doc.PageDict(1, false)
+	if err != nil {
+		t.Fatalf("cant read page: %v", err)
+	}
+
+	trimBox := pageBox(t, doc, pageDict, "TrimBox")
+
+	// the start mark is the first omr mark from the top
+	left := trimBox.LL.X + utils.MmToPoints(data.RenderOptions.MailingMarks.Left)
+	y := trimBox.UR.Y - utils.MmToPoints(data.RenderOptions.MailingMarks.Top) - omrMarkThickness/2
+	startMark := fmt.Sprintf("0 g\n%.2f %.2f ", left, y)
+
+	if content := pageContent(t, doc, 1); !bytes.Contains(content, []byte(startMark)) {
+		t.Fatalf("the marks should be placed relative to the trim box (expected %q in %s)", startMark, content)
+	}
+}
@@ -0,0 +1,51 @@
+package postprocessing
+
+import (
+	"context"
+	"strings"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+type metadataProcessor struct{}
+
+func (p *metadataProcessor) name() string {
+	return "metadata"
+}
+
+func (p *metadataProcessor) isRequired(data *models.RenderData) bool {
+	return data.RenderOptions.Metadata != nil && !data.RenderOptions.Metadata.IsEmpty()
+}
+
+// process sets the entries of the document info dict. Empty entries keep the values set by chromium.
+func (p *metadataProcessor) process(ctx context.Context, doc *document, data *models.RenderData) error {
+	m := data.RenderOptions.Metadata
+
+	info, err := doc.infoDict()
+	if err != nil {
+		return err
+	}
+
+	for key, value := range map[string]string{
+		"Title":    m.Title,
+		"Author":   m.Author,
+		"Subject":  m.Subject,
+		"Keywords": strings.Join(m.Keywords, ", "),
+		"Creator":  m.Creator,
+	} {
+		if value == "" {
+			continue
+		}
+
+		s, err := types.EscapedUTF16String(value)
+		if err != nil {
+			return err
+		}
+
+		info.Update(key, types.StringLiteral(*s))
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package postprocessing
+
+import (
+	"testing"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
+)
+
+func TestMetadata(t *testing.T) {
+	data := &models.RenderData{}
+	data.RenderOptions.Metadata = &models.RenderOptionsMetadata{
+		Title:    "Release Notes",
+		Author:   "Jürgen",
+		Keywords: []string{"release", "changelog"},
+	}
+
+	doc := readTestDocument(t, processTestPdf(t, 1, data))
+
+	for key, expected := range map[string]string{
+		"Title":    "Release Notes",
+		"Author":   "Jürgen",
+		"Keywords": "release, changelog",
+	} {
+		if value := doc.infoText(key); value != expected {
+			t.Fatalf("%s should be '%s' (curr: '%s')", key, expected, value)
+		}
+	}
+}
+
+func TestMetadataEmptyIsNotRequired(t *testing.T) {
+	data := &models.RenderData{}
+	data.RenderOptions.Metadata = &models.RenderOptionsMetadata{}
+
+	if (&metadataProcessor{}).isRequired(data) {
+		t.Fatal("empty metadata should not be required")
+	}
+}
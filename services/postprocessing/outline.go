@@ -0,0 +1,169 @@
+package postprocessing
+
+import (
+	"context"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+type outlineProcessor struct{}
+
+func (p *outlineProcessor) name() string {
+	return "outline"
+}
+
+func (p *outlineProcessor) isRequired(data *models.RenderData) bool {
+	outline := data.RenderOptions.Outline
+	return outline != nil && len(outline.Entries) > 0
+}
+
+func (p *outlineProcessor) process(ctx context.Context, doc *document, data *models.RenderData) error {
+	if data.RenderOptions.Outline.GeneratedByChromium {
+		hasOutline, err := hasOutline(doc)
+		// otherwise the chromium version does not support the document outline
+		if err != nil || hasOutline {
+			return err
+		}
+	}
+
+	dests, err := doc.namedDestinations()
+	if err != nil {
+		return err
+	}
+
+	items := buildOutlineTree(data.RenderOptions.Outline.Entries, dests)
+	if len(items) == 0 {
+		return nil
+	}
+
+	return addOutline(doc, items)
+}
+
+func hasOutline(doc *document) (bool, error) {
+	catalog, err := doc.catalog()
+	if err != nil {
+		return false, err
+	}
+
+	_, found := catalog.Find("Outlines")
+	return found, nil
+}
+
+type outlineItem struct {
+	title string
+	dest  types.Array
+	kids  []*outlineItem
+}
+
+// buildOutlineTree nests the entries by level. Entries without destination (e.g. hidden elements) are skipped.
+func buildOutlineTree(entries []models.OutlineEntry, dests map[string]types.Array) []*outlineItem {
+	root := &outlineItem{}
+
+	type levelItem struct {
+		level int
+		item  *outlineItem
+	}
+	stack := []levelItem{{0, root}}
+
+	for _, e := range entries {
+		dest, ok := dests[e.Id]
+		if !ok {
+			continue
+		}
+
+		for len(stack) > 1 && stack[len(stack)-1].level >= e.Level {
+			stack = stack[:len(stack)-1]
+		}
+
+		item := &outlineItem{title: e.Title, dest: dest}
+
+		parent := stack[len(stack)-1].item
+		parent.kids = append(parent.kids, item)
+
+		stack = append(stack, levelItem{e.Level, item})
+	}
+
+	return root.kids
+}
+
+// addOutline replaces the outline of the document and shows it when the document is opened
+func addOutline(doc *document, items []*outlineItem) error {
+	outlines := types.Dict{"Type": types.Name("Outlines")}
+
+	outlinesIndRef, err := doc.IndRefForNewObject(outlines)
+	if err != nil {
+		return err
+	}
+
+	first, last, count, err := addOutlineItems(doc, items, *outlinesIndRef)
+	if err != nil {
+		return err
+	}
+
+	outlines["First"] = *first
+	outlines["Last"] = *last
+	outlines["Count"] = types.Integer(count)
+
+	catalog, err := doc.catalog()
+	if err != nil {
+		return err
+	}
+
+	catalog.Update("Outlines", *outlinesIndRef)
+	catalog.Update("PageMode", types.Name("UseOutlines"))
+
+	return nil
+}
+
+// addOutlineItems creates the linked list of outline item dicts and returns the first and last item and the count of all items
+func addOutlineItems(doc *document, items []*outlineItem, parent types.IndirectRef) (first, last *types.IndirectRef, count int, err error) {
+	var prev types.Dict
+
+	for _, item := range items {
+		title, err := types.EscapedUTF16String(item.title)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+
+		d := types.Dict{
+			"Title":  types.StringLiteral(*title),
+			"Parent": parent,
+			"Dest":   item.dest,
+		}
+
+		indRef, err := doc.IndRefForNewObject(d)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+
+		if first == nil {
+			first = indRef
+		} else {
+			prev["Next"] = *indRef
+			d["Prev"] = *last
+		}
+
+		count++
+
+		if len(item.kids) > 0 {
+			kidsFirst, kidsLast, kidsCount, err := addOutlineItems(doc, item.kids, *indRef)
+			if err != nil {
+				return nil, nil, 0, err
+			}
+
+			d["First"] = *kidsFirst
+			d["Last"] = *kidsLast
+			// all levels are expanded
+			d["Count"] = types.Integer(kidsCount)
+
+			count += kidsCount
+		}
+
+		prev = d
+		last = indRef
+	}
+
+	return first, last, count, nil
+}
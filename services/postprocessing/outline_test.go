@@ -0,0 +1,153 @@
+package postprocessing
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+func TestOutline(t *testing.T) {
+	doc := newTestDocumentWithDestinations(t, map[string]int{"intro": 1, "details": 2, "more": 2, "end": 3})
+
+	data := &models.RenderData{}
+	data.RenderOptions.Outline = &models.RenderOptionsOutline{
+		Entries: []models.OutlineEntry{
+			{Title: "Intro", Level: 1, Id: "intro"},
+			{Title: "Details äöü", Level: 2, Id: "details"},
+			{Title: "More", Level: 3, Id: "more"},
+			{Title: "Hidden", Level: 2, Id: "not-rendered"},
+			{Title: "End", Level: 1, Id: "end"},
+		},
+	}
+
+	if err := (&outlineProcessor{}).process(context.Background(), doc, data); err != nil {
+		t.Fatalf("err should be nil: %v", err)
+	}
+
+	pdfBytes, err := doc.write()
+	if err != nil {
+		t.Fatalf("cant write pdf: %v", err)
+	}
+
+	bookmarks, err := api.Bookmarks(bytes.NewReader(pdfBytes), newConfiguration())
+	if err != nil {
+		t.Fatalf("cant read bookmarks: %v", err)
+	}
+
+	if len(bookmarks) != 2 || bookmarks[0].Title != "Intro" || bookmarks[1].Title != "End" || bookmarks[1].PageFrom != 3 {
+		t.Fatalf("top level bookmarks are not as expected (curr: %v)", bookmarks)
+	}
+
+	details := bookmarks[0].Kids
+	if len(details) != 1 || details[0].Title != "Details äöü" || details[0].PageFrom != 2 {
+		t.Fatalf("second level bookmarks are not as expected (curr: %v)", details)
+	}
+
+	if len(details[0].Kids) != 1 || details[0].Kids[0].Title != "More" {
+		t.Fatalf("third level bookmarks are not as expected (curr: %v)", details[0].Kids)
+	}
+}
+
+func TestOutlineKeepsChromiumOutline(t *testing.T) {
+	doc := newTestDocumentWithDestinations(t, map[string]int{"intro": 1, "details": 2})
+
+	chromiumData := &models.RenderData{}
+	chromiumData.RenderOptions.Outline = &models.RenderOptionsOutline{
+		Entries: []models.OutlineEntry{{Title: "Chromium", Level: 1, Id: "intro"}},
+	}
+	if err := (&outlineProcessor{}).process(context.Background(), doc, chromiumData); err != nil {
+		t.Fatalf("err should be nil: %v", err)
+	}
+
+	data := &models.RenderData{}
+	data.RenderOptions.Outline = &models.RenderOptionsOutline{
+		GeneratedByChromium: true,
+		Entries:             []models.OutlineEntry{{Title: "Intro", Level: 1, Id: "intro"}, {Title: "Details", Level: 1, Id: "details"}},
+	}
+	if err := (&outlineProcessor{}).process(context.Background(), doc, data); err != nil {
+		t.Fatalf("err should be nil: %v", err)
+	}
+
+	bookmarks := readTestBookmarks(t, doc)
+	if len(bookmarks) != 1 || bookmarks[0].Title != "Chromium" {
+		t.Fatalf("outline generated by chromium should be kept (curr: %v)", bookmarks)
+	}
+}
+
+func TestOutlineFallbackWithoutChromiumOutline(t *testing.T) {
+	doc := newTestDocumentWithDestinations(t, map[string]int{"intro": 1})
+
+	data := &models.RenderData{}
+	data.RenderOptions.Outline = &models.RenderOptionsOutline{
+		GeneratedByChromium: true,
+		Entries:             []models.OutlineEntry{{Title: "Intro", Level: 1, Id: "intro"}},
+	}
+	if err := (&outlineProcessor{}).process(context.Background(), doc, data); err != nil {
+		t.Fatalf("err should be nil: %v", err)
+	}
+
+	bookmarks := readTestBookmarks(t, doc)
+	if len(bookmarks) != 1 || bookmarks[0].Title != "Intro" {
+		t.Fatalf("outline should be added if chromium generated none (curr: %v)", bookmarks)
+	}
+}
+
+func readTestBookmarks(t *testing.T, doc *document) []pdfcpu.Bookmark {
+	pdfBytes, err := doc.write()
+	if err != nil {
+		t.Fatalf("cant write pdf: %v", err)
+	}
+
+	bookmarks, err := api.Bookmarks(bytes.NewReader(pdfBytes), newConfiguration())
+	if err != nil {
+		t.Fatalf("cant read bookmarks: %v", err)
+	}
+
+	return bookmarks
+}
+
+func TestBuildOutlineTreeWithSkippedLevels(t *testing.T) {
+	dests := map[string]types.Array{"a": {}, "b": {}, "c": {}}
+
+	items := buildOutlineTree([]models.OutlineEntry{
+		{Title: "A", Level: 2, Id: "a"},
+		{Title: "B", Level: 4, Id: "b"},
+		{Title: "C", Level: 1, Id: "c"},
+	}, dests)
+
+	if len(items) != 2 || len(items[0].kids) != 1 || items[0].kids[0].title != "B" || items[1].title != "C" {
+		t.Fatal("outline tree should nest by relative level")
+	}
+}
+
+// newTestDocumentWithDestinations creates a test document with named destinations to the given pages
+func newTestDocumentWithDestinations(t *testing.T, pagesByName map[string]int) *document {
+	pageCount := 0
+	for _, pageNr := range pagesByName {
+		pageCount = max(pageCount, pageNr)
+	}
+
+	doc := readTestDocument(t, newTestPdf(t, pageCount))
+
+	dests := types.NewDict()
+
+	for name, pageNr := range pagesByName {
+		_, pageIndRef, _, err := doc.PageDict(pageNr, false)
+		if err != nil {
+			t.Fatalf("cant read page %d: %v", pageNr, err)
+		}
+
+		dests.Insert(name, types.Array{*pageIndRef, types.Name("XYZ"), types.Integer(0), types.Integer(700), types.Integer(0)})
+	}
+
+	catalog, _ := doc.catalog()
+	catalog.Insert("Dests", dests)
+
+	return doc
+}
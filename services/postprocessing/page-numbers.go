@@ -0,0 +1,50 @@
+package postprocessing
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+type pageNumbersProcessor struct{}
+
+func (p *pageNumbersProcessor) name() string {
+	return "page numbers"
+}
+
+func (p *pageNumbersProcessor) isRequired(data *models.RenderData) bool {
+	pn := data.RenderOptions.PageNumbers
+	return pn != nil && len(pn.HeaderFooterPdf) > 0
+}
+
+// process stamps every page of the rendered header and footer on the same page of the document
+func (p *pageNumbersProcessor) process(ctx context.Context, doc *document, data *models.RenderData) error {
+	headerFooterPdf := data.RenderOptions.PageNumbers.HeaderFooterPdf
+
+	pageCount, err := GetPageCount(headerFooterPdf)
+	if err != nil {
+		return err
+	}
+
+	if pageCount != doc.PageCount {
+		return fmt.Errorf("page count of header and footer (%d) does not match (%d)", pageCount, doc.PageCount)
+	}
+
+	wm, err := api.PDFMultiWatermarkForReadSeeker(bytes.NewReader(headerFooterPdf), 1, 1, pageStampDescription, true, false, types.POINTS)
+	if err != nil {
+		return err
+	}
+
+	pages := types.IntSet{}
+	for pageNr := 1; pageNr <= doc.PageCount; pageNr++ {
+		pages[pageNr] = true
+	}
+
+	return pdfcpu.AddWatermarks(doc.Context, pages, wm)
+}
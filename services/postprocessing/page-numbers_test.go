@@ -0,0 +1,39 @@
+package postprocessing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
+)
+
+func TestPageNumbers(t *testing.T) {
+	data := &models.RenderData{}
+	data.RenderOptions.PageNumbers = &models.RenderOptionsPageNumbers{HeaderFooterPdf: newTestPdfWithText(t, 3, "Footer")}
+
+	doc := readTestDocument(t, processTestPdf(t, 3, data))
+
+	for pageNr := 1; pageNr <= doc.PageCount; pageNr++ {
+		if !isPageStamped(t, doc, pageNr) {
+			t.Fatalf("page %d should have the header and footer", pageNr)
+		}
+	}
+}
+
+func TestPageNumbersWithDifferentPageCount(t *testing.T) {
+	data := &models.RenderData{}
+	data.RenderOptions.PageNumbers = &models.RenderOptionsPageNumbers{HeaderFooterPdf: newTestPdf(t, 2)}
+
+	if err := (&pageNumbersProcessor{}).process(context.Background(), readTestDocument(t, newTestPdf(t, 3)), data); err == nil {
+		t.Fatal("header and footer with different page count should fail")
+	}
+}
+
+func TestPageNumbersIsNotRequiredWithoutRenderedPdf(t *testing.T) {
+	data := &models.RenderData{}
+	data.RenderOptions.PageNumbers = &models.RenderOptionsPageNumbers{}
+
+	if (&pageNumbersProcessor{}).isRequired(data) {
+		t.Fatal("page numbers should not be required without rendered header and footer")
+	}
+}
@@ -0,0 +1,97 @@
+package postprocessing
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
+	"github.com/lucas-gaitzsch/pdf-turtle/utils/logging"
+)
+
+type PostProcessingServiceAbstraction interface {
+	Process(ctx context.Context, pdfData io.Reader, data *models.RenderData) (io.Reader, error)
+}
+
+type postProcessor interface {
+	name() string
+	isRequired(data *models.RenderData) bool
+	process(ctx context.Context, doc *document, data *models.RenderData) error
+}
+
+func NewPostProcessingService() PostProcessingServiceAbstraction {
+	return &PostProcessingService{
+		// order matters: every processor works on the result of the previous one
+		processors: []postProcessor{
+			// the date of the document is used by the following processors
+			&deterministicProcessor{},
+			// the document info is read by the e-invoice xmp metadata
+			&metadataProcessor{},
+			&outlineProcessor{},
+			&pageNumbersProcessor{},
+			&watermarkProcessor{},
+			// the stationery is placed underneath everything else
+			&stationeryProcessor{},
+			// the marks are drawn on top of everything
+			&printMarksProcessor{},
+			// the mailing marks are placed relative to the trim box of the print options
+			&mailingMarksProcessor{},
+			// the pages are placed on the sheets with all stamps and marks
+			&impositionProcessor{},
+			&attachmentsProcessor{},
+			&eInvoiceProcessor{},
+		},
+	}
+}
+
+type PostProcessingService struct {
+	processors []postProcessor
+}
+
+func (pps *PostProcessingService) Process(ctx context.Context, pdfData io.Reader, data *models.RenderData) (io.Reader, error) {
+	required := pps.getRequiredProcessors(data)
+
+	if len(required) == 0 {
+		return pdfData, nil
+	}
+
+	pdfBytes, err := io.ReadAll(pdfData)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := readDocument(pdfBytes)
+	if err != nil {
+		return nil, fmt.Errorf("cant read rendered pdf: %w", err)
+	}
+
+	for _, p := range required {
+		err := logging.LogExecutionTimeWithResult("post-process pdf: "+p.name(), ctx, func() error {
+			return p.process(ctx, doc, data)
+		})
+
+		if err != nil {
+			return nil, fmt.Errorf("post-process pdf (%s): %w", p.name(), err)
+		}
+	}
+
+	processed, err := doc.write()
+	if err != nil {
+		return nil, fmt.Errorf("cant write post-processed pdf: %w", err)
+	}
+
+	return bytes.NewReader(processed), nil
+}
+
+func (pps *PostProcessingService) getRequiredProcessors(data *models.RenderData) []postProcessor {
+	required := make([]postProcessor, 0, len(pps.processors))
+
+	for _, p := range pps.processors {
+		if p.isRequired(data) {
+			required = append(required, p)
+		}
+	}
+
+	return required
+}
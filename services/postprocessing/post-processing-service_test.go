@@ -0,0 +1,84 @@
+package postprocessing
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+)
+
+func TestProcessWithoutRequiredProcessorReturnsInput(t *testing.T) {
+	pdfData := bytes.NewReader(newTestPdf(t, 1))
+
+	res, err := NewPostProcessingService().Process(context.Background(), pdfData, &models.RenderData{})
+	if err != nil {
+		t.Fatalf("err should be nil: %v", err)
+	}
+
+	if res != pdfData {
+		t.Fatal("pdf should not be touched without required post processor")
+	}
+}
+
+func TestProcessInvalidPdf(t *testing.T) {
+	data := &models.RenderData{
+		RenderOptions: models.RenderOptions{
+			Attachments: []models.Attachment{{Name: "test.txt", Content: []byte("test")}},
+		},
+	}
+
+	_, err := NewPostProcessingService().Process(context.Background(), strings.NewReader("no pdf"), data)
+	if err == nil {
+		t.Fatal("should fail for invalid pdf")
+	}
+}
+
+func newTestPdf(t *testing.T, pageCount int) []byte {
+	return newTestPdfWithText(t, pageCount, "Page")
+}
+
+// newTestPdfWithText creates a pdf with the text and the page number on every page
+func newTestPdfWithText(t *testing.T, pageCount int, text string) []byte {
+	pages := make([]string, pageCount)
+	for i := range pages {
+		pages[i] = fmt.Sprintf(`"%d": {"content": {"text": [{"value": "%s %d", "pos": [100, 700], "font": {"name": "Helvetica", "size": 12}}]}}`, i+1, text, i+1)
+	}
+
+	desc := fmt.Sprintf(`{"paper": "A4", "pages": {%s}}`, strings.Join(pages, ","))
+
+	buf := new(bytes.Buffer)
+	if err := api.Create(nil, strings.NewReader(desc), buf, newConfiguration()); err != nil {
+		t.Fatalf("cant create test pdf: %v", err)
+	}
+
+	return buf.Bytes()
+}
+
+func processTestPdf(t *testing.T, pageCount int, data *models.RenderData) []byte {
+	res, err := NewPostProcessingService().Process(context.Background(), bytes.NewReader(newTestPdf(t, pageCount)), data)
+	if err != nil {
+		t.Fatalf("post processing failed: %v", err)
+	}
+
+	b, err := io.ReadAll(res)
+	if err != nil {
+		t.Fatalf("cant read result: %v", err)
+	}
+
+	return b
+}
+
+func readTestDocument(t *testing.T, pdfBytes []byte) *document {
+	doc, err := readDocument(pdfBytes)
+	if err != nil {
+		t.Fatalf("cant read result pdf: %v", err)
+	}
+
+	return doc
+}
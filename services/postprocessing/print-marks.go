@@ -0,0 +1,203 @@
+package postprocessing
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
+	"github.com/lucas-gaitzsch/pdf-turtle/utils"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+const (
+	// length of the crop marks in mm
+	cropMarkLength = 5
+	// radius of the registration marks in mm
+	registrationMarkRadius = 2.5
+	// line width of the marks in points
+	markLineWidth = 0.25
+	// control point distance of a bezier curve approximating a quarter circle
+	bezierCircleFactor = 0.5523
+)
+
+type printMarksProcessor struct{}
+
+func (p *printMarksProcessor) name() string {
+	return "print marks"
+}
+
+func (p *printMarksProcessor) isRequired(data *models.RenderData) bool {
+	return data.RenderOptions.Print != nil
+}
+
+// process sets the trim and bleed box of every page and draws the marks in the slug area around the bleed.
+// The pages are already rendered with the bleed.
+func (p *printMarksProcessor) process(ctx context.Context, doc *document, data *models.RenderData) error {
+	printOptions := data.RenderOptions.Print
+
+	bleed := utils.MmToPoints(printOptions.Bleed)
+
+	slug := 0.0
+	if printOptions.HasMarks() {
+		slug = utils.MmToPoints(printOptions.Slug)
+	}
+
+	for pageNr := 1; pageNr <= doc.PageCount; pageNr++ {
+		pageDict, _, inherited, err := doc.PageDict(pageNr, false)
+		if err != nil {
+			return err
+		}
+
+		if inherited == nil || inherited.MediaBox == nil {
+			return fmt.Errorf("page %d has no media box", pageNr)
+		}
+
+		bleedBox := inherited.MediaBox
+		trimBox := bleedBox.CroppedCopy(bleed)
+		mediaBox := bleedBox.CroppedCopy(-slug)
+
+		pageDict.Update("MediaBox", mediaBox.Array())
+		pageDict.Update("BleedBox", bleedBox.Array())
+		pageDict.Update("TrimBox", trimBox.Array())
+		pageDict.Delete("CropBox")
+
+		if !printOptions.HasMarks() {
+			continue
+		}
+
+		marks := new(strings.Builder)
+
+		if printOptions.CropMarks {
+			writeCropMarks(marks, trimBox, bleedBox, math.Min(utils.MmToPoints(cropMarkLength), slug))
+		}
+
+		if printOptions.RegistrationMarks {
+			writeRegistrationMarks(marks, bleedBox, slug)
+		}
+
+		if err := doc.appendPageContent(pageDict, marks.String()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeCropMarks draws the lines of the trim box edges from the bleed box outwards
+func writeCropMarks(sb *strings.Builder, trimBox, bleedBox *types.Rectangle, length float64) {
+	corners := []struct {
+		trimX, trimY, bleedX, bleedY, dirX, dirY float64
+	}{
+		{trimBox.LL.X, trimBox.LL.Y, bleedBox.LL.X, bleedBox.LL.Y, -1, -1},
+		{trimBox.UR.X, trimBox.LL.Y, bleedBox.UR.X, bleedBox.LL.Y, 1, -1},
+		{trimBox.LL.X, trimBox.UR.Y, bleedBox.LL.X, bleedBox.UR.Y, -1, 1},
+		{trimBox.UR.X, trimBox.UR.Y, bleedBox.UR.X, bleedBox.UR.Y, 1, 1},
+	}
+
+	for _, c := range corners {
+		writeLine(sb, c.trimX, c.bleedY, c.trimX, c.bleedY+c.dirY*length)
+		writeLine(sb, c.bleedX, c.trimY, c.bleedX+c.dirX*length, c.trimY)
+	}
+}
+
+// writeRegistrationMarks draws a circle with a cross in the middle of the slug area of every side
+func writeRegistrationMarks(sb *strings.Builder, bleedBox *types.Rectangle, slug float64) {
+	r := math.Min(utils.MmToPoints(1)*registrationMarkRadius, slug/4)
+	midX := (bleedBox.LL.X + bleedBox.UR.X) / 2
+	midY := (bleedBox.LL.Y + bleedBox.UR.Y) / 2
+
+	centers := [][2]float64{
+		{midX, bleedBox.LL.Y - slug/2},
+		{midX, bleedBox.UR.Y + slug/2},
+		{bleedBox.LL.X - slug/2, midY},
+		{bleedBox.UR.X + slug/2, midY},
+	}
+
+	for _, c := range centers {
+		x, y := c[0], c[1]
+		k := r * bezierCircleFactor
+
+		fmt.Fprintf(sb, "%.2f %.2f m\n", x+r, y)
+		fmt.Fprintf(sb, "%.2f %.2f %.2f %.2f %.2f %.2f c\n", x+r, y+k, x+k, y+r, x, y+r)
+		fmt.Fprintf(sb, "%.2f %.2f %.2f %.2f %.2f %.2f c\n", x-k, y+r, x-r, y+k, x-r, y)
+		fmt.Fprintf(sb, "%.2f %.2f %.2f %.2f %.2f %.2f c\n", x-r, y-k, x-k, y-r, x, y-r)
+		fmt.Fprintf(sb, "%.2f %.2f %.2f %.2f %.2f %.2f c\n", x+k, y-r, x+r, y-k, x+r, y)
+		sb.WriteString("S\n")
+
+		writeLine(sb, x-1.5*r, y, x+1.5*r, y)
+		writeLine(sb, x, y-1.5*r, x, y+1.5*r)
+	}
+}
+
+func writeLine(sb *strings.Builder, x1, y1, x2, y2 float64) {
+	fmt.Fprintf(sb, "%.2f %.2f m %.2f %.2f l S\n", x1, y1, x2, y2)
+}
+
+// appendPageContent draws the marks (in registration color) on top of the page content.
+// The existing content is wrapped in a saved graphics state, so its transformations do not affect the marks.
+func (doc *document) appendPageContent(pageDict types.Dict, content string) error {
+	contents, err := doc.pageContentRefs(pageDict)
+	if err != nil {
+		return err
+	}
+
+	before, err := doc.newContentStream("q\n")
+	if err != nil {
+		return err
+	}
+
+	after, err := doc.newContentStream(fmt.Sprintf("Q\nq\n%.2f w\n1 1 1 1 K\n%sQ\n", markLineWidth, content))
+	if err != nil {
+		return err
+	}
+
+	arr := types.Array{*before}
+	arr = append(arr, contents...)
+	arr = append(arr, *after)
+
+	pageDict.Update("Contents", arr)
+
+	return nil
+}
+
+func (doc *document) pageContentRefs(pageDict types.Dict) (types.Array, error) {
+	o, found := pageDict.Find("Contents")
+	if !found {
+		return types.Array{}, nil
+	}
+
+	if indRef, ok := o.(types.IndirectRef); ok {
+		deref, err := doc.Dereference(indRef)
+		if err != nil {
+			return nil, err
+		}
+
+		if arr, ok := deref.(types.Array); ok {
+			return arr, nil
+		}
+
+		return types.Array{indRef}, nil
+	}
+
+	if arr, ok := o.(types.Array); ok {
+		return arr, nil
+	}
+
+	return nil, fmt.Errorf("unexpected page contents %T", o)
+}
+
+func (doc *document) newContentStream(content string) (*types.IndirectRef, error) {
+	sd, err := doc.NewStreamDictForBuf([]byte(content))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := sd.Encode(); err != nil {
+		return nil, err
+	}
+
+	return doc.IndRefForNewObject(*sd)
+}
@@ -0,0 +1,77 @@
+package postprocessing
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
+	"github.com/lucas-gaitzsch/pdf-turtle/utils"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+func TestPrintMarks(t *testing.T) {
+	data := &models.RenderData{}
+	data.RenderOptions.Print = &models.RenderOptionsPrint{CropMarks: true, RegistrationMarks: true}
+	data.RenderOptions.Print.SetDefaults()
+
+	doc := readTestDocument(t, processTestPdf(t, 2, data))
+
+	bleed := utils.MmToPoints(3)
+	slug := utils.MmToPoints(10)
+
+	for pageNr := 1; pageNr <= doc.PageCount; pageNr++ {
+		pageDict, _, _, err := doc.PageDict(pageNr, false)
+		if err != nil {
+			t.Fatalf("cant read page %d: %v", pageNr, err)
+		}
+
+		bleedBox := pageBox(t, doc, pageDict, "BleedBox")
+		trimBox := pageBox(t, doc, pageDict, "TrimBox")
+		mediaBox := pageBox(t, doc, pageDict, "MediaBox")
+
+		if !almostEqual(trimBox.LL.X-bleedBox.LL.X, bleed) || !almostEqual(bleedBox.UR.Y-trimBox.UR.Y, bleed) {
+			t.Fatalf("trim box should be inside the bleed box by the bleed (trim: %v, bleed: %v)", trimBox, bleedBox)
+		}
+
+		if !almostEqual(bleedBox.LL.X-mediaBox.LL.X, slug) || !almostEqual(mediaBox.UR.Y-bleedBox.UR.Y, slug) {
+			t.Fatalf("media box should be enlarged by the slug (media: %v, bleed: %v)", mediaBox, bleedBox)
+		}
+
+		content := pageContent(t, doc, pageNr)
+		if !bytes.Contains(content, []byte("1 1 1 1 K")) || !bytes.Contains(content, []byte(" c\n")) {
+			t.Fatalf("page %d should have crop and registration marks", pageNr)
+		}
+
+		if !bytes.Contains(content, []byte("Page")) {
+			t.Fatalf("page %d should keep its content", pageNr)
+		}
+	}
+}
+
+func TestPrintBleedWithoutMarks(t *testing.T) {
+	data := &models.RenderData{}
+	data.RenderOptions.Print = &models.RenderOptionsPrint{}
+	data.RenderOptions.Print.SetDefaults()
+
+	doc := readTestDocument(t, processTestPdf(t, 1, data))
+
+	pageDict, _, _, _ := doc.PageDict(1, false)
+
+	if mediaBox, bleedBox := pageBox(t, doc, pageDict, "MediaBox"), pageBox(t, doc, pageDict, "BleedBox"); *mediaBox != *bleedBox {
+		t.Fatalf("media box should be the bleed box without marks (media: %v, bleed: %v)", mediaBox, bleedBox)
+	}
+}
+
+func pageBox(t *testing.T, doc *document, pageDict types.Dict, name string) *types.Rectangle {
+	arr, err := doc.DereferenceArray(pageDict[name])
+	if err != nil || len(arr) != 4 {
+		t.Fatalf("page should have a %s (err: %v)", name, err)
+	}
+
+	return types.RectForArray(arr)
+}
+
+func almostEqual(a, b float64) bool {
+	return a-b < 0.01 && b-a < 0.01
+}
@@ -0,0 +1,61 @@
+package postprocessing
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// pdf pages with the size of the document (stationery, header and footer) are placed unscaled in the center of the page
+const pageStampDescription = "pos:c, scale:1 abs, rotation:0"
+
+type stationeryProcessor struct{}
+
+func (p *stationeryProcessor) name() string {
+	return "stationery"
+}
+
+func (p *stationeryProcessor) isRequired(data *models.RenderData) bool {
+	return data.RenderOptions.Stationery != nil
+}
+
+func (p *stationeryProcessor) process(ctx context.Context, doc *document, data *models.RenderData) error {
+	s := data.RenderOptions.Stationery
+
+	if err := s.Validate(); err != nil {
+		return err
+	}
+
+	firstPage := types.IntSet{1: true}
+	followingPages := types.IntSet{}
+
+	for pageNr := 2; pageNr <= doc.PageCount; pageNr++ {
+		followingPages[pageNr] = true
+	}
+
+	if len(s.FirstPagePdf) == 0 {
+		followingPages[1] = true
+	} else if err := addStationery(doc, s.FirstPagePdf, firstPage); err != nil {
+		return err
+	}
+
+	if len(s.Pdf) == 0 || len(followingPages) == 0 {
+		return nil
+	}
+
+	return addStationery(doc, s.Pdf, followingPages)
+}
+
+func addStationery(doc *document, pdf []byte, pages types.IntSet) error {
+	wm, err := api.PDFWatermarkForReadSeeker(bytes.NewReader(pdf), 1, pageStampDescription, false, false, types.POINTS)
+	if err != nil {
+		return err
+	}
+
+	return pdfcpu.AddWatermarks(doc.Context, pages, wm)
+}
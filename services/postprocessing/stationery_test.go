@@ -0,0 +1,46 @@
+package postprocessing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
+)
+
+func TestStationery(t *testing.T) {
+	data := &models.RenderData{}
+	data.RenderOptions.Stationery = &models.RenderOptionsStationery{Pdf: newTestPdf(t, 1)}
+
+	res := processTestPdf(t, 3, data)
+
+	if !hasWatermarks(t, res) {
+		t.Fatal("stationery should be placed underneath the pages")
+	}
+
+	doc := readTestDocument(t, res)
+	for pageNr := 1; pageNr <= doc.PageCount; pageNr++ {
+		if !isPageStamped(t, doc, pageNr) {
+			t.Fatalf("page %d should have the stationery", pageNr)
+		}
+	}
+}
+
+func TestStationeryFirstPageOnly(t *testing.T) {
+	data := &models.RenderData{}
+	data.RenderOptions.Stationery = &models.RenderOptionsStationery{FirstPagePdf: newTestPdf(t, 1)}
+
+	doc := readTestDocument(t, processTestPdf(t, 2, data))
+
+	if !isPageStamped(t, doc, 1) || isPageStamped(t, doc, 2) {
+		t.Fatal("only the first page should have the stationery")
+	}
+}
+
+func TestStationeryWithoutPdf(t *testing.T) {
+	data := &models.RenderData{}
+	data.RenderOptions.Stationery = &models.RenderOptionsStationery{}
+
+	if err := (&stationeryProcessor{}).process(context.Background(), readTestDocument(t, newTestPdf(t, 1)), data); err == nil {
+		t.Fatal("stationery without pdf should fail")
+	}
+}
@@ -0,0 +1,105 @@
+package postprocessing
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
+	"github.com/lucas-gaitzsch/pdf-turtle/utils"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	pdfcpumodel "github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+var watermarkAnchorsByPosition = map[string]string{
+	models.WatermarkPositionTopLeft:      "tl",
+	models.WatermarkPositionTopCenter:    "tc",
+	models.WatermarkPositionTopRight:     "tr",
+	models.WatermarkPositionLeft:         "l",
+	models.WatermarkPositionCenter:       "c",
+	models.WatermarkPositionRight:        "r",
+	models.WatermarkPositionBottomLeft:   "bl",
+	models.WatermarkPositionBottomCenter: "bc",
+	models.WatermarkPositionBottomRight:  "br",
+}
+
+type watermarkProcessor struct{}
+
+func (p *watermarkProcessor) name() string {
+	return "watermark"
+}
+
+func (p *watermarkProcessor) isRequired(data *models.RenderData) bool {
+	return data.RenderOptions.Watermark != nil
+}
+
+func (p *watermarkProcessor) process(ctx context.Context, doc *document, data *models.RenderData) error {
+	wm := data.RenderOptions.Watermark
+
+	if err := wm.Validate(); err != nil {
+		return err
+	}
+
+	pages, err := doc.selectPages(wm.Pages)
+	if err != nil {
+		return err
+	}
+
+	pdfcpuWm, err := newPdfcpuWatermark(wm)
+	if err != nil {
+		return err
+	}
+
+	return pdfcpu.AddWatermarks(doc.Context, pages, pdfcpuWm)
+}
+
+func newPdfcpuWatermark(wm *models.RenderOptionsWatermark) (*pdfcpumodel.Watermark, error) {
+	onTop := !wm.Background
+
+	switch {
+	case wm.Html != "":
+		if len(wm.HtmlPdf) == 0 {
+			return nil, fmt.Errorf("watermark html was not rendered")
+		}
+		// the html is rendered with the page size of the document and is stamped unscaled
+		desc := fmt.Sprintf("pos:c, scale:1 abs, rotation:0, opacity:%s", formatOpacity(wm.Opacity))
+		return api.PDFWatermarkForReadSeeker(bytes.NewReader(wm.HtmlPdf), 1, desc, onTop, false, types.POINTS)
+
+	case len(wm.Image) > 0:
+		return api.ImageWatermarkForReader(bytes.NewReader(wm.Image), getWatermarkDescription(wm), onTop, false, types.POINTS)
+
+	default:
+		desc := fmt.Sprintf("%s, font:%s, points:%d, fillcolor:%s", getWatermarkDescription(wm), wm.FontName, wm.FontSize, wm.Color)
+		return api.TextWatermark(wm.Text, desc, onTop, false, types.POINTS)
+	}
+}
+
+func getWatermarkDescription(wm *models.RenderOptionsWatermark) string {
+	anchor, ok := watermarkAnchorsByPosition[strings.ToLower(wm.Position)]
+	if !ok {
+		anchor = "c"
+	}
+
+	return fmt.Sprintf(
+		"pos:%s, offset:%.2f %.2f, rotation:%d, opacity:%s, scale:%.2f rel",
+		anchor,
+		utils.MmToPoints(wm.OffsetX),
+		utils.MmToPoints(wm.OffsetY),
+		wm.Rotation,
+		formatOpacity(wm.Opacity),
+		float64(wm.Scale)/100,
+	)
+}
+
+// formatOpacity returns the opacity from 0 to 1; without an opacity the stamp is opaque
+func formatOpacity(percent *int) string {
+	if percent == nil {
+		return "1.00"
+	}
+
+	return fmt.Sprintf("%.2f", float64(max(0, min(100, *percent)))/100)
+}
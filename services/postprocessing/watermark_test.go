@@ -0,0 +1,128 @@
+package postprocessing
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+)
+
+func TestTextWatermark(t *testing.T) {
+	opacity := 30
+
+	data := &models.RenderData{}
+	data.RenderOptions.Watermark = &models.RenderOptionsWatermark{Text: "DRAFT", Rotation: 45, Opacity: &opacity}
+	data.RenderOptions.SetDefaults()
+
+	res := processTestPdf(t, 3, data)
+
+	if !hasWatermarks(t, res) {
+		t.Fatal("pdf should contain watermarks")
+	}
+}
+
+func TestImageWatermarkOnSelectedPages(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 10, 10))
+	img.Set(5, 5, color.Black)
+
+	buf := new(bytes.Buffer)
+	if err := png.Encode(buf, img); err != nil {
+		t.Fatalf("cant encode test image: %v", err)
+	}
+
+	data := &models.RenderData{}
+	data.RenderOptions.Watermark = &models.RenderOptionsWatermark{
+		Image:    buf.Bytes(),
+		Position: models.WatermarkPositionTopRight,
+		Pages:    "odd",
+	}
+	data.RenderOptions.SetDefaults()
+
+	res := processTestPdf(t, 3, data)
+
+	doc := readTestDocument(t, res)
+
+	for pageNr := 1; pageNr <= doc.PageCount; pageNr++ {
+		isStamped := isPageStamped(t, doc, pageNr)
+
+		if isStamped != (pageNr%2 == 1) {
+			t.Fatalf("only odd pages should be stamped (page %d stamped: %v)", pageNr, isStamped)
+		}
+	}
+}
+
+func TestHtmlWatermarkWithoutRenderedPdf(t *testing.T) {
+	doc := readTestDocument(t, newTestPdf(t, 1))
+
+	data := &models.RenderData{}
+	data.RenderOptions.Watermark = &models.RenderOptionsWatermark{Html: "<b>COPY</b>"}
+
+	if err := (&watermarkProcessor{}).process(context.Background(), doc, data); err == nil {
+		t.Fatal("html watermark without rendered pdf should fail")
+	}
+}
+
+func TestHtmlWatermark(t *testing.T) {
+	data := &models.RenderData{}
+	data.RenderOptions.Watermark = &models.RenderOptionsWatermark{
+		Html:    "<b>COPY</b>",
+		HtmlPdf: newTestPdf(t, 1),
+	}
+	data.RenderOptions.SetDefaults()
+
+	if !hasWatermarks(t, processTestPdf(t, 2, data)) {
+		t.Fatal("pdf should contain watermarks")
+	}
+}
+
+func TestWatermarkInvalidPageSelection(t *testing.T) {
+	doc := readTestDocument(t, newTestPdf(t, 1))
+
+	data := &models.RenderData{}
+	data.RenderOptions.Watermark = &models.RenderOptionsWatermark{Text: "DRAFT", Pages: "a-b"}
+	data.RenderOptions.SetDefaults()
+
+	if err := (&watermarkProcessor{}).process(context.Background(), doc, data); err == nil {
+		t.Fatal("invalid page selection should fail")
+	}
+}
+
+// isPageStamped returns true if a form xobject (used by pdfcpu for stamps) is referenced by the page
+func isPageStamped(t *testing.T, doc *document, pageNr int) bool {
+	pageDict, _, _, err := doc.PageDict(pageNr, false)
+	if err != nil {
+		t.Fatalf("cant read page %d: %v", pageNr, err)
+	}
+
+	resources, _ := doc.DereferenceDict(pageDict["Resources"])
+	_, isStamped := resources.Find("XObject")
+
+	return isStamped
+}
+
+func hasWatermarks(t *testing.T, pdfBytes []byte) bool {
+	ok, err := api.HasWatermarks(bytes.NewReader(pdfBytes), newConfiguration())
+	if err != nil {
+		t.Fatalf("cant check watermarks: %v", err)
+	}
+
+	return ok
+}
+
+func TestWatermarkDescriptionWithZeroOpacity(t *testing.T) {
+	opacity := 0
+
+	wm := &models.RenderOptionsWatermark{Text: "DRAFT", Opacity: &opacity}
+	wm.SetDefaults()
+
+	if desc := getWatermarkDescription(wm); !strings.Contains(desc, "opacity:0.00") {
+		t.Fatalf("explicit opacity 0 should be kept (curr: %s)", desc)
+	}
+}
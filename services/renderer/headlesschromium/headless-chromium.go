@@ -4,13 +4,16 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"io"
+	"math"
 	"time"
 
 	"github.com/lucas-gaitzsch/pdf-turtle/config"
 
 	"github.com/rs/zerolog/log"
 
+	"github.com/chromedp/cdproto/emulation"
 	"github.com/chromedp/cdproto/page"
 	"github.com/chromedp/chromedp"
 )
@@ -84,39 +87,7 @@ func RenderHtmlAsPdf(chromiumAllocCtx context.Context, outerCtx context.Context,
 	tasks := chromedp.Tasks{
 		chromedp.Navigate(location),
 
-		chromedp.ActionFunc(func(cctx context.Context) error {
-			lctx, cancelLctx := context.WithCancel(cctx)
-			defer cancelLctx()
-
-			done := make(chan bool, 1)
-
-			chromedp.ListenTarget(lctx, func(ev any) {
-				if _, ok := ev.(*page.EventLoadEventFired); ok {
-					cancelLctx()
-					done <- true
-				}
-			})
-
-			frameTree, err := page.GetFrameTree().Do(cctx)
-			if err != nil {
-				return err
-			}
-
-			if html != nil {
-				if err := page.SetDocumentContent(frameTree.Frame.ID, *html).Do(cctx); err != nil {
-					return err
-				}
-			}
-
-			select {
-			case <-done:
-				return nil
-			case <-time.After(time.Duration(config.Get(outerCtx).RenderTimeoutInSeconds) * time.Second):
-				return errors.New("render timeout")
-			case <-outerCtx.Done():
-				return errors.New("canceled by outer ctx")
-			}
-		}),
+		loadHtml(outerCtx, html),
 
 		// injectCss(preloadedMergedCss),
 
@@ -137,6 +108,91 @@ func RenderHtmlAsPdf(chromiumAllocCtx context.Context, outerCtx context.Context,
 	return pdfStream, nil
 }
 
+const (
+	measureElementId = "pdf-turtle-measure"
+	cssPxPerInch     = 96
+	mmPerInch        = 25.4
+)
+
+// MeasureHtmlHeight renders the html with the given width (and horizontal padding) and returns the height of the content in mm
+func MeasureHtmlHeight(chromiumAllocCtx context.Context, outerCtx context.Context, location string, html string, widthInMm int, paddingLeftInMm int, paddingRightInMm int) (float64, error) {
+	if location == "" {
+		location = "about:blank"
+	}
+
+	cctx, cancel := chromedp.NewContext(chromiumAllocCtx)
+	defer cancel()
+
+	go func() {
+		select {
+		case <-cctx.Done():
+		case <-outerCtx.Done():
+			cancel()
+		}
+	}()
+
+	wrappedHtml := fmt.Sprintf(
+		`<html><body style="margin: 0"><div id="%s" style="box-sizing: border-box; display: flow-root; width: %dmm; padding: 0 %dmm 0 %dmm">%s</div></body></html>`,
+		measureElementId,
+		widthInMm,
+		paddingRightInMm,
+		paddingLeftInMm,
+		html,
+	)
+
+	var heightInPx float64
+
+	tasks := chromedp.Tasks{
+		emulation.SetDeviceMetricsOverride(int64(math.Ceil(float64(widthInMm)/mmPerInch*cssPxPerInch)), 100, 1, false),
+		chromedp.Navigate(location),
+		loadHtml(outerCtx, &wrappedHtml),
+		chromedp.Evaluate(fmt.Sprintf(`document.getElementById("%s").getBoundingClientRect().height`, measureElementId), &heightInPx),
+	}
+
+	if err := chromedp.Run(cctx, runWithTimeOut(outerCtx, tasks)); err != nil {
+		return 0, err
+	}
+
+	return heightInPx / cssPxPerInch * mmPerInch, nil
+}
+
+// loadHtml sets the html as document content and waits for the load event
+func loadHtml(outerCtx context.Context, html *string) chromedp.ActionFunc {
+	return func(cctx context.Context) error {
+		lctx, cancelLctx := context.WithCancel(cctx)
+		defer cancelLctx()
+
+		done := make(chan bool, 1)
+
+		chromedp.ListenTarget(lctx, func(ev any) {
+			if _, ok := ev.(*page.EventLoadEventFired); ok {
+				cancelLctx()
+				done <- true
+			}
+		})
+
+		frameTree, err := page.GetFrameTree().Do(cctx)
+		if err != nil {
+			return err
+		}
+
+		if html != nil {
+			if err := page.SetDocumentContent(frameTree.Frame.ID, *html).Do(cctx); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-done:
+			return nil
+		case <-time.After(time.Duration(config.Get(outerCtx).RenderTimeoutInSeconds) * time.Second):
+			return errors.New("render timeout")
+		case <-outerCtx.Done():
+			return errors.New("canceled by outer ctx")
+		}
+	}
+}
+
 func runWithTimeOut(outerCtx context.Context, tasks chromedp.Tasks) chromedp.ActionFunc {
 	timeout := time.Duration(config.Get(outerCtx).RenderTimeoutInSeconds) * time.Second
 	return func(ctx context.Context) error {
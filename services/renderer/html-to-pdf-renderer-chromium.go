@@ -101,16 +101,11 @@ func (r *HtmlToPdfRendererChromium) RenderHtmlAsPdf(ctx context.Context, data *m
 			WithMarginTop(utils.MmToInches(margins.Top)).
 			WithMarginRight(utils.MmToInches(margins.Right) + magicBodyPaddingInInches).
 			WithMarginBottom(utils.MmToInches(margins.Bottom)).
-			WithMarginLeft(utils.MmToInches(margins.Left) + magicBodyPaddingInInches)
+			WithMarginLeft(utils.MmToInches(margins.Left) + magicBodyPaddingInInches).
+			WithGenerateDocumentOutline(data.RenderOptions.Outline != nil && data.RenderOptions.Outline.GeneratedByChromium)
 
 		if hasHeaderOrFooter {
-			var headerFooterWidth int
-
-			if !data.RenderOptions.Landscape {
-				headerFooterWidth = data.RenderOptions.PageSize.Width
-			} else {
-				headerFooterWidth = data.RenderOptions.PageSize.Height
-			}
+			headerFooterWidth := getHeaderFooterWidth(&data.RenderOptions)
 
 			headerFooterAppendCss := fmt.Sprintf(`
 				#header, #footer {
@@ -173,6 +168,25 @@ func (r *HtmlToPdfRendererChromium) RenderHtmlAsPdf(ctx context.Context, data *m
 	return headlesschromium.RenderHtmlAsPdf(r.ChromiumCtx, ctx, data.RenderOptions.BasePath, bodyHtml, paramsFunc)
 }
 
+// MeasureHtmlHeight returns the height in mm of the header or footer html rendered with the width of the page
+func (r *HtmlToPdfRendererChromium) MeasureHtmlHeight(ctx context.Context, html string, options *models.RenderOptions) (float64, error) {
+	margins := models.RenderOptionsMargins{}
+
+	if options.Margins != nil {
+		margins = *options.Margins
+	}
+
+	return headlesschromium.MeasureHtmlHeight(r.ChromiumCtx, ctx, options.BasePath, html, getHeaderFooterWidth(options), margins.Left, margins.Right)
+}
+
+func getHeaderFooterWidth(options *models.RenderOptions) int {
+	if options.Landscape {
+		return options.PageSize.Height
+	}
+
+	return options.PageSize.Width
+}
+
 func (r *HtmlToPdfRendererChromium) Close() {
 	r.watcherCtxCancelFunc()
 	<-r.watcherClosedChan
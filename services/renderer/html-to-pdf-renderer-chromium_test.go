@@ -3,11 +3,15 @@ package renderer
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"io"
+	"os"
 	"testing"
 
 	"github.com/lucas-gaitzsch/pdf-turtle/config"
 	"github.com/lucas-gaitzsch/pdf-turtle/models"
+	"github.com/lucas-gaitzsch/pdf-turtle/services/bundles"
+	"github.com/lucas-gaitzsch/pdf-turtle/services/postprocessing"
 	"github.com/lucas-gaitzsch/pdf-turtle/services/templating/templateengines"
 	"github.com/lucas-gaitzsch/pdf-turtle/utils"
 	"github.com/lucas-gaitzsch/pdf-turtle/utils/logging"
@@ -125,6 +129,61 @@ func TestRenderHugeHtmlAsPdf(t *testing.T) {
 	}
 }
 
+func TestRenderTestBundleDeterministic(t *testing.T) {
+	logging.InitTestLogger(t)
+	defer logging.SetNullLogger()
+
+	ctxCancel, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ctx := getContextWithTestConfig(ctxCancel)
+
+	f, err := os.ReadFile("../../test-assets/test-bundle.zip")
+	if err != nil {
+		t.Fatalf("cant read test bundle: %v", err)
+	}
+
+	bundle := &bundles.Bundle{}
+	if err := bundle.ReadFromZip(bytes.NewReader(f), int64(len(f))); err != nil {
+		t.Fatalf("cant read test bundle: %v", err)
+	}
+
+	renderer := NewAsyncHtmlRendererChromium(ctx)
+	defer renderer.Close()
+
+	render := func() [32]byte {
+		data := &models.RenderData{
+			Html:          bundle.GetBodyHtml(),
+			HeaderHtml:    bundle.GetHeaderHtml(),
+			FooterHtml:    bundle.GetFooterHtml(),
+			RenderOptions: bundle.GetOptions(),
+		}
+		data.RenderOptions.Deterministic = &models.RenderOptionsDeterministic{}
+		data.RenderOptions.SetDefaults()
+
+		reader, err := renderer.RenderHtmlAsPdf(ctx, data)
+		if err != nil {
+			t.Fatalf("RenderHtmlAsPdf fails: %v", err)
+		}
+
+		processed, err := postprocessing.NewPostProcessingService().Process(ctx, reader, data)
+		if err != nil {
+			t.Fatalf("post processing fails: %v", err)
+		}
+
+		b, err := io.ReadAll(processed)
+		if err != nil {
+			t.Fatalf("cant read pdf: %v", err)
+		}
+
+		return sha256.Sum256(b)
+	}
+
+	if render() != render() {
+		t.Fatal("rendering the same bundle twice should produce the same bytes")
+	}
+}
+
 func getContextWithTestConfig(parentCtx context.Context) context.Context  {	
 	c := &config.Config{}
 	utils.ReflectDefaultValues(c)
@@ -9,5 +9,6 @@ import (
 
 type HtmlToPdfRendererAbstraction interface {
 	RenderHtmlAsPdf(ctx context.Context, data *models.RenderData) (io.Reader, error)
+	MeasureHtmlHeight(ctx context.Context, html string, options *models.RenderOptions) (float64, error)
 	Close()
 }
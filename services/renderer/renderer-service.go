@@ -151,6 +151,17 @@ func (rbs *RendererBackgroundService) RenderAndReceive(job models.Job) (io.Reade
 	}
 }
 
+// MeasureHtmlHeight measures the height of the html in mm with a worker of the pool
+func (rbs *RendererBackgroundService) MeasureHtmlHeight(ctx context.Context, html string, options *models.RenderOptions) (float64, error) {
+	rbs.acquiredWorker()
+	defer rbs.releaseWorker()
+
+	measureCtx, cancel := context.WithTimeout(ctx, rbs.renderTimeout)
+	defer cancel()
+
+	return rbs.htmlToPdfRenderer.MeasureHtmlHeight(measureCtx, html, options)
+}
+
 func (rbs *RendererBackgroundService) Close() {
 	rbs.htmlToPdfRenderer.Close()
 	rbs.localCtxCancel()
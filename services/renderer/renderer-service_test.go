@@ -22,6 +22,10 @@ func (m *htmlToPdfRendererMock) RenderHtmlAsPdf(ctx context.Context, data *model
 	return bytes.NewReader([]byte{}), nil
 }
 
+func (m *htmlToPdfRendererMock) MeasureHtmlHeight(ctx context.Context, html string, options *models.RenderOptions) (float64, error) {
+	return 0, nil
+}
+
 func (m *htmlToPdfRendererMock) Close() {
 	close(m.ContinueChan)
 	close(m.HitRenderChan)
@@ -2,13 +2,19 @@ package templating
 
 import (
 	"errors"
+	"fmt"
+	"slices"
 
 	"github.com/lucas-gaitzsch/pdf-turtle/models"
+	"github.com/lucas-gaitzsch/pdf-turtle/services/modelcontract"
 	"github.com/lucas-gaitzsch/pdf-turtle/services/templating/templateengines"
 )
 
 type TemplateServiceAbstraction interface {
 	ExecuteTemplate(data *models.RenderTemplateData) (*models.RenderData, error)
+	TestTemplate(data *models.RenderTemplateData) []models.TemplateDiagnostic
+	GetModelContract(data *models.RenderTemplateData) (*models.ModelContract, error)
+	ExecuteAttachmentTemplates(attachments []models.Attachment, templateEngineKey string, locale string, model any) ([]models.Attachment, error)
 }
 
 func NewTemplateService() TemplateServiceAbstraction {
@@ -32,6 +38,9 @@ func (ts *TemplateService) ExecuteTemplate(templateData *models.RenderTemplateDa
 
 	templateengines.LogParsedTemplateEngine(templateData.TemplateEngine, templateEngine, found)
 
+	templateEngine.SetLocale(templateData.RenderOptions.Locale)
+	templateEngine.SetPartials(templateData.Partials)
+
 	data := &models.RenderData{
 		RenderOptions: templateData.RenderOptions,
 	}
@@ -51,9 +60,167 @@ func (ts *TemplateService) ExecuteTemplate(templateData *models.RenderTemplateDa
 		return nil, err
 	}
 
+	headerHtmlPages, err := executePageVariantTemplates(templateEngine, templateData.HeaderHtmlTemplatePages, templateData.Model)
+	if err != nil {
+		return nil, err
+	}
+
+	footerHtmlPages, err := executePageVariantTemplates(templateEngine, templateData.FooterHtmlTemplatePages, templateData.Model)
+	if err != nil {
+		return nil, err
+	}
+
+	attachments, err := executeAttachmentTemplates(templateEngine, templateData.RenderOptions.Attachments, templateData.Model)
+	if err != nil {
+		return nil, err
+	}
+
 	data.Html = html
 	data.HeaderHtml = *headerHtml
 	data.FooterHtml = *footerHtml
+	data.HeaderHtmlPages = headerHtmlPages
+	data.FooterHtmlPages = footerHtmlPages
+	data.RenderOptions.Attachments = attachments
 
 	return data, nil
 }
+
+type namedTemplate struct {
+	name     string
+	template *string
+}
+
+// TestTemplate checks the templates of the body, header, footer and the page variants with the model
+func (ts *TemplateService) TestTemplate(templateData *models.RenderTemplateData) []models.TemplateDiagnostic {
+	templateEngine, found := templateengines.GetTemplateEngineByKey(templateData.TemplateEngine)
+
+	templateengines.LogParsedTemplateEngine(templateData.TemplateEngine, templateEngine, found)
+
+	templateEngine.SetLocale(templateData.RenderOptions.Locale)
+	templateEngine.SetPartials(templateData.Partials)
+
+	diagnostics := []models.TemplateDiagnostic{}
+
+	for _, t := range getNamedTemplates(templateData) {
+		for _, d := range templateEngine.Diagnose(t.template, templateData.Model) {
+			d.Template = t.name
+			diagnostics = append(diagnostics, d)
+		}
+	}
+
+	return diagnostics
+}
+
+// GetModelContract analyses the model paths of the body, header, footer and the page variants and compares them with the model
+func (ts *TemplateService) GetModelContract(templateData *models.RenderTemplateData) (*models.ModelContract, error) {
+	templateEngine, found := templateengines.GetTemplateEngineByKey(templateData.TemplateEngine)
+
+	templateengines.LogParsedTemplateEngine(templateData.TemplateEngine, templateEngine, found)
+
+	templateEngine.SetPartials(templateData.Partials)
+
+	pathLists := [][]models.ModelPath{}
+
+	for _, t := range getNamedTemplates(templateData) {
+		paths, err := templateEngine.ModelPaths(t.template)
+		if err != nil {
+			return nil, fmt.Errorf("cant analyse %s template: %w", t.name, err)
+		}
+
+		pathLists = append(pathLists, paths)
+	}
+
+	return modelcontract.NewModelContract(modelcontract.MergePaths(pathLists...), templateData.Model), nil
+}
+
+// getNamedTemplates returns the non empty templates of the body, header, footer and the page variants
+func getNamedTemplates(templateData *models.RenderTemplateData) []namedTemplate {
+	templates := []namedTemplate{
+		{"body", templateData.HtmlTemplate},
+		{"header", &templateData.HeaderHtmlTemplate},
+		{"footer", &templateData.FooterHtmlTemplate},
+	}
+
+	for _, variant := range models.PageVariantNames {
+		templates = append(templates,
+			namedTemplate{"header." + variant, templateData.HeaderHtmlTemplatePages.Get(variant)},
+			namedTemplate{"footer." + variant, templateData.FooterHtmlTemplatePages.Get(variant)},
+		)
+	}
+
+	return slices.DeleteFunc(templates, func(t namedTemplate) bool {
+		return t.template == nil || *t.template == ""
+	})
+}
+
+func executePageVariantTemplates(templateEngine templateengines.TemplateEngine, templates models.PageVariants, model any) (models.PageVariants, error) {
+	res := models.PageVariants{}
+
+	for _, variant := range models.PageVariantNames {
+		template := templates.Get(variant)
+		if template == nil {
+			continue
+		}
+
+		html, err := templateEngine.Execute(template, model)
+		if err != nil {
+			return res, fmt.Errorf("cant execute template of %s page variant: %w", variant, err)
+		}
+
+		res.Set(variant, html)
+	}
+
+	return res, nil
+}
+
+// ExecuteAttachmentTemplates executes the attachment templates of renders without html template (e.g. bundles without model or markdown).
+// Without model an empty model is used, so the template source is never embedded.
+func (ts *TemplateService) ExecuteAttachmentTemplates(attachments []models.Attachment, templateEngineKey string, locale string, model any) ([]models.Attachment, error) {
+	if !slices.ContainsFunc(attachments, func(a models.Attachment) bool { return a.IsTemplate }) {
+		return attachments, nil
+	}
+
+	templateEngine, found := templateengines.GetTemplateEngineByKey(templateEngineKey)
+
+	templateengines.LogParsedTemplateEngine(templateEngineKey, templateEngine, found)
+
+	templateEngine.SetLocale(locale)
+
+	if model == nil {
+		model = map[string]any{}
+	}
+
+	return executeAttachmentTemplates(templateEngine, attachments, model)
+}
+
+// executeAttachmentTemplates renders the attachments marked as template. Engines escaping html are executed as text, because attachments are no html (e.g. factur-x.xml).
+func executeAttachmentTemplates(templateEngine templateengines.TemplateEngine, attachments []models.Attachment, model any) ([]models.Attachment, error) {
+	if len(attachments) == 0 {
+		return attachments, nil
+	}
+
+	execute := templateEngine.Execute
+	if textEngine, ok := templateEngine.(templateengines.TextTemplateEngine); ok {
+		execute = textEngine.ExecuteText
+	}
+
+	res := make([]models.Attachment, len(attachments))
+
+	for i, a := range attachments {
+		if a.IsTemplate {
+			template := string(a.Content)
+
+			content, err := execute(&template, model)
+			if err != nil {
+				return nil, fmt.Errorf("cant execute template of attachment '%s': %w", a.Name, err)
+			}
+
+			a.Content = []byte(*content)
+			a.IsTemplate = false
+		}
+
+		res[i] = a
+	}
+
+	return res, nil
+}
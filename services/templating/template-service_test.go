@@ -0,0 +1,93 @@
+package templating
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
+)
+
+const testInvoiceXml = `<?xml version="1.0" encoding="UTF-8"?>
+<!-- generated by pdf turtle -->
+<rsm:CrossIndustryInvoice xmlns:rsm="urn:un:unece:uncefact:data:standard:CrossIndustryInvoice:100">
+	<rsm:ExchangedDocument><ram:ID xmlns:ram="urn:ram">{{.number}}</ram:ID></rsm:ExchangedDocument>
+</rsm:CrossIndustryInvoice>`
+
+// readXmlTokens parses the xml and returns the kinds of the tokens (declaration, comment) with the text of the elements
+func readXmlTokens(t *testing.T, content []byte) (hasDeclaration bool, hasComment bool, text string) {
+	decoder := xml.NewDecoder(strings.NewReader(string(content)))
+
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return
+		}
+		if err != nil {
+			t.Fatalf("attachment should be well-formed xml: %v\n%s", err, content)
+		}
+
+		switch tok := token.(type) {
+		case xml.ProcInst:
+			hasDeclaration = hasDeclaration || tok.Target == "xml"
+		case xml.Comment:
+			hasComment = true
+		case xml.CharData:
+			text += strings.TrimSpace(string(tok))
+		}
+	}
+}
+
+func TestExecuteTemplateWithXmlAttachment(t *testing.T) {
+	html := "<p>{{.number}}</p>"
+
+	data, err := NewTemplateService().ExecuteTemplate(&models.RenderTemplateData{
+		HtmlTemplate: &html,
+		Model:        map[string]any{"number": "RE-1"},
+		RenderOptions: models.RenderOptions{
+			Attachments: []models.Attachment{{Name: "factur-x.xml", Content: []byte(testInvoiceXml), IsTemplate: true}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("cant execute template: %v", err)
+	}
+
+	a := data.RenderOptions.Attachments[0]
+	if a.IsTemplate {
+		t.Fatal("attachment should be executed")
+	}
+
+	hasDeclaration, hasComment, text := readXmlTokens(t, a.Content)
+
+	if !hasDeclaration || !hasComment {
+		t.Fatalf("xml declaration and comment should be kept (curr: %s)", a.Content)
+	}
+
+	if text != "RE-1" {
+		t.Fatalf("model value should be rendered (curr: %s)", text)
+	}
+}
+
+func TestExecuteAttachmentTemplatesWithoutModel(t *testing.T) {
+	attachments := []models.Attachment{
+		{Name: "factur-x.xml", Content: []byte(testInvoiceXml), IsTemplate: true},
+		{Name: "notes.txt", Content: []byte("{{ not a template }}")},
+	}
+
+	res, err := NewTemplateService().ExecuteAttachmentTemplates(attachments, "", "", nil)
+	if err != nil {
+		t.Fatalf("cant execute attachment templates: %v", err)
+	}
+
+	if res[0].IsTemplate || strings.Contains(string(res[0].Content), "{{") {
+		t.Fatalf("template should be executed with an empty model (curr: %s)", res[0].Content)
+	}
+
+	readXmlTokens(t, res[0].Content)
+
+	if string(res[1].Content) != "{{ not a template }}" {
+		t.Fatal("attachments which are no template should not be changed")
+	}
+}
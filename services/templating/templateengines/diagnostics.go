@@ -0,0 +1,62 @@
+package templateengines
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
+)
+
+// count of lines before and after the line of the issue in the excerpt
+const excerptContextLines = 1
+
+func newDiagnostic(templateHtml *string, severity string, line int, column int, err error) models.TemplateDiagnostic {
+	d := models.TemplateDiagnostic{
+		Severity: severity,
+		Line:     line,
+		Column:   column,
+		Message:  err.Error(),
+	}
+
+	if templateHtml != nil {
+		d.Excerpt = getExcerpt(*templateHtml, line, column)
+	}
+
+	return d
+}
+
+// getExcerpt returns the numbered lines around the line with a marker under the column
+func getExcerpt(templateHtml string, line int, column int) string {
+	lines := strings.Split(templateHtml, "\n")
+
+	if line < 1 || line > len(lines) {
+		return ""
+	}
+
+	from := max(1, line-excerptContextLines)
+	to := min(len(lines), line+excerptContextLines)
+	width := len(strconv.Itoa(to))
+
+	sb := new(strings.Builder)
+
+	for nr := from; nr <= to; nr++ {
+		text := strings.TrimSuffix(lines[nr-1], "\r")
+
+		fmt.Fprintf(sb, "%*d | %s\n", width, nr, text)
+
+		if nr == line && column > 0 {
+			// keep tabs, so the marker is aligned in every editor
+			indent := strings.Map(func(r rune) rune {
+				if r == '\t' {
+					return r
+				}
+				return ' '
+			}, text[:min(column-1, len(text))])
+
+			fmt.Fprintf(sb, "%*s | %s^\n", width, "", indent)
+		}
+	}
+
+	return strings.TrimSuffix(sb.String(), "\n")
+}
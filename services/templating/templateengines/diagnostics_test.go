@@ -0,0 +1,96 @@
+package templateengines
+
+import (
+	"testing"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
+)
+
+func diagnose(t *testing.T, engineKey string, templateStr string) models.TemplateDiagnostic {
+	engine := getTemplateEngineByKey(t, engineKey)
+
+	diagnostics := engine.Diagnose(&templateStr, getModel())
+	if len(diagnostics) != 1 {
+		t.Fatalf("%s: one diagnostic expected (curr: %v)", engineKey, diagnostics)
+	}
+
+	return diagnostics[0]
+}
+
+func TestDiagnoseGoTemplateMissingValue(t *testing.T) {
+	d := diagnose(t, GoTemplateEngineKey, goTemplateUnknownProperty)
+
+	if d.Severity != models.TemplateDiagnosticSeverityWarning || d.ModelPath != "lastname" {
+		t.Fatalf("missing value should be a warning with model path lastname (curr: %+v)", d)
+	}
+
+	if d.Line != 4 || d.Column != 19 {
+		t.Fatalf("position should be 4:19 (curr: %d:%d)", d.Line, d.Column)
+	}
+
+	expectedExcerpt := "3 | <body>\n4 | \t<h1>Profile of {{.lastname}}</h1>\n  | \t                 ^\n5 | \t<p>Working at {{.company.name}}</p>"
+	if d.Excerpt != expectedExcerpt {
+		t.Fatalf("excerpt should be\n%s\n(curr:\n%s)", expectedExcerpt, d.Excerpt)
+	}
+}
+
+func TestDiagnoseGoTemplateSyntaxError(t *testing.T) {
+	d := diagnose(t, GoTemplateEngineKey, goTemplateInvalid)
+
+	if d.Severity != models.TemplateDiagnosticSeverityError || d.Line != 4 || d.ModelPath != "" {
+		t.Fatalf("syntax error should be an error in line 4 (curr: %+v)", d)
+	}
+}
+
+func TestDiagnoseLiquidUndefinedVariable(t *testing.T) {
+	d := diagnose(t, LiquidTemplateEngineKey, liquidTemplateUnknownProperty)
+
+	if d.Severity != models.TemplateDiagnosticSeverityWarning || d.ModelPath != "lastname" || d.Line != 4 {
+		t.Fatalf("undefined variable should be a warning in line 4 with model path lastname (curr: %+v)", d)
+	}
+}
+
+func TestDiagnoseLiquidSyntaxError(t *testing.T) {
+	d := diagnose(t, LiquidTemplateEngineKey, liquidTemplateInvalid)
+
+	if d.Severity != models.TemplateDiagnosticSeverityError || d.Line != 5 {
+		t.Fatalf("syntax error should be an error in line 5 (curr: %+v)", d)
+	}
+}
+
+func TestDiagnoseHandlebarsSyntaxError(t *testing.T) {
+	d := diagnose(t, HandlebarsTemplateEngineKey, handlebarsTemplateInvalid)
+
+	if d.Severity != models.TemplateDiagnosticSeverityError || d.Line != 4 || d.Excerpt == "" {
+		t.Fatalf("syntax error should be an error in line 4 with excerpt (curr: %+v)", d)
+	}
+}
+
+func TestDiagnoseDjangoSyntaxError(t *testing.T) {
+	d := diagnose(t, DjangoTemplateEngineKey, djangoTemplateInvalid)
+
+	if d.Severity != models.TemplateDiagnosticSeverityError || d.Line != 4 || d.Column == 0 {
+		t.Fatalf("syntax error should be an error in line 4 with column (curr: %+v)", d)
+	}
+}
+
+func TestDiagnoseValidTemplates(t *testing.T) {
+	for key, templateStr := range map[string]string{
+		GoTemplateEngineKey:         goTemplate,
+		HandlebarsTemplateEngineKey: handlebarsTemplate,
+		DjangoTemplateEngineKey:     djangoTemplate,
+		LiquidTemplateEngineKey:     liquidTemplate,
+	} {
+		engine := getTemplateEngineByKey(t, key)
+
+		if diagnostics := engine.Diagnose(&templateStr, getModel()); len(diagnostics) != 0 {
+			t.Fatalf("%s: valid template should have no diagnostics (curr: %v)", key, diagnostics)
+		}
+	}
+}
+
+func TestExcerptOutOfRange(t *testing.T) {
+	if excerpt := getExcerpt("one line", 3, 1); excerpt != "" {
+		t.Fatalf("excerpt of unknown line should be empty (curr: %s)", excerpt)
+	}
+}
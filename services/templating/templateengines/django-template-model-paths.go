@@ -0,0 +1,134 @@
+package templateengines
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
+)
+
+var (
+	djangoTagRegex        = regexp.MustCompile(`(?s)\{\{(.*?)\}\}|\{%(.*?)%\}|\{#.*?#\}`)
+	djangoStringRegex     = regexp.MustCompile(`"(?:[^"\\]|\\.)*"|'(?:[^'\\]|\\.)*'`)
+	djangoVariableRegex   = regexp.MustCompile(`(\|\s*)?\b([A-Za-z_]\w*(?:\.\w+)*)`)
+	djangoSingleRegex     = regexp.MustCompile(`^(?:not\s+)?([A-Za-z_]\w*(?:\.\w+)*)$`)
+	djangoForRegex        = regexp.MustCompile(`^for\s+(?:\w+\s*,\s*)?(\w+)\s+in\s+(.+?)(?:\s+reversed)?(?:\s+sorted)?$`)
+	djangoWithAsRegex     = regexp.MustCompile(`^with\s+(.+?)\s+as\s+(\w+)$`)
+	djangoAssignmentRegex = regexp.MustCompile(`(\w+)\s*=\s*([^\s=]+)`)
+)
+
+// ModelPaths scans the tags of the template, because the AST of pongo2 is not exported.
+// The template is parsed first to report syntax errors.
+func (te *DjangoTemplateEngine) ModelPaths(templateHtml *string) ([]models.ModelPath, error) {
+	if templateHtml == nil {
+		return nil, errors.New("templateHtml is nil")
+	}
+
+	if _, _, err := te.fromString(*templateHtml); err != nil {
+		return nil, err
+	}
+
+	s := &djangoModelPathScanner{
+		collector: newModelPathCollector(),
+		scopes:    []map[string]string{{"model": ""}},
+	}
+
+	for _, m := range djangoTagRegex.FindAllStringSubmatch(*templateHtml, -1) {
+		if variable := strings.TrimSpace(m[1]); variable != "" {
+			s.expression(variable, false)
+		} else if tag := strings.TrimSpace(m[2]); tag != "" {
+			s.tag(tag)
+		}
+	}
+
+	return s.collector.paths(), nil
+}
+
+// djangoModelPathScanner tracks the variables of for, with and set tags; the root scope only contains the model
+type djangoModelPathScanner struct {
+	collector *modelPathCollector
+	scopes    []map[string]string
+}
+
+func (s *djangoModelPathScanner) tag(tag string) {
+	name, args, _ := strings.Cut(tag, " ")
+
+	switch name {
+	case "for":
+		scope := map[string]string{}
+		if m := djangoForRegex.FindStringSubmatch(tag); m != nil {
+			if path, known := s.expression(m[2], false); known {
+				scope[m[1]] = path + "[]"
+				s.collector.add(path+"[]", false)
+			}
+		}
+		s.scopes = append(s.scopes, scope)
+	case "with":
+		scope := map[string]string{}
+		if m := djangoWithAsRegex.FindStringSubmatch(tag); m != nil {
+			if path, known := s.expression(m[1], false); known {
+				scope[m[2]] = path
+			}
+		} else {
+			s.assignments(args, scope)
+		}
+		s.scopes = append(s.scopes, scope)
+	case "set":
+		s.assignments(args, s.scopes[len(s.scopes)-1])
+	case "endfor", "endwith":
+		if len(s.scopes) > 1 {
+			s.scopes = s.scopes[:len(s.scopes)-1]
+		}
+	case "if", "elif":
+		s.expression(args, true)
+	default:
+		s.expression(args, false)
+	}
+}
+
+func (s *djangoModelPathScanner) assignments(args string, scope map[string]string) {
+	for _, m := range djangoAssignmentRegex.FindAllStringSubmatch(args, -1) {
+		if path, known := s.expression(m[2], false); known {
+			scope[m[1]] = path
+		} else {
+			delete(scope, m[1])
+		}
+	}
+}
+
+// expression collects the paths of the expression and returns the path of its value, if the expression is a single model value
+func (s *djangoModelPathScanner) expression(expr string, condition bool) (string, bool) {
+	expr = strings.TrimSpace(djangoStringRegex.ReplaceAllString(expr, `""`))
+
+	single := djangoSingleRegex.FindStringSubmatch(expr)
+
+	for _, m := range djangoVariableRegex.FindAllStringSubmatch(expr, -1) {
+		// filter names are not part of the model
+		if m[1] != "" {
+			continue
+		}
+
+		if path, known := s.resolve(m[2]); known {
+			s.collector.add(path, condition && single != nil)
+		}
+	}
+
+	if single == nil {
+		return "", false
+	}
+
+	return s.resolve(single[1])
+}
+
+func (s *djangoModelPathScanner) resolve(variable string) (string, bool) {
+	parts := strings.Split(variable, ".")
+
+	for i := len(s.scopes) - 1; i >= 0; i-- {
+		if base, found := s.scopes[i][parts[0]]; found {
+			return joinModelPath(base, parts[1:]...), true
+		}
+	}
+
+	return "", false
+}
@@ -1,7 +1,18 @@
 package templateengines
 
 import (
+	"bytes"
+	"cmp"
 	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"math"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
 
 	"github.com/flosch/pongo2/v6"
 )
@@ -9,6 +20,8 @@ import (
 const DjangoTemplateEngineKey = "django"
 
 type DjangoTemplateEngine struct {
+	localizedHelpers
+	templatePartials
 }
 
 func (te *DjangoTemplateEngine) Execute(templateHtml *string, model any) (*string, error) {
@@ -18,18 +31,24 @@ func (te *DjangoTemplateEngine) Execute(templateHtml *string, model any) (*strin
 		return &empty, errors.New("templateHtml is nil")
 	}
 
-	t, err := pongo2.FromString(*templateHtml)
+	t, loader, err := te.fromString(*templateHtml)
+	if err != nil {
+		return &empty, err
+	}
+
+	helpers, err := te.getHelpers()
 	if err != nil {
 		return &empty, err
 	}
 
 	html, err := t.Execute(pongo2.Context{
 		"model": model,
-		"func":  templateFunctions,
+		"func":  getDjangoFuncs(helpers),
 	})
 
 	if err != nil {
-		return &empty, err
+		// includes with a variable name are loaded while executing
+		return &empty, cmp.Or(loader.err(), err)
 	}
 
 	return &html, nil
@@ -40,3 +59,185 @@ func (te *DjangoTemplateEngine) Test(templateHtml *string, model any) error {
 
 	return err
 }
+
+// fromString parses the template; includes and extends are resolved by the partials: {% include "address.html" %}
+func (te *DjangoTemplateEngine) fromString(templateHtml string) (*pongo2.Template, *djangoPartialsLoader, error) {
+	if te.partials == nil {
+		t, err := pongo2.FromString(templateHtml)
+		return t, nil, err
+	}
+
+	loader := &djangoPartialsLoader{partials: te.partials, includes: map[string][]string{}}
+
+	t, err := pongo2.NewSet("partials", loader).FromString(templateHtml)
+	if err != nil {
+		return nil, loader, cmp.Or(loader.err(), err)
+	}
+
+	return t, loader, nil
+}
+
+// djangoPartialsLoader loads the templates of includes and extends from the partials.
+// pongo2 parses included templates while parsing the including template, so recursive includes are rejected instead of overflowing the stack.
+type djangoPartialsLoader struct {
+	partials models.PartialsReader
+	// names of the partials included by a partial
+	includes map[string][]string
+	// recursive include found while resolving the names; pongo2 only reports that the template is not resolvable
+	recursionErr error
+}
+
+// Abs resolves the name relative to the partials directory, also for includes inside of partials
+func (l *djangoPartialsLoader) Abs(base, name string) string {
+	name = strings.TrimPrefix(path.Clean("/"+name), "/")
+
+	// the base is empty for the template itself
+	if base != "" && l.recursionErr == nil {
+		if l.isIncludedBy(base, name, map[string]bool{}) {
+			l.recursionErr = fmt.Errorf("recursive include of partial %s in %s", name, base)
+		}
+
+		l.includes[base] = append(l.includes[base], name)
+	}
+
+	return name
+}
+
+// isIncludedBy checks if the partial is the include or (indirectly) included by it
+func (l *djangoPartialsLoader) isIncludedBy(partial string, include string, visited map[string]bool) bool {
+	if partial == include {
+		return true
+	}
+
+	if visited[include] {
+		return false
+	}
+	visited[include] = true
+
+	for _, next := range l.includes[include] {
+		if l.isIncludedBy(partial, next, visited) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (l *djangoPartialsLoader) err() error {
+	if l == nil {
+		return nil
+	}
+
+	return l.recursionErr
+}
+
+func (l *djangoPartialsLoader) Get(name string) (io.Reader, error) {
+	if l.recursionErr != nil {
+		return nil, l.recursionErr
+	}
+
+	r, err := l.partials.GetPartial(name)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.NewReader(content), nil
+}
+
+func init() {
+	registerDjangoFilters()
+}
+
+func (te *DjangoTemplateEngine) Diagnose(templateHtml *string, model any) []models.TemplateDiagnostic {
+	err := te.Test(templateHtml, model)
+	if err == nil {
+		return nil
+	}
+
+	line, column := 0, 0
+
+	var pongoErr *pongo2.Error
+	if errors.As(err, &pongoErr) {
+		line, column = pongoErr.Line, pongoErr.Column
+	}
+
+	return []models.TemplateDiagnostic{newDiagnostic(templateHtml, models.TemplateDiagnosticSeverityError, line, column, err)}
+}
+
+// getDjangoFuncs adapts the template helpers to functions callable as func.name(a, b)
+func getDjangoFuncs(helpers []templateHelper) map[string]any {
+	funcs := map[string]any{}
+
+	for _, h := range helpers {
+		funcs[h.name] = func(args ...any) (*pongo2.Value, error) {
+			result, err := h.call(args)
+			if err != nil {
+				return nil, err
+			}
+
+			return toDjangoValue(result), nil
+		}
+	}
+
+	return funcs
+}
+
+// djangoBuiltinFilters are the helpers with a builtin pongo2 filter of the same name.
+// The builtin filter is kept (e.g. add also concatenates strings), the helper is only available as func.add(a, b).
+var djangoBuiltinFilters = []string{"add"}
+
+// registerDjangoFilters registers all helpers with one or two parameters as filter: {{ model.a|multiply:2 }}.
+// Filters are global in pongo2, so the formatting helpers (depending on the locale of the render) are not available as filter.
+func registerDjangoFilters() {
+	for _, h := range templateHelpers {
+		arity := h.arity()
+
+		if arity < 1 || arity > 2 || slices.Contains(djangoBuiltinFilters, h.name) || pongo2.FilterExists(h.name) {
+			continue
+		}
+
+		pongo2.RegisterFilter(h.name, func(in *pongo2.Value, param *pongo2.Value) (*pongo2.Value, *pongo2.Error) {
+			args := []any{in.Interface()}
+			if arity == 2 {
+				args = append(args, param.Interface())
+			}
+
+			result, err := h.call(args)
+			if err != nil {
+				return nil, &pongo2.Error{Sender: "filter:" + h.name, OrigError: err}
+			}
+
+			return toDjangoValue(result), nil
+		})
+	}
+}
+
+// djangoNumber prints a float like the other engines (pongo2 would print 1.500000), but stays a number for comparisons and builtin filters like add
+type djangoNumber float64
+
+func (n djangoNumber) String() string {
+	return formatHelperNumber(float64(n))
+}
+
+// toDjangoValue marks html as safe and keeps numbers numeric. Whole numbers are returned as int, so builtin filters like add print them without decimals as well.
+func toDjangoValue(v any) *pongo2.Value {
+	switch val := v.(type) {
+	case template.HTML:
+		return pongo2.AsSafeValue(string(val))
+	case template.JS:
+		return pongo2.AsSafeValue(string(val))
+	case float64:
+		if val == math.Trunc(val) && math.Abs(val) < 1<<53 {
+			return pongo2.AsValue(int(val))
+		}
+		return pongo2.AsValue(djangoNumber(val))
+	}
+
+	return pongo2.AsValue(v)
+}
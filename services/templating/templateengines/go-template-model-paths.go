@@ -0,0 +1,161 @@
+package templateengines
+
+import (
+	"errors"
+	"maps"
+	"text/template/parse"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
+)
+
+func (gte *GoTemplateEngine) ModelPaths(templateHtml *string) ([]models.ModelPath, error) {
+	if templateHtml == nil {
+		return nil, errors.New("templateHtml is nil")
+	}
+
+	t := parse.New("")
+	t.Mode = parse.SkipFuncCheck
+
+	trees := map[string]*parse.Tree{}
+	if _, err := t.Parse(*templateHtml, "", "", trees); err != nil {
+		return nil, err
+	}
+
+	collector := newModelPathCollector()
+
+	// defined templates are analysed with the model as dot
+	for _, tree := range trees {
+		collectGoTemplatePaths(collector, tree.Root, newGoModelScope())
+	}
+
+	return collector.paths(), nil
+}
+
+// goModelScope tracks the model paths of dot and the variables; unknown values (e.g. results of functions) are not tracked
+type goModelScope struct {
+	dot      string
+	dotKnown bool
+	vars     map[string]string
+}
+
+func newGoModelScope() *goModelScope {
+	return &goModelScope{dotKnown: true, vars: map[string]string{"$": ""}}
+}
+
+// child creates the scope of a block; variables declared in a block are not visible outside
+func (s *goModelScope) child(dot string, dotKnown bool) *goModelScope {
+	return &goModelScope{dot: dot, dotKnown: dotKnown, vars: maps.Clone(s.vars)}
+}
+
+func (s *goModelScope) declare(decl []*parse.VariableNode, path string, known bool) {
+	if len(decl) == 0 {
+		return
+	}
+
+	// range with two variables declares index and element
+	name := decl[len(decl)-1].Ident[0]
+
+	if known {
+		s.vars[name] = path
+	} else {
+		delete(s.vars, name)
+	}
+}
+
+func collectGoTemplatePaths(c *modelPathCollector, node parse.Node, scope *goModelScope) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			collectGoTemplatePaths(c, child, scope)
+		}
+	case *parse.ActionNode:
+		path, known := collectGoPipePaths(c, n.Pipe, scope, false)
+		scope.declare(n.Pipe.Decl, path, known)
+	case *parse.TemplateNode:
+		collectGoPipePaths(c, n.Pipe, scope, false)
+	case *parse.IfNode:
+		path, known := collectGoPipePaths(c, n.Pipe, scope, true)
+		inner := scope.child(scope.dot, scope.dotKnown)
+		inner.declare(n.Pipe.Decl, path, known)
+		collectGoTemplatePaths(c, n.List, inner)
+		collectGoTemplatePaths(c, n.ElseList, scope.child(scope.dot, scope.dotKnown))
+	case *parse.WithNode:
+		path, known := collectGoPipePaths(c, n.Pipe, scope, true)
+		inner := scope.child(path, known)
+		inner.declare(n.Pipe.Decl, path, known)
+		collectGoTemplatePaths(c, n.List, inner)
+		collectGoTemplatePaths(c, n.ElseList, scope.child(scope.dot, scope.dotKnown))
+	case *parse.RangeNode:
+		path, known := collectGoPipePaths(c, n.Pipe, scope, false)
+		if known {
+			path += "[]"
+			c.add(path, false)
+		}
+		inner := scope.child(path, known)
+		inner.declare(n.Pipe.Decl, path, known)
+		collectGoTemplatePaths(c, n.List, inner)
+		collectGoTemplatePaths(c, n.ElseList, scope.child(scope.dot, scope.dotKnown))
+	}
+}
+
+// collectGoPipePaths collects the paths of the pipeline and returns the path of its value, if the pipeline is a single model value
+func collectGoPipePaths(c *modelPathCollector, pipe *parse.PipeNode, scope *goModelScope, condition bool) (string, bool) {
+	if pipe == nil {
+		return "", false
+	}
+
+	single := len(pipe.Cmds) == 1 && len(pipe.Cmds[0].Args) == 1
+
+	path, known := "", false
+
+	for _, cmd := range pipe.Cmds {
+		for _, arg := range cmd.Args {
+			argPath, argKnown := collectGoArgPath(c, arg, scope, condition && single)
+			if single {
+				path, known = argPath, argKnown
+			}
+		}
+	}
+
+	return path, known
+}
+
+func collectGoArgPath(c *modelPathCollector, node parse.Node, scope *goModelScope, optional bool) (string, bool) {
+	var path string
+
+	switch n := node.(type) {
+	case *parse.DotNode:
+		if !scope.dotKnown {
+			return "", false
+		}
+		path = scope.dot
+	case *parse.FieldNode:
+		if !scope.dotKnown {
+			return "", false
+		}
+		path = joinModelPath(scope.dot, n.Ident...)
+	case *parse.VariableNode:
+		base, found := scope.vars[n.Ident[0]]
+		if !found {
+			return "", false
+		}
+		path = joinModelPath(base, n.Ident[1:]...)
+	case *parse.ChainNode:
+		base, known := collectGoArgPath(c, n.Node, scope, false)
+		if !known {
+			return "", false
+		}
+		path = joinModelPath(base, n.Field...)
+	case *parse.PipeNode:
+		return collectGoPipePaths(c, n, scope, optional)
+	default:
+		return "", false
+	}
+
+	c.add(path, optional)
+
+	return path, true
+}
@@ -2,13 +2,23 @@ package templateengines
 
 import (
 	"bytes"
+	"encoding/xml"
 	"errors"
+	"fmt"
 	"html/template"
+	"regexp"
+	"strconv"
+	texttemplate "text/template"
+	"text/template/parse"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
 )
 
 const GoTemplateEngineKey = "golang"
 
 type GoTemplateEngine struct {
+	localizedHelpers
+	templatePartials
 }
 
 func (gte *GoTemplateEngine) Execute(templateHtml *string, model any) (*string, error) {
@@ -18,13 +28,11 @@ func (gte *GoTemplateEngine) Execute(templateHtml *string, model any) (*string,
 		return &empty, errors.New("templateHtml is nil")
 	}
 
-	t, err := template.New("").
-		Funcs(templateFunctions).
-		Parse(*templateHtml)
-
+	t, err := gte.parse(*templateHtml)
 	if err != nil {
 		return &empty, err
 	}
+
 	var buff bytes.Buffer
 
 	if err := t.Execute(&buff, model); err != nil {
@@ -37,7 +45,11 @@ func (gte *GoTemplateEngine) Execute(templateHtml *string, model any) (*string,
 }
 
 func (gte *GoTemplateEngine) Test(templateHtml *string, model any) error {
-	t, err := template.New("").Option("missingkey=error").Parse(*templateHtml)
+	if templateHtml == nil {
+		return errors.New("templateHtml is nil")
+	}
+
+	t, err := gte.parse(*templateHtml, "missingkey=error")
 	if err != nil {
 		return err
 	}
@@ -50,3 +62,174 @@ func (gte *GoTemplateEngine) Test(templateHtml *string, model any) error {
 
 	return nil
 }
+
+// ExecuteText executes the template with text/template, so the xml of attachments (e.g. the declaration and comments) is not escaped like html.
+// The printed values are escaped as xml instead.
+func (gte *GoTemplateEngine) ExecuteText(templateText *string, model any) (*string, error) {
+	empty := ""
+
+	if templateText == nil {
+		return &empty, errors.New("templateText is nil")
+	}
+
+	helpers, err := gte.getHelpers()
+	if err != nil {
+		return &empty, err
+	}
+
+	partials, err := gte.readPartials()
+	if err != nil {
+		return &empty, err
+	}
+
+	funcs := texttemplate.FuncMap(getGoTemplateFuncs(helpers))
+	funcs[xmlEscapeFunc] = xmlEscape
+
+	t := texttemplate.New("").Funcs(funcs)
+
+	for name, partial := range partials {
+		if _, err := t.New(name).Parse(partial); err != nil {
+			return &empty, err
+		}
+	}
+
+	if _, err := t.Parse(*templateText); err != nil {
+		return &empty, err
+	}
+
+	for _, tmpl := range t.Templates() {
+		if tmpl.Tree != nil {
+			addXmlEscaping(tmpl.Tree, tmpl.Tree.Root)
+		}
+	}
+
+	var buff bytes.Buffer
+
+	if err := t.Execute(&buff, model); err != nil {
+		return &empty, err
+	}
+
+	text := buff.String()
+
+	return &text, nil
+}
+
+const xmlEscapeFunc = "pdfTurtleXmlEscape"
+
+// addXmlEscaping appends the xml escaping to all printing actions like html/template does for html
+func addXmlEscaping(tree *parse.Tree, node parse.Node) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			addXmlEscaping(tree, child)
+		}
+	case *parse.ActionNode:
+		// actions with variable declarations print nothing: {{$a := .b}}
+		if len(n.Pipe.Decl) == 0 {
+			n.Pipe.Cmds = append(n.Pipe.Cmds, &parse.CommandNode{
+				NodeType: parse.NodeCommand,
+				Pos:      n.Pos,
+				Args:     []parse.Node{parse.NewIdentifier(xmlEscapeFunc).SetTree(tree).SetPos(n.Pos)},
+			})
+		}
+	case *parse.IfNode:
+		addXmlEscaping(tree, n.List)
+		addXmlEscaping(tree, n.ElseList)
+	case *parse.RangeNode:
+		addXmlEscaping(tree, n.List)
+		addXmlEscaping(tree, n.ElseList)
+	case *parse.WithNode:
+		addXmlEscaping(tree, n.List)
+		addXmlEscaping(tree, n.ElseList)
+	}
+}
+
+// xmlEscape escapes the printed value; missing values are printed empty like in html templates (text/template would print <no value>)
+func xmlEscape(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case template.HTML:
+		// markup of helpers
+		return string(val)
+	}
+
+	buff := new(bytes.Buffer)
+	xml.EscapeText(buff, []byte(fmt.Sprint(v)))
+
+	return buff.String()
+}
+
+// parse parses the template with the helpers and the partials as named templates: {{template "address" .}}
+func (gte *GoTemplateEngine) parse(templateHtml string, options ...string) (*template.Template, error) {
+	helpers, err := gte.getHelpers()
+	if err != nil {
+		return nil, err
+	}
+
+	partials, err := gte.readPartials()
+	if err != nil {
+		return nil, err
+	}
+
+	t := template.New("").
+		Option(options...).
+		Funcs(getGoTemplateFuncs(helpers))
+
+	for name, partial := range partials {
+		if _, err := t.New(name).Parse(partial); err != nil {
+			return nil, err
+		}
+	}
+
+	return t.Parse(templateHtml)
+}
+
+var (
+	// positions are only read from errors of the template itself (partials are named), e.g. template: :4:19: executing "" at <.company.name>: ...
+	goTemplateErrorPositionRegex = regexp.MustCompile(`^template: :(\d+)(?::(\d+))?:`)
+	goTemplateMissingValueRegex  = regexp.MustCompile(`at <\.([\w.]+)>: (?:map has no entry for key|can't evaluate field|nil pointer evaluating)`)
+)
+
+func (gte *GoTemplateEngine) Diagnose(templateHtml *string, model any) []models.TemplateDiagnostic {
+	err := gte.Test(templateHtml, model)
+	if err == nil {
+		return nil
+	}
+
+	line, column := 0, 0
+	if m := goTemplateErrorPositionRegex.FindStringSubmatch(err.Error()); m != nil {
+		line, _ = strconv.Atoi(m[1])
+		if m[2] != "" {
+			// go counts the columns from 0
+			column, _ = strconv.Atoi(m[2])
+			column++
+		}
+	}
+
+	d := newDiagnostic(templateHtml, models.TemplateDiagnosticSeverityError, line, column, err)
+
+	// missing values are rendered empty by the render endpoints
+	if m := goTemplateMissingValueRegex.FindStringSubmatch(err.Error()); m != nil {
+		d.Severity = models.TemplateDiagnosticSeverityWarning
+		d.ModelPath = m[1]
+	}
+
+	return []models.TemplateDiagnostic{d}
+}
+
+// getGoTemplateFuncs adapts the template helpers to variadic functions. The count of the arguments is checked on execution.
+func getGoTemplateFuncs(helpers []templateHelper) template.FuncMap {
+	funcs := template.FuncMap{}
+
+	for _, h := range helpers {
+		funcs[h.name] = func(args ...any) (any, error) {
+			return h.call(args)
+		}
+	}
+
+	return funcs
+}
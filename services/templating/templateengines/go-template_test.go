@@ -90,3 +90,19 @@ func TestGoTemplateTestUnknownProperty(t *testing.T) {
 		t.Fatal("should fail")
 	}
 }
+
+func TestGoTemplateExecuteText(t *testing.T) {
+	templateStr := `<?xml version="1.0"?><!-- invoice --><name>{{.name}}</name><missing>{{.missing}}</missing>{{$n := .name}}{{if $n}}<ok/>{{end}}`
+
+	engine := &GoTemplateEngine{}
+
+	xml, err := engine.ExecuteText(&templateStr, map[string]any{"name": "Müller & Söhne <GmbH>"})
+	if err != nil {
+		t.Fatalf("cant execute text template: %v", err)
+	}
+
+	expected := `<?xml version="1.0"?><!-- invoice --><name>Müller &amp; Söhne &lt;GmbH&gt;</name><missing></missing><ok/>`
+	if *xml != expected {
+		t.Fatalf("xml should be '%s' (curr: '%s')", expected, *xml)
+	}
+}
@@ -0,0 +1,223 @@
+package templateengines
+
+import (
+	"errors"
+	"maps"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
+
+	"github.com/aymerick/raymond/ast"
+	"github.com/aymerick/raymond/parser"
+)
+
+// built-in helpers of raymond
+var handlebarsBuiltinHelpers = []string{"if", "unless", "with", "each", "log", "lookup", "equal"}
+
+func (te *HandlebarsTemplateEngine) ModelPaths(templateHtml *string) ([]models.ModelPath, error) {
+	if templateHtml == nil {
+		return nil, errors.New("templateHtml is nil")
+	}
+
+	program, err := parser.Parse(*templateHtml)
+	if err != nil {
+		return nil, err
+	}
+
+	helpers, err := te.getHelpers()
+	if err != nil {
+		return nil, err
+	}
+
+	w := &handlebarsModelPathWalker{
+		collector: newModelPathCollector(),
+		helpers:   map[string]bool{},
+	}
+
+	for _, name := range handlebarsBuiltinHelpers {
+		w.helpers[name] = true
+	}
+	for _, h := range helpers {
+		w.helpers[h.name] = true
+	}
+
+	w.program(program, &handlebarsModelScope{contexts: []handlebarsContext{{known: true}}})
+
+	return w.collector.paths(), nil
+}
+
+type handlebarsContext struct {
+	path  string
+	known bool
+}
+
+// handlebarsModelScope tracks the context stack (root first) and the block params ({{#each items as |item|}})
+type handlebarsModelScope struct {
+	contexts    []handlebarsContext
+	blockParams map[string]handlebarsContext
+}
+
+func (s *handlebarsModelScope) child(context handlebarsContext, blockParams []string) *handlebarsModelScope {
+	inner := &handlebarsModelScope{
+		contexts:    append(s.contexts[:len(s.contexts):len(s.contexts)], context),
+		blockParams: maps.Clone(s.blockParams),
+	}
+
+	// the first block param is the context, the second one the index or key
+	if len(blockParams) > 0 {
+		if inner.blockParams == nil {
+			inner.blockParams = map[string]handlebarsContext{}
+		}
+		inner.blockParams[blockParams[0]] = context
+	}
+
+	return inner
+}
+
+type handlebarsModelPathWalker struct {
+	collector *modelPathCollector
+	helpers   map[string]bool
+}
+
+func (w *handlebarsModelPathWalker) program(program *ast.Program, scope *handlebarsModelScope) {
+	if program == nil {
+		return
+	}
+
+	for _, node := range program.Body {
+		switch n := node.(type) {
+		case *ast.MustacheStatement:
+			w.expression(n.Expression, scope, false)
+		case *ast.BlockStatement:
+			w.block(n, scope)
+		case *ast.PartialStatement:
+			for _, param := range n.Params {
+				w.value(param, scope, false)
+			}
+			w.hash(n.Hash, scope)
+		}
+	}
+}
+
+func (w *handlebarsModelPathWalker) block(block *ast.BlockStatement, scope *handlebarsModelScope) {
+	var blockParams []string
+	if block.Program != nil {
+		blockParams = block.Program.BlockParams
+	}
+
+	context := scope.contexts[len(scope.contexts)-1]
+
+	switch name := w.helperName(block.Expression); name {
+	case "each":
+		context = w.firstParam(block.Expression, scope, false)
+		if context.known {
+			context.path += "[]"
+			w.collector.add(context.path, false)
+		}
+	case "with":
+		context = w.firstParam(block.Expression, scope, true)
+	case "if", "unless":
+		w.firstParam(block.Expression, scope, true)
+	case "":
+		// sections without helper ({{#company}}) are rendered with the value as context, if it is set
+		context = w.expression(block.Expression, scope, true)
+	default:
+		w.expression(block.Expression, scope, false)
+	}
+
+	w.program(block.Program, scope.child(context, blockParams))
+	w.program(block.Inverse, scope)
+}
+
+func (w *handlebarsModelPathWalker) helperName(expr *ast.Expression) string {
+	name := expr.HelperName()
+
+	if len(expr.Params) > 0 || expr.Hash != nil || w.helpers[name] {
+		return name
+	}
+
+	return ""
+}
+
+func (w *handlebarsModelPathWalker) firstParam(expr *ast.Expression, scope *handlebarsModelScope, optional bool) handlebarsContext {
+	context := handlebarsContext{}
+
+	for i, param := range expr.Params {
+		c := w.value(param, scope, optional && i == 0)
+		if i == 0 {
+			context = c
+		}
+	}
+	w.hash(expr.Hash, scope)
+
+	return context
+}
+
+// expression collects the paths of a helper call or a model value and returns the context of the value
+func (w *handlebarsModelPathWalker) expression(expr *ast.Expression, scope *handlebarsModelScope, optional bool) handlebarsContext {
+	if w.helperName(expr) != "" {
+		for _, param := range expr.Params {
+			w.value(param, scope, false)
+		}
+		w.hash(expr.Hash, scope)
+
+		return handlebarsContext{}
+	}
+
+	return w.value(expr.Path, scope, optional)
+}
+
+func (w *handlebarsModelPathWalker) hash(hash *ast.Hash, scope *handlebarsModelScope) {
+	if hash == nil {
+		return
+	}
+
+	for _, pair := range hash.Pairs {
+		w.value(pair.Val, scope, false)
+	}
+}
+
+func (w *handlebarsModelPathWalker) value(node ast.Node, scope *handlebarsModelScope, optional bool) handlebarsContext {
+	switch n := node.(type) {
+	case *ast.SubExpression:
+		return w.expression(n.Expression, scope, false)
+	case *ast.Expression:
+		return w.expression(n, scope, optional)
+	case *ast.PathExpression:
+		context := resolveHandlebarsPath(n, scope)
+		if context.known {
+			w.collector.add(context.path, optional)
+		}
+		return context
+	}
+
+	return handlebarsContext{}
+}
+
+func resolveHandlebarsPath(path *ast.PathExpression, scope *handlebarsModelScope) handlebarsContext {
+	parts := path.Parts
+	base, isBlockParam := handlebarsContext{}, false
+
+	if path.Depth == 0 && !path.Scoped && len(parts) > 0 {
+		base, isBlockParam = scope.blockParams[parts[0]]
+	}
+
+	switch {
+	case path.Data:
+		// only @root references the model, other data variables (@index, @key, ...) are provided by the helpers
+		if !path.IsDataRoot() {
+			return handlebarsContext{}
+		}
+		base = scope.contexts[0]
+		parts = parts[1:]
+	case isBlockParam:
+		parts = parts[1:]
+	default:
+		base = scope.contexts[max(len(scope.contexts)-1-path.Depth, 0)]
+	}
+
+	if !base.known {
+		return handlebarsContext{}
+	}
+
+	return handlebarsContext{path: joinModelPath(base.path, parts...), known: true}
+}
@@ -2,6 +2,13 @@ package templateengines
 
 import (
 	"errors"
+	"fmt"
+	"html/template"
+	"reflect"
+	"regexp"
+	"strconv"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
 
 	"github.com/aymerick/raymond"
 )
@@ -9,6 +16,8 @@ import (
 const HandlebarsTemplateEngineKey = "handlebars"
 
 type HandlebarsTemplateEngine struct {
+	localizedHelpers
+	templatePartials
 }
 
 func (te *HandlebarsTemplateEngine) Execute(templateHtml *string, model any) (*string, error) {
@@ -23,7 +32,21 @@ func (te *HandlebarsTemplateEngine) Execute(templateHtml *string, model any) (*s
 		return &empty, err
 	}
 
-	t.RegisterHelpers(templateFunctions)
+	helpers, err := te.getHelpers()
+	if err != nil {
+		return &empty, err
+	}
+
+	t.RegisterHelpers(getHandlebarsHelpers(helpers))
+
+	partials, err := te.readPartials()
+	if err != nil {
+		return &empty, err
+	}
+
+	// partials are registered by their name without extension: {{> address}}
+	t.RegisterPartials(wrapHandlebarsPartials(partials))
+	t.RegisterHelper(handlebarsPartialHelper, getHandlebarsPartialHelper())
 
 	html, err := t.Exec(model)
 	if err != nil {
@@ -38,3 +61,98 @@ func (te *HandlebarsTemplateEngine) Test(templateHtml *string, model any) error
 
 	return err
 }
+
+// raymond only reports the line of parse errors
+var handlebarsErrorLineRegex = regexp.MustCompile(`^Parse error on line (\d+):`)
+
+func (te *HandlebarsTemplateEngine) Diagnose(templateHtml *string, model any) []models.TemplateDiagnostic {
+	err := te.Test(templateHtml, model)
+	if err == nil {
+		return nil
+	}
+
+	line := 0
+	if m := handlebarsErrorLineRegex.FindStringSubmatch(err.Error()); m != nil {
+		line, _ = strconv.Atoi(m[1])
+	}
+
+	return []models.TemplateDiagnostic{newDiagnostic(templateHtml, models.TemplateDiagnosticSeverityError, line, 0, err)}
+}
+
+// handlebarsPartialHelper is the block helper wrapping every partial to count the depth of nested partials
+const handlebarsPartialHelper = "pdfTurtlePartial"
+
+// wrapHandlebarsPartials wraps the partials with the partial helper.
+// The opening tag is on its own line, so it is standalone and does not change the whitespace of the partial.
+func wrapHandlebarsPartials(partials map[string]string) map[string]string {
+	wrapped := make(map[string]string, len(partials))
+
+	for name, partial := range partials {
+		wrapped[name] = "{{#" + handlebarsPartialHelper + "}}\n" + partial + "{{/" + handlebarsPartialHelper + "}}"
+	}
+
+	return wrapped
+}
+
+// getHandlebarsPartialHelper fails the execution if the partials are nested deeper than maxPartialDepth (e.g. a partial including itself)
+func getHandlebarsPartialHelper() func(options *raymond.Options) raymond.SafeString {
+	depth := 0
+
+	return func(options *raymond.Options) raymond.SafeString {
+		if depth >= maxPartialDepth {
+			// raymond returns errors raised by helpers as execution error
+			panic(fmt.Errorf("partials are nested deeper than %d levels (recursive partial?)", maxPartialDepth))
+		}
+
+		depth++
+		defer func() { depth-- }()
+
+		return raymond.SafeString(options.Fn())
+	}
+}
+
+// getHandlebarsHelpers adapts the template helpers to functions with a fixed count of parameters, because raymond checks the arity.
+// Boolean results are returned as they are, so they can be used as block condition: {{#if (strContains a "b")}}...{{/if}}
+func getHandlebarsHelpers(helpers []templateHelper) map[string]any {
+	anyType := reflect.TypeOf((*any)(nil)).Elem()
+	handlebarsHelpers := map[string]any{}
+
+	for _, h := range helpers {
+		in := make([]reflect.Type, h.arity())
+		for i := range in {
+			in[i] = anyType
+		}
+
+		fnType := reflect.FuncOf(in, []reflect.Type{anyType}, false)
+
+		handlebarsHelpers[h.name] = reflect.MakeFunc(fnType, func(args []reflect.Value) []reflect.Value {
+			params := make([]any, len(args))
+			for i, arg := range args {
+				params[i] = arg.Interface()
+			}
+
+			result, err := h.call(params)
+			if err != nil {
+				// raymond returns errors raised by helpers as execution error
+				panic(err)
+			}
+
+			result = toHandlebarsValue(result)
+
+			return []reflect.Value{reflect.ValueOf(&result).Elem()}
+		}).Interface()
+	}
+
+	return handlebarsHelpers
+}
+
+func toHandlebarsValue(v any) any {
+	switch val := v.(type) {
+	case template.HTML:
+		return raymond.SafeString(val)
+	case template.JS:
+		return raymond.SafeString(val)
+	}
+
+	return v
+}
@@ -0,0 +1,158 @@
+package templateengines
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
+)
+
+var (
+	liquidTagRegex        = regexp.MustCompile(`(?s)\{\{-?(.*?)-?\}\}|\{%-?(.*?)-?%\}`)
+	liquidIgnoredRegex    = regexp.MustCompile(`(?s)\{%-?\s*(comment|raw)\s*-?%\}.*?\{%-?\s*end(?:comment|raw)\s*-?%\}`)
+	liquidIndexRegex      = regexp.MustCompile(`\[(\d+)\]`)
+	liquidVariableRegex   = regexp.MustCompile(`(\|\s*)?\b([A-Za-z_]\w*(?:\.\w+)*)(\s*:)?`)
+	liquidSingleRegex     = regexp.MustCompile(`^(?:not\s+)?([A-Za-z_]\w*(?:\.\w+)*)$`)
+	liquidForRegex        = regexp.MustCompile(`^(?:for|tablerow)\s+(\w+)\s+in\s+(\S+)(.*)$`)
+	liquidAssignmentRegex = regexp.MustCompile(`^(\w+)\s*=\s*(.*)$`)
+)
+
+// keywords and objects of liquid, which are not part of the model
+var liquidKeywords = []string{"and", "or", "not", "contains", "in", "reversed", "with", "as", "true", "false", "nil", "null", "empty", "blank", "forloop", "tablerowloop"}
+
+// ModelPaths scans the tags of the template, the variables not assigned by the template are fields of the model.
+// The template is parsed first to report syntax errors.
+func (te *LiquidTemplateEngine) ModelPaths(templateHtml *string) ([]models.ModelPath, error) {
+	if templateHtml == nil {
+		return nil, errors.New("templateHtml is nil")
+	}
+
+	engine, err := te.newEngine(false)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, sourceErr := engine.ParseTemplateLocation([]byte(*templateHtml), "", 1); sourceErr != nil {
+		return nil, sourceErr
+	}
+
+	s := &liquidModelPathScanner{
+		collector: newModelPathCollector(),
+		scopes:    []map[string]string{{}},
+	}
+
+	templateStr := liquidIgnoredRegex.ReplaceAllString(*templateHtml, "")
+
+	for _, m := range liquidTagRegex.FindAllStringSubmatch(templateStr, -1) {
+		if object := strings.TrimSpace(m[1]); object != "" {
+			s.expression(object, false)
+		} else if tag := strings.TrimSpace(m[2]); tag != "" {
+			s.tag(tag)
+		}
+	}
+
+	return s.collector.paths(), nil
+}
+
+// liquidModelPathScanner tracks the variables of the template; the first scope contains the global variables (assign, capture).
+// Variables with an empty path are not part of the model.
+type liquidModelPathScanner struct {
+	collector *modelPathCollector
+	scopes    []map[string]string
+}
+
+func (s *liquidModelPathScanner) tag(tag string) {
+	name, args, _ := strings.Cut(tag, " ")
+
+	switch name {
+	case "for", "tablerow":
+		scope := map[string]string{}
+		if m := liquidForRegex.FindStringSubmatch(tag); m != nil {
+			scope[m[1]] = ""
+			if path, known := s.expression(m[2], false); known {
+				scope[m[1]] = path + "[]"
+				s.collector.add(path+"[]", false)
+			}
+			s.expression(m[3], false)
+		}
+		s.scopes = append(s.scopes, scope)
+	case "endfor", "endtablerow":
+		if len(s.scopes) > 1 {
+			s.scopes = s.scopes[:len(s.scopes)-1]
+		}
+	case "assign":
+		if m := liquidAssignmentRegex.FindStringSubmatch(strings.TrimSpace(args)); m != nil {
+			path, _ := s.expression(m[2], false)
+			s.scopes[0][m[1]] = path
+		}
+	case "capture", "increment", "decrement":
+		s.scopes[0][strings.TrimSpace(args)] = ""
+	case "if", "elsif", "unless":
+		s.expression(args, true)
+	case "#":
+		// inline comment
+	default:
+		s.expression(args, false)
+	}
+}
+
+// expression collects the paths of the expression and returns the path of its value, if the expression is a single model value
+func (s *liquidModelPathScanner) expression(expr string, condition bool) (string, bool) {
+	expr = strings.TrimSpace(djangoStringRegex.ReplaceAllString(expr, `""`))
+	expr = liquidIndexRegex.ReplaceAllString(expr, ".$1")
+
+	single := liquidSingleRegex.FindStringSubmatch(expr)
+
+	for _, m := range liquidVariableRegex.FindAllStringSubmatch(expr, -1) {
+		// filter names and named arguments of filters are not part of the model
+		if m[1] != "" || m[3] != "" {
+			continue
+		}
+
+		if path, known := s.resolve(m[2]); known {
+			s.collector.add(path, condition && single != nil)
+		}
+	}
+
+	if single == nil {
+		return "", false
+	}
+
+	return s.resolve(single[1])
+}
+
+func (s *liquidModelPathScanner) resolve(variable string) (string, bool) {
+	parts := strings.Split(variable, ".")
+
+	if slices.Contains(liquidKeywords, parts[0]) {
+		return "", false
+	}
+
+	path := parts[0]
+
+	for i := len(s.scopes) - 1; i >= 0; i-- {
+		if base, found := s.scopes[i][parts[0]]; found {
+			path = base
+			break
+		}
+	}
+
+	if path == "" {
+		return "", false
+	}
+
+	for i, part := range parts[1:] {
+		switch {
+		case part == "first" || part == "last":
+			part = "0"
+		case part == "size" && i == len(parts)-2:
+			return path, true
+		}
+
+		path = joinModelPath(path, part)
+	}
+
+	return path, true
+}
@@ -0,0 +1,208 @@
+package templateengines
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"maps"
+	"path"
+	"regexp"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
+
+	"github.com/osteele/liquid"
+	"github.com/osteele/liquid/render"
+)
+
+const LiquidTemplateEngineKey = "liquid"
+
+type LiquidTemplateEngine struct {
+	localizedHelpers
+	templatePartials
+}
+
+func (te *LiquidTemplateEngine) Execute(templateHtml *string, model any) (*string, error) {
+	empty := ""
+
+	html, err := te.render(templateHtml, model, false)
+	if err != nil {
+		return &empty, err
+	}
+
+	return &html, nil
+}
+
+func (te *LiquidTemplateEngine) Test(templateHtml *string, model any) error {
+	_, err := te.render(templateHtml, model, true)
+
+	return err
+}
+
+// e.g. Liquid error (line 4): undefined variable in {{ customer.name }}
+var liquidUndefinedVariableRegex = regexp.MustCompile(`undefined variable in \{\{-?\s*([\w.]+)\s*-?\}\}`)
+
+func (te *LiquidTemplateEngine) Diagnose(templateHtml *string, model any) []models.TemplateDiagnostic {
+	err := te.Test(templateHtml, model)
+	if err == nil {
+		return nil
+	}
+
+	// lines of errors in partials are not reported, the excerpt is read from the template
+	line := 0
+	var sourceErr liquid.SourceError
+	if errors.As(err, &sourceErr) && sourceErr.Path() == "" {
+		line = sourceErr.LineNumber()
+	}
+
+	d := newDiagnostic(templateHtml, models.TemplateDiagnosticSeverityError, line, 0, err)
+
+	// undefined variables are rendered empty by the render endpoints
+	if m := liquidUndefinedVariableRegex.FindStringSubmatch(err.Error()); m != nil {
+		d.Severity = models.TemplateDiagnosticSeverityWarning
+		d.ModelPath = m[1]
+	}
+
+	return []models.TemplateDiagnostic{d}
+}
+
+// render executes the template with the fields of the model as variables: {{ customer.name }}.
+// Undefined variables are errors in strict mode.
+func (te *LiquidTemplateEngine) render(templateHtml *string, model any, strict bool) (string, error) {
+	if templateHtml == nil {
+		return "", errors.New("templateHtml is nil")
+	}
+
+	engine, err := te.newEngine(strict)
+	if err != nil {
+		return "", err
+	}
+
+	t, sourceErr := engine.ParseTemplateLocation([]byte(*templateHtml), "", 1)
+	if sourceErr != nil {
+		return "", sourceErr
+	}
+
+	html, sourceErr := t.RenderString(getLiquidBindings(model))
+	if sourceErr != nil {
+		return "", sourceErr
+	}
+
+	return html, nil
+}
+
+func (te *LiquidTemplateEngine) newEngine(strict bool) (*liquid.Engine, error) {
+	helpers, err := te.getHelpers()
+	if err != nil {
+		return nil, err
+	}
+
+	partials, err := te.readPartials()
+	if err != nil {
+		return nil, err
+	}
+
+	engine := liquid.NewEngine()
+
+	if strict {
+		engine.StrictVariables()
+	}
+
+	for name, filter := range getLiquidFilters(helpers) {
+		engine.RegisterFilter(name, filter)
+	}
+
+	// nesting of the partials; the engine is created for every render
+	depth := 0
+
+	// replaces the include tag of liquid, which reads files of the file system
+	engine.RegisterTag("include", func(ctx render.Context) (string, error) {
+		if depth >= maxPartialDepth {
+			return "", fmt.Errorf("partials are nested deeper than %d levels (recursive partial?)", maxPartialDepth)
+		}
+
+		depth++
+		defer func() { depth-- }()
+
+		return renderLiquidPartial(engine, partials, ctx)
+	})
+
+	return engine, nil
+}
+
+// renderLiquidPartial renders the partial with the variables of the including template: {% include "address" %}.
+// The name can be given with or without extension.
+func renderLiquidPartial(engine *liquid.Engine, partials map[string]string, ctx render.Context) (string, error) {
+	value, err := ctx.EvaluateString(ctx.TagArgs())
+	if err != nil {
+		return "", err
+	}
+
+	name, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("include requires the name of a partial; got %v", value)
+	}
+
+	partial, ok := partials[name]
+	if !ok {
+		if partial, ok = partials[name[:len(name)-len(path.Ext(name))]]; !ok {
+			return "", fmt.Errorf("partial %s not found", name)
+		}
+	}
+
+	t, sourceErr := engine.ParseTemplateLocation([]byte(partial), name, 1)
+	if sourceErr != nil {
+		return "", sourceErr
+	}
+
+	html, sourceErr := t.RenderString(ctx.Bindings())
+	if sourceErr != nil {
+		return "", sourceErr
+	}
+
+	return html, nil
+}
+
+// liquidModelKey is the variable of a model which has no fields (e.g. an array or a scalar)
+const liquidModelKey = "model"
+
+// getLiquidBindings provides the fields of the model as variables; assign tags must not change the model.
+// Any other model is bound as a whole under liquidModelKey.
+func getLiquidBindings(model any) liquid.Bindings {
+	if model == nil {
+		return liquid.Bindings{}
+	}
+
+	if m, ok := model.(map[string]any); ok {
+		return maps.Clone(m)
+	}
+
+	return liquid.Bindings{liquidModelKey: model}
+}
+
+// getLiquidFilters adapts the template helpers to variadic filters. The first argument is the input of the filter: {{ a | multiply: b }}.
+// The count of the arguments is checked on execution.
+func getLiquidFilters(helpers []templateHelper) map[string]any {
+	filters := map[string]any{}
+
+	for _, h := range helpers {
+		filters[h.name] = func(input any, args ...any) (any, error) {
+			result, err := h.call(append([]any{input}, args...))
+
+			return toLiquidValue(result), err
+		}
+	}
+
+	return filters
+}
+
+// toLiquidValue unwraps html, liquid does not escape the output
+func toLiquidValue(v any) any {
+	switch val := v.(type) {
+	case template.HTML:
+		return string(val)
+	case template.JS:
+		return string(val)
+	}
+
+	return v
+}
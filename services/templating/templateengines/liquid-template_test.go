@@ -0,0 +1,136 @@
+package templateengines
+
+import "testing"
+
+const liquidTemplate = `
+<html>
+<body>
+	<h1>Profile of {{ name }}</h1>
+	<p>Working at {{ company.name }}</p>
+	<p>Locations:</p>
+	<ul>
+		{% for location in company.locations %}<li>{{ location }}</li>{% endfor %}
+	</ul>
+</body>
+</html>
+`
+
+const liquidTemplateUnknownProperty = `
+<html>
+<body>
+	<h1>Profile of {{ lastname }}</h1>
+	<p>Working at {{ company.name }}</p>
+</body>
+</html>
+`
+
+const liquidTemplateInvalid = `
+<html>
+<body>
+	<h1>Profile of {{ name }}</h1>
+	{% for location in company.locations %}<li>{{ location }}</li>
+</body>
+</html>
+`
+
+func TestLiquidTemplate(t *testing.T) {
+	templateStr := liquidTemplate
+
+	engine, _ := GetTemplateEngineByKey(LiquidTemplateEngineKey)
+
+	htmlBody, err := engine.Execute(&templateStr, getModel())
+	if err != nil {
+		t.Fatalf("cant generate template %v", err)
+	}
+
+	if *htmlBody != resultHtml {
+		t.Fatalf("html not equal")
+	}
+}
+
+func TestLiquidTemplateTestValid(t *testing.T) {
+	templateStr := liquidTemplate
+
+	engine, _ := GetTemplateEngineByKey(LiquidTemplateEngineKey)
+
+	err := engine.Test(&templateStr, getModel())
+	if err != nil {
+		t.Fatalf("cant generate template %v", err)
+	}
+}
+
+func TestLiquidTemplateTestInvalid(t *testing.T) {
+	templateStr := liquidTemplateInvalid
+
+	engine, _ := GetTemplateEngineByKey(LiquidTemplateEngineKey)
+
+	err := engine.Test(&templateStr, getModel())
+	if err == nil {
+		t.Fatalf("should fail")
+	}
+}
+
+func TestLiquidTemplateTestUnknownProperty(t *testing.T) {
+	templateStr := liquidTemplateUnknownProperty
+
+	engine, _ := GetTemplateEngineByKey(LiquidTemplateEngineKey)
+
+	if _, err := engine.Execute(&templateStr, getModel()); err != nil {
+		t.Fatalf("unknown property should be rendered empty: %v", err)
+	}
+
+	if err := engine.Test(&templateStr, getModel()); err == nil {
+		t.Fatalf("unknown property should fail in test (strict variables)")
+	}
+}
+
+func TestLiquidTemplateAssignDoesNotChangeModel(t *testing.T) {
+	templateStr := `{% assign name = "Other" %}{{ name }}`
+	model := map[string]any{"name": "Bruno"}
+
+	engine, _ := GetTemplateEngineByKey(LiquidTemplateEngineKey)
+
+	if _, err := engine.Execute(&templateStr, model); err != nil {
+		t.Fatalf("cant generate template %v", err)
+	}
+
+	if model["name"] != "Bruno" {
+		t.Fatal("assign should not change the model")
+	}
+}
+
+func TestLiquidTemplateNonMapModel(t *testing.T) {
+	type person struct {
+		Name string
+	}
+
+	cases := []struct {
+		template string
+		model    any
+		expected string
+	}{
+		{`{% for item in model %}{{ item }},{% endfor %}`, []any{"a", "b"}, "a,b,"},
+		{`{{ model }}`, 42.5, "42.5"},
+		{`{{ model }}`, "text", "text"},
+		{`{{ model.Name }}`, person{Name: "Bruno"}, "Bruno"},
+	}
+
+	engine, _ := GetTemplateEngineByKey(LiquidTemplateEngineKey)
+
+	for _, c := range cases {
+		templateStr := c.template
+
+		html, err := engine.Execute(&templateStr, c.model)
+		if err != nil {
+			t.Fatalf("cant generate template %v", err)
+		}
+
+		if *html != c.expected {
+			t.Fatalf("expected %q for model %v, got %q", c.expected, c.model, *html)
+		}
+
+		if err := engine.Test(&templateStr, c.model); err != nil {
+			t.Fatalf("model %v should be bound as variable: %v", c.model, err)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package templateengines
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
+)
+
+// modelPathCollector collects the model paths referenced by a template.
+// A path is optional, if all references are conditions.
+type modelPathCollector struct {
+	optional map[string]bool
+}
+
+func newModelPathCollector() *modelPathCollector {
+	return &modelPathCollector{optional: map[string]bool{}}
+}
+
+func (c *modelPathCollector) add(path string, optional bool) {
+	if path == "" {
+		return
+	}
+
+	if o, found := c.optional[path]; found {
+		optional = optional && o
+	}
+
+	c.optional[path] = optional
+}
+
+func (c *modelPathCollector) paths() []models.ModelPath {
+	paths := make([]models.ModelPath, 0, len(c.optional))
+
+	for path, optional := range c.optional {
+		paths = append(paths, models.ModelPath{Path: path, Optional: optional})
+	}
+
+	slices.SortFunc(paths, func(a, b models.ModelPath) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	return paths
+}
+
+// joinModelPath appends the field names to the path; indexes (e.g. items.0) are written as list items (items[])
+func joinModelPath(path string, fields ...string) string {
+	for _, field := range fields {
+		if _, err := strconv.Atoi(field); err == nil {
+			path += "[]"
+		} else if path == "" {
+			path = field
+		} else {
+			path += "." + field
+		}
+	}
+
+	return path
+}
@@ -0,0 +1,110 @@
+package templateengines
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
+)
+
+var modelPathTemplates = map[string]string{
+	GoTemplateEngineKey: `
+		{{if .discount}}{{.discount.rate}}{{end}}
+		<h1>{{.customer.name}}</h1>
+		{{with .customer.address}}{{.street}}{{end}}
+		{{range $i, $item := .items}}{{$item.title}} {{formatCurrency .price $.currency}}{{end}}`,
+	HandlebarsTemplateEngineKey: `
+		{{#if discount}}{{discount.rate}}{{/if}}
+		<h1>{{customer.name}}</h1>
+		{{#with customer.address}}{{street}}{{/with}}
+		{{#each items as |item|}}{{item.title}} {{formatCurrency price @root.currency}}{{else}}{{@index}}{{/each}}`,
+	DjangoTemplateEngineKey: `
+		{% if model.discount %}{{ model.discount.rate }}{% endif %}
+		<h1>{{ model.customer.name|upper }}</h1>
+		{% if model.customer.address %}{{ model.customer.address.street }}{% endif %}
+		{# {{ model.comment }} #}
+		{% for item in model.items %}{{ item.title }} {{ func.formatCurrency(item.price, model.currency) }}{% endfor %}`,
+	LiquidTemplateEngineKey: `
+		{% if discount %}{{ discount.rate }}{% endif %}
+		<h1>{{ customer.name | upcase }}</h1>
+		{% unless customer.address %}-{% else %}{{ customer.address.street }}{% endunless %}
+		{% comment %}{{ comment }}{% endcomment %}
+		{%- for item in items -%}{{ item.title }} {{ item.price | formatCurrency: currency }}{%- endfor -%}`,
+}
+
+var expectedModelPaths = []models.ModelPath{
+	{Path: "currency"},
+	{Path: "customer.address", Optional: true},
+	{Path: "customer.address.street"},
+	{Path: "customer.name"},
+	{Path: "discount", Optional: true},
+	{Path: "discount.rate"},
+	{Path: "items"},
+	{Path: "items[]"},
+	{Path: "items[].price"},
+	{Path: "items[].title"},
+}
+
+func TestModelPaths(t *testing.T) {
+	for key, templateStr := range modelPathTemplates {
+		engine := getTemplateEngineByKey(t, key)
+
+		paths, err := engine.ModelPaths(&templateStr)
+		if err != nil {
+			t.Fatalf("%s: cant analyse template: %v", key, err)
+		}
+
+		if !reflect.DeepEqual(paths, expectedModelPaths) {
+			t.Fatalf("%s: paths should be\n%v\n(curr:\n%v)", key, expectedModelPaths, paths)
+		}
+	}
+}
+
+func TestModelPathsInvalidTemplate(t *testing.T) {
+	for key, templateStr := range map[string]string{
+		GoTemplateEngineKey:         goTemplateInvalid,
+		HandlebarsTemplateEngineKey: handlebarsTemplateInvalid,
+		DjangoTemplateEngineKey:     djangoTemplateInvalid,
+		LiquidTemplateEngineKey:     liquidTemplateInvalid,
+	} {
+		engine := getTemplateEngineByKey(t, key)
+
+		if _, err := engine.ModelPaths(&templateStr); err == nil {
+			t.Fatalf("%s: invalid template should fail", key)
+		}
+	}
+}
+
+func TestDjangoModelPathsVariables(t *testing.T) {
+	templateStr := `{% with c=model.customer %}{{ c.name }}{% endwith %}{{ c.other }}{% set n = model.items.0 %}{{ n.title }}`
+
+	paths, err := getTemplateEngineByKey(t, DjangoTemplateEngineKey).ModelPaths(&templateStr)
+	if err != nil {
+		t.Fatalf("cant analyse template: %v", err)
+	}
+
+	expected := []models.ModelPath{{Path: "customer"}, {Path: "customer.name"}, {Path: "items[]"}, {Path: "items[].title"}}
+	if !reflect.DeepEqual(paths, expected) {
+		t.Fatalf("paths should be %v (curr: %v)", expected, paths)
+	}
+}
+
+func TestLiquidModelPathsVariables(t *testing.T) {
+	templateStr := `{% assign c = customer %}{{ c.name }}{% capture text %}{{ items.first.title }}{% endcapture %}{{ text }}{{ items.size }}{{ forloop.index }}`
+
+	paths, err := getTemplateEngineByKey(t, LiquidTemplateEngineKey).ModelPaths(&templateStr)
+	if err != nil {
+		t.Fatalf("cant analyse template: %v", err)
+	}
+
+	expected := []models.ModelPath{{Path: "customer"}, {Path: "customer.name"}, {Path: "items"}, {Path: "items[].title"}}
+	if !reflect.DeepEqual(paths, expected) {
+		t.Fatalf("paths should be %v (curr: %v)", expected, paths)
+	}
+}
+
+func TestJoinModelPath(t *testing.T) {
+	if path := joinModelPath("", "items", "0", "price"); path != "items[].price" {
+		t.Fatalf("index should be written as list item (curr: %s)", path)
+	}
+}
@@ -0,0 +1,162 @@
+package templateengines
+
+import (
+	"errors"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type testPartials map[string]string
+
+func (p testPartials) GetPartialNames() []string {
+	return slices.Sorted(maps.Keys(p))
+}
+
+func (p testPartials) GetPartial(name string) (io.ReadCloser, error) {
+	content, ok := p[name]
+	if !ok {
+		return nil, errors.New("no file found: " + name)
+	}
+
+	return io.NopCloser(strings.NewReader(content)), nil
+}
+
+func TestPartials(t *testing.T) {
+	cases := []struct {
+		engineKey string
+		partials  testPartials
+		template  string
+	}{
+		{
+			GoTemplateEngineKey,
+			testPartials{"blocks/company.html": `{{define "location"}}<li>{{.}}</li>{{end}}<p>{{.name}}</p>{{range .locations}}{{template "location" .}}{{end}}`},
+			`<h1>{{.name}}</h1>{{template "blocks/company" .company}}`,
+		},
+		{
+			HandlebarsTemplateEngineKey,
+			testPartials{"blocks/company.hbs": `<p>{{name}}</p>{{#each locations}}{{> location}}{{/each}}`, "location.hbs": `<li>{{this}}</li>`},
+			`<h1>{{name}}</h1>{{> blocks/company company}}`,
+		},
+		{
+			DjangoTemplateEngineKey,
+			testPartials{
+				"layout.html":         `<h1>{{ model.name }}</h1>{% block content %}{% endblock %}`,
+				"blocks/company.html": `<p>{{ model.company.name }}</p>{% for l in model.company.locations %}{% include "location.html" %}{% endfor %}`,
+				"location.html":       `<li>{{ l }}</li>`,
+			},
+			`{% extends "layout.html" %}{% block content %}{% include "blocks/company.html" %}{% endblock %}`,
+		},
+		{
+			LiquidTemplateEngineKey,
+			testPartials{"blocks/company.liquid": `<p>{{ company.name }}</p>{% for l in company.locations %}{% include "location.liquid" %}{% endfor %}`, "location.liquid": `<li>{{ l }}</li>`},
+			`<h1>{{ name }}</h1>{% include "blocks/company" %}`,
+		},
+	}
+
+	expected := "<h1>Bruno</h1><p>Testcompany</p><li>Chemnitz</li><li>Berlin</li><li>Amsterdam</li>"
+
+	for _, c := range cases {
+		engine := getTemplateEngineByKey(t, c.engineKey)
+		engine.SetPartials(c.partials)
+
+		templateStr := c.template
+
+		html, err := engine.Execute(&templateStr, getModel())
+		if err != nil {
+			t.Fatalf("%s: cant execute template with partials: %v", c.engineKey, err)
+		}
+
+		if *html != expected {
+			t.Fatalf("%s: html should be '%s' (curr: '%s')", c.engineKey, expected, *html)
+		}
+	}
+}
+
+func TestMissingPartial(t *testing.T) {
+	for key, templateStr := range map[string]string{
+		GoTemplateEngineKey:         `{{template "missing" .}}`,
+		HandlebarsTemplateEngineKey: `{{> missing}}`,
+		DjangoTemplateEngineKey:     `{% include "missing.html" %}`,
+		LiquidTemplateEngineKey:     `{% include "missing" %}`,
+	} {
+		engine := getTemplateEngineByKey(t, key)
+		engine.SetPartials(testPartials{})
+
+		if _, err := engine.Execute(&templateStr, getModel()); err == nil {
+			t.Fatalf("%s: missing partial should fail", key)
+		}
+	}
+}
+
+func TestRecursivePartials(t *testing.T) {
+	cases := []struct {
+		engineKey string
+		partials  testPartials
+		template  string
+	}{
+		{HandlebarsTemplateEngineKey, testPartials{"self.hbs": `{{> self}}`}, `{{> self}}`},
+		{HandlebarsTemplateEngineKey, testPartials{"a.hbs": `a{{> b}}`, "b.hbs": `b{{> a}}`}, `{{> a}}`},
+		{DjangoTemplateEngineKey, testPartials{"self.html": `{% include "self.html" %}`}, `{% include "self.html" %}`},
+		{DjangoTemplateEngineKey, testPartials{"a.html": `a{% include "b.html" %}`, "b.html": `b{% include "a.html" %}`}, `{% include "a.html" %}`},
+		{DjangoTemplateEngineKey, testPartials{"self.html": `{% include model.name %}`, "Bruno": `{% include "self.html" %}`}, `{% include "self.html" %}`},
+		{LiquidTemplateEngineKey, testPartials{"self.liquid": `{% include "self" %}`}, `{% include "self" %}`},
+		{LiquidTemplateEngineKey, testPartials{"a.liquid": `a{% include "b" %}`, "b.liquid": `b{% include "a" %}`}, `{% include "a" %}`},
+	}
+
+	for _, c := range cases {
+		engine := getTemplateEngineByKey(t, c.engineKey)
+		engine.SetPartials(c.partials)
+
+		templateStr := c.template
+
+		if _, err := engine.Execute(&templateStr, getModel()); err == nil || !strings.Contains(err.Error(), "recursive") {
+			t.Fatalf("%s: recursive partial should fail (curr: %v)", c.engineKey, err)
+		}
+	}
+}
+
+func TestNestedPartials(t *testing.T) {
+	// raymond resolves missing fields from the parent contexts, so the leafs need empty children
+	model := map[string]any{"name": "root", "children": []any{
+		map[string]any{"name": "a", "children": []any{map[string]any{"name": "b", "children": []any{}}}},
+		map[string]any{"name": "c", "children": []any{}},
+	}}
+
+	cases := []struct {
+		engineKey string
+		partials  testPartials
+		template  string
+	}{
+		{
+			HandlebarsTemplateEngineKey,
+			testPartials{"node.hbs": `<li>{{name}}{{#if children}}<ul>{{#each children}}{{> node}}{{/each}}</ul>{{/if}}</li>`},
+			`<ul>{{> node root}}</ul>`,
+		},
+		{
+			LiquidTemplateEngineKey,
+			testPartials{"node.liquid": `<li>{{ node.name }}{% if node.children.size > 0 %}<ul>{% for node in node.children %}{% include "node" %}{% endfor %}</ul>{% endif %}</li>`},
+			`{% assign node = root %}<ul>{% include "node" %}</ul>`,
+		},
+	}
+
+	expected := "<ul><li>root<ul><li>a<ul><li>b</li></ul></li><li>c</li></ul></li></ul>"
+
+	for _, c := range cases {
+		engine := getTemplateEngineByKey(t, c.engineKey)
+		engine.SetPartials(c.partials)
+
+		templateStr := c.template
+
+		html, err := engine.Execute(&templateStr, map[string]any{"root": model})
+		if err != nil {
+			t.Fatalf("%s: cant execute template with nested partials: %v", c.engineKey, err)
+		}
+
+		if *html != expected {
+			t.Fatalf("%s: html should be '%s' (curr: '%s')", c.engineKey, expected, *html)
+		}
+	}
+}
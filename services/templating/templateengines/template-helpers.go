@@ -0,0 +1,310 @@
+package templateengines
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"math/big"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/services/barcodes"
+	"github.com/lucas-gaitzsch/pdf-turtle/services/charts"
+	"github.com/lucas-gaitzsch/pdf-turtle/services/decimals"
+	"github.com/lucas-gaitzsch/pdf-turtle/services/formatting"
+	"github.com/lucas-gaitzsch/pdf-turtle/services/markdown"
+)
+
+// templateHelper is the definition of a helper shared by all template engines
+type templateHelper struct {
+	name string
+	// fn is a func with typed parameters returning one value and optionally an error.
+	// The arguments of every engine are converted to the types of the parameters.
+	fn any
+}
+
+var float64Type = reflect.TypeOf(float64(0))
+
+var templateHelpers = []templateHelper{
+	{name: "marshal", fn: func(v any) (template.JS, error) {
+		a, err := json.Marshal(v)
+		return template.JS(a), err
+	}},
+	{name: "barcodeQr", fn: func(content string) (template.HTML, error) {
+		qr, err := barcodes.NewQrCode(content)
+		if err != nil {
+			return "", err
+		}
+		return template.HTML(qr.Svg()), nil
+	}},
+	{name: "barcodeEan", fn: func(content string) (template.HTML, error) {
+		ean, err := barcodes.NewEanCode(content)
+		if err != nil {
+			return "", err
+		}
+		return template.HTML(ean.Svg()), nil
+	}},
+	{name: "barcodeDataMatrix", fn: func(content string) (template.HTML, error) {
+		dm, err := barcodes.NewDataMatrixCode(content)
+		if err != nil {
+			return "", err
+		}
+		return template.HTML(dm.Svg()), nil
+	}},
+	// raw html in the markdown is omitted, because the markdown is usually part of the model
+	{name: "markdown", fn: func(md string) (template.HTML, error) {
+		html, err := markdown.ToHtml(md, false)
+		return template.HTML(html), err
+	}},
+	{name: "strContains", fn: strings.Contains},
+	{name: "strHasPrefix", fn: strings.HasPrefix},
+	{name: "strHasSuffix", fn: strings.HasSuffix},
+	{name: "add", fn: func(a float64, b float64) float64 {
+		return a + b
+	}},
+	{name: "subtract", fn: func(a float64, b float64) float64 {
+		return a - b
+	}},
+	{name: "multiply", fn: func(a float64, b float64) float64 {
+		return a * b
+	}},
+	{name: "divide", fn: func(a float64, b float64) float64 {
+		return a / b
+	}},
+	{name: "float64ToInt", fn: func(val float64) int {
+		return int(val)
+	}},
+	{name: "intToFloat64", fn: func(val int) float64 {
+		return float64(val)
+	}},
+	{name: "bitwiseAnd", fn: func(a int, b int) int {
+		return a & b
+	}},
+	{name: "decimalAdd", fn: decimalOperation((*big.Rat).Add)},
+	{name: "decimalSubtract", fn: decimalOperation((*big.Rat).Sub)},
+	{name: "decimalMultiply", fn: decimalOperation((*big.Rat).Mul)},
+	{name: "decimalDivide", fn: func(a any, b any, places int) (string, error) {
+		x, y, err := parseDecimals(a, b)
+		if err != nil {
+			return "", err
+		}
+		if y.Sign() == 0 {
+			return "", errors.New("division by zero")
+		}
+		return roundDecimal(new(big.Rat).Quo(x, y), places, decimals.RoundingHalfUp)
+	}},
+	{name: "decimalSum", fn: func(list any, field string) (string, error) {
+		sum, err := decimals.Sum(list, field)
+		if err != nil {
+			return "", err
+		}
+		return decimals.String(sum), nil
+	}},
+	{name: "decimalRound", fn: func(value any, places int, mode string) (string, error) {
+		r, err := decimals.Parse(value)
+		if err != nil {
+			return "", err
+		}
+		return roundDecimal(r, places, mode)
+	}},
+	{name: "decimalTax", fn: func(net any, ratePercent any, places int) (string, error) {
+		x, rate, err := parseDecimals(net, ratePercent)
+		if err != nil {
+			return "", err
+		}
+		tax := new(big.Rat).Mul(x, rate)
+		tax.Quo(tax, big.NewRat(100, 1))
+		return roundDecimal(tax, places, decimals.RoundingHalfUp)
+	}},
+	{name: "decimalCompare", fn: func(a any, b any) (int, error) {
+		x, y, err := parseDecimals(a, b)
+		if err != nil {
+			return 0, err
+		}
+		return x.Cmp(y), nil
+	}},
+}
+
+// decimalOperation creates a helper calculating exactly with two decimals
+func decimalOperation(op func(z *big.Rat, x *big.Rat, y *big.Rat) *big.Rat) func(a any, b any) (string, error) {
+	return func(a any, b any) (string, error) {
+		x, y, err := parseDecimals(a, b)
+		if err != nil {
+			return "", err
+		}
+		return decimals.String(op(new(big.Rat), x, y)), nil
+	}
+}
+
+func parseDecimals(a any, b any) (*big.Rat, *big.Rat, error) {
+	x, err := decimals.Parse(a)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	y, err := decimals.Parse(b)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return x, y, nil
+}
+
+// roundDecimal rounds and formats the decimal with exactly the count of decimal places
+func roundDecimal(r *big.Rat, places int, mode string) (string, error) {
+	rounded, err := decimals.Round(r, places, mode)
+	if err != nil {
+		return "", err
+	}
+
+	return rounded.FloatString(places), nil
+}
+
+// getTemplateHelpers returns all helpers with the formatting helpers for the locale
+func getTemplateHelpers(locale string) ([]templateHelper, error) {
+	formatter, err := formatting.NewFormatter(locale)
+	if err != nil {
+		return nil, err
+	}
+
+	helpers := append(slices.Clone(templateHelpers), getFormattingHelpers(formatter)...)
+
+	return append(helpers, getChartHelpers(formatter)...), nil
+}
+
+func getFormattingHelpers(f *formatting.Formatter) []templateHelper {
+	return []templateHelper{
+		{name: "formatDate", fn: func(date any, layout string, timezone string) (string, error) {
+			d, err := formatting.ParseDate(date)
+			if err != nil {
+				return "", err
+			}
+			return f.FormatDate(d, layout, timezone)
+		}},
+		{name: "formatNumber", fn: f.FormatNumber},
+		{name: "formatCurrency", fn: f.FormatCurrency},
+		{name: "formatPercent", fn: f.FormatPercent},
+		{name: "formatRelativeTime", fn: func(date any) (string, error) {
+			d, err := formatting.ParseDate(date)
+			if err != nil {
+				return "", err
+			}
+			return f.FormatRelativeTime(d), nil
+		}},
+	}
+}
+
+// getChartHelpers returns the chart helpers with the numbers formatted for the locale.
+// The options are an object of the model or a JSON string.
+func getChartHelpers(f *formatting.Formatter) []templateHelper {
+	chartHelper := func(name string, draw func(data any, options *charts.Options) (string, error)) templateHelper {
+		return templateHelper{name: name, fn: func(data any, options any) (template.HTML, error) {
+			opt, err := charts.ParseOptions(options)
+			if err != nil {
+				return "", err
+			}
+			opt.FormatNumber = f.FormatNumber
+
+			svg, err := draw(data, opt)
+			return template.HTML(svg), err
+		}}
+	}
+
+	return []templateHelper{
+		chartHelper("chartBar", charts.Bar),
+		chartHelper("chartStackedBar", charts.StackedBar),
+		chartHelper("chartLine", charts.Line),
+		chartHelper("chartPie", charts.Pie),
+		chartHelper("chartDonut", charts.Donut),
+		chartHelper("chartSparkline", charts.Sparkline),
+	}
+}
+
+// arity returns the count of the parameters of the helper
+func (h templateHelper) arity() int {
+	return reflect.TypeOf(h.fn).NumIn()
+}
+
+// call converts the arguments to the parameter types and calls the helper
+func (h templateHelper) call(args []any) (any, error) {
+	fn := reflect.ValueOf(h.fn)
+	fnType := fn.Type()
+
+	if len(args) != fnType.NumIn() {
+		return nil, fmt.Errorf("helper '%s' needs %d arguments (curr: %d)", h.name, fnType.NumIn(), len(args))
+	}
+
+	in := make([]reflect.Value, len(args))
+	for i, arg := range args {
+		v, err := convertHelperArg(arg, fnType.In(i))
+		if err != nil {
+			return nil, fmt.Errorf("argument %d of helper '%s': %w", i+1, h.name, err)
+		}
+		in[i] = v
+	}
+
+	out := fn.Call(in)
+
+	if len(out) == 2 && !out[1].IsNil() {
+		return nil, out[1].Interface().(error)
+	}
+
+	return out[0].Interface(), nil
+}
+
+// convertHelperArg converts numbers, numeric strings and booleans, so literals and model values
+// of all engines can be passed to the same helper
+func convertHelperArg(arg any, t reflect.Type) (reflect.Value, error) {
+	if arg == nil {
+		return reflect.Zero(t), nil
+	}
+
+	v := reflect.ValueOf(arg)
+	if v.Type().AssignableTo(t) {
+		return v, nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		if isNumberKind(v.Kind()) {
+			return reflect.ValueOf(formatHelperNumber(v.Convert(float64Type).Float())).Convert(t), nil
+		}
+		return reflect.ValueOf(fmt.Sprint(arg)).Convert(t), nil
+	case reflect.Bool:
+		if v.Kind() == reflect.String {
+			b, err := strconv.ParseBool(v.String())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			return reflect.ValueOf(b).Convert(t), nil
+		}
+	default:
+		if !isNumberKind(t.Kind()) {
+			break
+		}
+		if isNumberKind(v.Kind()) {
+			return v.Convert(t), nil
+		}
+		if v.Kind() == reflect.String {
+			f, err := strconv.ParseFloat(strings.TrimSpace(v.String()), 64)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			return reflect.ValueOf(f).Convert(t), nil
+		}
+	}
+
+	return reflect.Value{}, fmt.Errorf("cant convert %T to %s", arg, t)
+}
+
+// formatHelperNumber formats a number without trailing zeros like the golang and handlebars engines
+func formatHelperNumber(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func isNumberKind(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Float64
+}
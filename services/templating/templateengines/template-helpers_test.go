@@ -0,0 +1,375 @@
+package templateengines
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lucas-gaitzsch/pdf-turtle/services/barcodes"
+	"github.com/lucas-gaitzsch/pdf-turtle/services/charts"
+	"github.com/lucas-gaitzsch/pdf-turtle/services/formatting"
+)
+
+// modelPath is an argument read from the model instead of a literal
+type modelPath string
+
+type helperContractCase struct {
+	helper string
+	args   []any
+	// condition renders the helper as if block with the output yes or no
+	condition bool
+	// script renders the helper inside of a script element (escaping context of html/template)
+	script   bool
+	expected string
+}
+
+var helperModel = map[string]any{
+	"text":   "pdf turtle",
+	"ean":    "4006381333931",
+	"a":      6.0,
+	"b":      4.0,
+	"count":  7.0,
+	"list":   []any{1.0, "two"},
+	"date":   "2026-10-16T10:00:00Z",
+	"amount": 1234.567,
+	"items":  []any{map[string]any{"price": 0.1}, map[string]any{"price": "0.2"}},
+	"notes":  "**pdf** <i>turtle</i>",
+	"sales": []any{
+		map[string]any{"month": "Jan", "net": 1200.0, "tax": 228.0},
+		map[string]any{"month": "Feb", "net": 950.5, "tax": 180.6},
+	},
+	"chartOptions": map[string]any{"label": "month", "values": []any{"net", "tax"}, "valueLabels": true},
+}
+
+func getHelperContractCases(t *testing.T) []helperContractCase {
+	qr, err := barcodes.NewQrCode("pdf turtle")
+	if err != nil {
+		t.Fatalf("cant create qr code: %v", err)
+	}
+
+	ean, err := barcodes.NewEanCode("4006381333931")
+	if err != nil {
+		t.Fatalf("cant create ean code: %v", err)
+	}
+
+	dataMatrix, err := barcodes.NewDataMatrixCode("pdf turtle")
+	if err != nil {
+		t.Fatalf("cant create data matrix code: %v", err)
+	}
+
+	formatter, err := formatting.NewFormatter("")
+	if err != nil {
+		t.Fatalf("cant create formatter: %v", err)
+	}
+
+	chart := func(draw func(data any, options *charts.Options) (string, error)) string {
+		opt, err := charts.ParseOptions(helperModel["chartOptions"])
+		if err != nil {
+			t.Fatalf("cant parse chart options: %v", err)
+		}
+		opt.FormatNumber = formatter.FormatNumber
+
+		svg, err := draw(helperModel["sales"], opt)
+		if err != nil {
+			t.Fatalf("cant draw chart: %v", err)
+		}
+
+		return svg
+	}
+
+	return []helperContractCase{
+		{helper: "marshal", args: []any{modelPath("list")}, script: true, expected: `<script>var list = [1,"two"];</script>`},
+		{helper: "barcodeQr", args: []any{modelPath("text")}, expected: qr.Svg()},
+		{helper: "barcodeEan", args: []any{modelPath("ean")}, expected: ean.Svg()},
+		{helper: "barcodeDataMatrix", args: []any{modelPath("text")}, expected: dataMatrix.Svg()},
+		{helper: "markdown", args: []any{modelPath("notes")}, expected: "<p><strong>pdf</strong> <!-- raw HTML omitted -->turtle<!-- raw HTML omitted --></p>\n"},
+		{helper: "strContains", args: []any{modelPath("text"), "turtle"}, condition: true, expected: "yes"},
+		{helper: "strContains", args: []any{modelPath("text"), "rabbit"}, condition: true, expected: "no"},
+		{helper: "strHasPrefix", args: []any{modelPath("text"), "pdf"}, condition: true, expected: "yes"},
+		{helper: "strHasSuffix", args: []any{modelPath("text"), "pdf"}, condition: true, expected: "no"},
+		{helper: "add", args: []any{modelPath("a"), 2}, expected: "8"},
+		{helper: "subtract", args: []any{modelPath("a"), modelPath("b")}, expected: "2"},
+		{helper: "multiply", args: []any{modelPath("a"), 1.5}, expected: "9"},
+		{helper: "divide", args: []any{modelPath("a"), modelPath("b")}, expected: "1.5"},
+		{helper: "float64ToInt", args: []any{modelPath("count")}, expected: "7"},
+		{helper: "intToFloat64", args: []any{3}, expected: "3"},
+		{helper: "bitwiseAnd", args: []any{modelPath("count"), 5}, expected: "5"},
+		{helper: "decimalAdd", args: []any{0.1, "0.2"}, expected: "0.3"},
+		{helper: "decimalSubtract", args: []any{"0.3", 0.1}, expected: "0.2"},
+		{helper: "decimalMultiply", args: []any{"1.1", "1.1"}, expected: "1.21"},
+		{helper: "decimalDivide", args: []any{"10", "3", 2}, expected: "3.33"},
+		{helper: "decimalSum", args: []any{modelPath("items"), "price"}, expected: "0.3"},
+		{helper: "decimalRound", args: []any{"2.345", 2, "half-even"}, expected: "2.34"},
+		{helper: "decimalRound", args: []any{"2.345", 2, "half-up"}, expected: "2.35"},
+		{helper: "decimalTax", args: []any{"100.05", "19", 2}, expected: "19.01"},
+		{helper: "decimalCompare", args: []any{"0.30", modelPath("b")}, expected: "-1"},
+		{helper: "formatDate", args: []any{modelPath("date"), "Monday, 2 January 2006 15:04", "Europe/Berlin"}, expected: "Friday, 16 October 2026 12:00"},
+		{helper: "formatNumber", args: []any{modelPath("amount"), 2}, expected: "1,234.57"},
+		{helper: "formatCurrency", args: []any{modelPath("amount"), "EUR"}, expected: "€1,234.57"},
+		{helper: "formatPercent", args: []any{0.19, 0}, expected: "19%"},
+		{helper: "chartBar", args: []any{modelPath("sales"), modelPath("chartOptions")}, expected: chart(charts.Bar)},
+		{helper: "chartStackedBar", args: []any{modelPath("sales"), modelPath("chartOptions")}, expected: chart(charts.StackedBar)},
+		{helper: "chartLine", args: []any{modelPath("sales"), modelPath("chartOptions")}, expected: chart(charts.Line)},
+		{helper: "chartPie", args: []any{modelPath("sales"), modelPath("chartOptions")}, expected: chart(charts.Pie)},
+		{helper: "chartDonut", args: []any{modelPath("sales"), modelPath("chartOptions")}, expected: chart(charts.Donut)},
+		{helper: "chartSparkline", args: []any{modelPath("sales"), modelPath("chartOptions")}, expected: chart(charts.Sparkline)},
+		{helper: "formatRelativeTime", args: []any{"2000-01-01"}, expected: fmt.Sprintf("%d years ago", int(time.Since(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)).Hours()/24/365))},
+	}
+}
+
+type helperSyntax struct {
+	engineKey string
+	path      func(p modelPath) string
+	call      func(helper string, args []string) string
+	condition func(call string) string
+	// print of a helper call outside of conditions
+	print func(call string) string
+}
+
+var helperSyntaxes = []helperSyntax{
+	{
+		engineKey: GoTemplateEngineKey,
+		path:      func(p modelPath) string { return "." + string(p) },
+		call:      func(helper string, args []string) string { return helper + " " + strings.Join(args, " ") },
+		condition: func(call string) string { return "{{if " + call + "}}yes{{else}}no{{end}}" },
+		print:     func(call string) string { return "{{" + call + "}}" },
+	},
+	{
+		engineKey: HandlebarsTemplateEngineKey,
+		path:      func(p modelPath) string { return string(p) },
+		call:      func(helper string, args []string) string { return helper + " " + strings.Join(args, " ") },
+		condition: func(call string) string { return "{{#if (" + call + ")}}yes{{else}}no{{/if}}" },
+		print:     func(call string) string { return "{{" + call + "}}" },
+	},
+	{
+		engineKey: DjangoTemplateEngineKey,
+		path:      func(p modelPath) string { return "model." + string(p) },
+		call: func(helper string, args []string) string {
+			return "func." + helper + "(" + strings.Join(args, ", ") + ")"
+		},
+		condition: func(call string) string { return "{% if " + call + " %}yes{% else %}no{% endif %}" },
+		print:     func(call string) string { return "{{ " + call + " }}" },
+	},
+	{
+		engineKey: LiquidTemplateEngineKey,
+		path:      func(p modelPath) string { return string(p) },
+		call: func(helper string, args []string) string {
+			if len(args) == 1 {
+				return args[0] + " | " + helper
+			}
+			return args[0] + " | " + helper + ": " + strings.Join(args[1:], ", ")
+		},
+		condition: func(call string) string {
+			return "{% assign result = " + call + " %}{% if result %}yes{% else %}no{% endif %}"
+		},
+		print: func(call string) string { return "{{ " + call + " }}" },
+	},
+}
+
+// djangoFilterSyntax calls the helpers with one or two parameters as filter: {{ model.a|multiply:2 }}
+var djangoFilterSyntax = helperSyntax{
+	engineKey: DjangoTemplateEngineKey,
+	path:      func(p modelPath) string { return "model." + string(p) },
+	call: func(helper string, args []string) string {
+		if len(args) == 1 {
+			return args[0] + "|" + helper
+		}
+		return args[0] + "|" + helper + ":" + args[1]
+	},
+	condition: func(call string) string { return "{% if " + call + " %}yes{% else %}no{% endif %}" },
+	print:     func(call string) string { return "{{ " + call + " }}" },
+}
+
+func (s helperSyntax) template(c helperContractCase) string {
+	args := make([]string, len(c.args))
+
+	for i, arg := range c.args {
+		switch a := arg.(type) {
+		case modelPath:
+			args[i] = s.path(a)
+		case string:
+			args[i] = fmt.Sprintf("%q", a)
+		default:
+			args[i] = fmt.Sprint(a)
+		}
+	}
+
+	call := s.call(c.helper, args)
+
+	if c.condition {
+		return s.condition(call)
+	}
+
+	if c.script {
+		return "<script>var list = " + s.print(call) + ";</script>"
+	}
+
+	return s.print(call)
+}
+
+func TestTemplateHelpersContract(t *testing.T) {
+	cases := getHelperContractCases(t)
+
+	helpers, err := getTemplateHelpers("")
+	if err != nil {
+		t.Fatalf("cant get helpers: %v", err)
+	}
+
+	for _, h := range helpers {
+		found := false
+		for _, c := range cases {
+			found = found || c.helper == h.name
+		}
+
+		if !found {
+			t.Fatalf("helper '%s' has no contract test case", h.name)
+		}
+	}
+
+	for _, syntax := range helperSyntaxes {
+		engine := getTemplateEngineByKey(t, syntax.engineKey)
+
+		for _, c := range cases {
+			templateStr := syntax.template(c)
+
+			html, err := engine.Execute(&templateStr, helperModel)
+			if err != nil {
+				t.Fatalf("%s: cant execute '%s': %v", syntax.engineKey, templateStr, err)
+			}
+
+			if *html != c.expected {
+				t.Fatalf("%s: '%s' should render '%s' (curr: '%s')", syntax.engineKey, templateStr, c.expected, *html)
+			}
+
+			if err := engine.Test(&templateStr, helperModel); err != nil {
+				t.Fatalf("%s: test of '%s' should pass: %v", syntax.engineKey, templateStr, err)
+			}
+		}
+	}
+}
+
+func TestTemplateHelpersWrongArgumentCount(t *testing.T) {
+	for _, syntax := range helperSyntaxes {
+		engine := getTemplateEngineByKey(t, syntax.engineKey)
+
+		templateStr := syntax.template(helperContractCase{helper: "add", args: []any{1}})
+
+		if _, err := engine.Execute(&templateStr, helperModel); err == nil {
+			t.Fatalf("%s: '%s' should fail", syntax.engineKey, templateStr)
+		}
+	}
+}
+
+func TestTemplateHelpersError(t *testing.T) {
+	for _, syntax := range helperSyntaxes {
+		engine := getTemplateEngineByKey(t, syntax.engineKey)
+
+		templateStr := syntax.template(helperContractCase{helper: "barcodeEan", args: []any{"no ean"}})
+
+		if _, err := engine.Execute(&templateStr, helperModel); err == nil {
+			t.Fatalf("%s: '%s' should fail", syntax.engineKey, templateStr)
+		}
+	}
+}
+
+func TestTemplateHelpersLocale(t *testing.T) {
+	for _, syntax := range helperSyntaxes {
+		engine := getTemplateEngineByKey(t, syntax.engineKey)
+		engine.SetLocale("de-DE")
+
+		templateStr := syntax.template(helperContractCase{helper: "formatCurrency", args: []any{modelPath("amount"), "EUR"}})
+
+		html, err := engine.Execute(&templateStr, helperModel)
+		if err != nil {
+			t.Fatalf("%s: cant execute '%s': %v", syntax.engineKey, templateStr, err)
+		}
+
+		if *html != "1.234,57\u00a0€" {
+			t.Fatalf("%s: amount should be formatted for de-DE (curr: '%s')", syntax.engineKey, *html)
+		}
+
+		engine.SetLocale("not a locale")
+
+		if _, err := engine.Execute(&templateStr, helperModel); err == nil {
+			t.Fatalf("%s: invalid locale should fail", syntax.engineKey)
+		}
+	}
+}
+
+func TestDjangoHelperFiltersContract(t *testing.T) {
+	engine := getTemplateEngineByKey(t, DjangoTemplateEngineKey)
+
+	for _, c := range getHelperContractCases(t) {
+		i := slices.IndexFunc(templateHelpers, func(h templateHelper) bool { return h.name == c.helper })
+		if i < 0 || templateHelpers[i].arity() < 1 || templateHelpers[i].arity() > 2 {
+			continue
+		}
+
+		templateStr := djangoFilterSyntax.template(c)
+
+		html, err := engine.Execute(&templateStr, helperModel)
+
+		if slices.Contains(djangoBuiltinFilters, c.helper) {
+			if err == nil && *html == c.expected {
+				t.Fatalf("'%s' should use the builtin filter and not the helper", templateStr)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("cant execute '%s': %v", templateStr, err)
+		}
+
+		if *html != c.expected {
+			t.Fatalf("'%s' should render '%s' (curr: '%s'); add builtin filters with the same name to djangoBuiltinFilters", templateStr, c.expected, *html)
+		}
+	}
+}
+
+func TestDjangoBuiltinFilterAdd(t *testing.T) {
+	engine := getTemplateEngineByKey(t, DjangoTemplateEngineKey)
+
+	templateStr := `{{ model.text|add:"!" }} {{ func.add(model.a, 2) }}`
+
+	html, err := engine.Execute(&templateStr, helperModel)
+	if err != nil {
+		t.Fatalf("cant execute template: %v", err)
+	}
+
+	if *html != "pdf turtle! 8" {
+		t.Fatalf("builtin filter add should concatenate strings (curr: '%s')", *html)
+	}
+}
+
+func TestDjangoHelperFilterChainedIntoAdd(t *testing.T) {
+	engine := getTemplateEngineByKey(t, DjangoTemplateEngineKey)
+
+	// the builtin add prints fractions with the float format of pongo2
+	templateStr := `{{ model.a|multiply:2|add:1 }} {{ model.a|divide:4|add:1 }} {{ model.a|divide:4|multiply:2 }} {% if func.multiply(model.b, 2) > 10 %}no{% else %}yes{% endif %}`
+
+	html, err := engine.Execute(&templateStr, helperModel)
+	if err != nil {
+		t.Fatalf("cant execute template: %v", err)
+	}
+
+	if *html != "13 2.500000 3 yes" {
+		t.Fatalf("helper results should be numbers for the builtin add filter (curr: '%s')", *html)
+	}
+}
+
+func TestDjangoHelperFilters(t *testing.T) {
+	engine := getTemplateEngineByKey(t, DjangoTemplateEngineKey)
+
+	templateStr := `{{ model.a|multiply:model.b }} {{ model.count|float64ToInt }} {% if model.text|strHasPrefix:"pdf" %}yes{% endif %}`
+
+	html, err := engine.Execute(&templateStr, helperModel)
+	if err != nil {
+		t.Fatalf("cant execute template: %v", err)
+	}
+
+	if *html != "24 7 yes" {
+		t.Fatalf("filters should render '24 7 yes' (curr: '%s')", *html)
+	}
+}
@@ -1,15 +1,84 @@
 package templateengines
 
 import (
+	"fmt"
+	"io"
+	"path"
 	"reflect"
 	"strings"
 
+	"github.com/lucas-gaitzsch/pdf-turtle/models"
 	"github.com/rs/zerolog/log"
 )
 
 type TemplateEngine interface {
 	Execute(templateHtml *string, model any) (*string, error)
 	Test(templateHtml *string, model any) error
+	// Diagnose tests the template and returns the issues with position and source excerpt
+	Diagnose(templateHtml *string, model any) []models.TemplateDiagnostic
+	// ModelPaths statically analyses the template and returns the referenced model paths
+	ModelPaths(templateHtml *string) ([]models.ModelPath, error)
+	// SetLocale selects the locale of the formatting helpers (e.g. de-DE)
+	SetLocale(locale string)
+	// SetPartials provides shared templates which can be included by the templates
+	SetPartials(partials models.PartialsReader)
+}
+
+// TextTemplateEngine is implemented by the template engines escaping the template itself as html (e.g. html/template),
+// to execute templates of other file types like the xml of an e-invoice
+type TextTemplateEngine interface {
+	ExecuteText(templateText *string, model any) (*string, error)
+}
+
+// localizedHelpers is embedded by all template engines to provide the helpers for the selected locale
+type localizedHelpers struct {
+	locale string
+}
+
+func (lh *localizedHelpers) SetLocale(locale string) {
+	lh.locale = locale
+}
+
+func (lh *localizedHelpers) getHelpers() ([]templateHelper, error) {
+	return getTemplateHelpers(lh.locale)
+}
+
+// maxPartialDepth limits the nesting of partials, so a recursive partial fails instead of overflowing the stack
+const maxPartialDepth = 100
+
+// templatePartials is embedded by all template engines to provide the shared templates
+type templatePartials struct {
+	partials models.PartialsReader
+}
+
+func (tp *templatePartials) SetPartials(partials models.PartialsReader) {
+	tp.partials = partials
+}
+
+// readPartials returns the content of all partials by their name without extension (e.g. address for address.html)
+func (tp *templatePartials) readPartials() (map[string]string, error) {
+	partials := map[string]string{}
+
+	if tp.partials == nil {
+		return partials, nil
+	}
+
+	for _, name := range tp.partials.GetPartialNames() {
+		r, err := tp.partials.GetPartial(name)
+		if err != nil {
+			return nil, err
+		}
+
+		content, err := io.ReadAll(r)
+		r.Close()
+		if err != nil {
+			return nil, fmt.Errorf("cant read partial %s: %w", name, err)
+		}
+
+		partials[strings.TrimSuffix(name, path.Ext(name))] = string(content)
+	}
+
+	return partials, nil
 }
 
 func GetTemplateEngineByKey(key string) (TemplateEngine, bool) {
@@ -20,6 +89,8 @@ func GetTemplateEngineByKey(key string) (TemplateEngine, bool) {
 		templateEngine = &HandlebarsTemplateEngine{}
 	case strings.ToLower(DjangoTemplateEngineKey):
 		templateEngine = &DjangoTemplateEngine{}
+	case strings.ToLower(LiquidTemplateEngineKey):
+		templateEngine = &LiquidTemplateEngine{}
 	case strings.ToLower(GoTemplateEngineKey):
 		templateEngine = &GoTemplateEngine{}
 	default:
@@ -59,6 +59,14 @@ func TestGetTemplateEngineByKeyDjango(t *testing.T) {
 	}
 }
 
+func TestGetTemplateEngineByKeyLiquid(t *testing.T) {
+	engine := getTemplateEngineByKey(t, LiquidTemplateEngineKey)
+
+	if reflect.TypeOf(engine).Elem().Name() != reflect.TypeOf(LiquidTemplateEngine{}).Name() {
+		fatalWrongTemplateEngine(t)
+	}
+}
+
 func TestGetTemplateEngineByKeyEmpty(t *testing.T) {
 	engine, found := GetTemplateEngineByKey("")
 
@@ -2,5 +2,5 @@ package embed
 
 import "embed"
 
-//go:embed *.css
+//go:embed *.css *.icc markdown-themes/*.css
 var BuiltinFS embed.FS
@@ -3,3 +3,7 @@ package utils
 func MmToInches(mm int) float64 {
 	return float64(mm) / 25.4
 }
+
+func MmToPoints(mm int) float64 {
+	return MmToInches(mm) * 72
+}
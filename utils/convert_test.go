@@ -26,3 +26,24 @@ func TestMmToInches(t *testing.T) {
 		})
 	}
 }
+
+var mmToPointsTestData = []struct {
+	mm     int
+	points float64
+}{
+	{0, 0.0},
+	{1, 2.83465},
+	{210, 595.27559},
+}
+
+func TestMmToPoints(t *testing.T) {
+	for _, d := range mmToPointsTestData {
+		t.Run(fmt.Sprintf("convert %d mm to points", d.mm), func(t *testing.T) {
+			points := MmToPoints(d.mm)
+
+			if math.Abs(points-d.points) > 0.0001 {
+				t.Fatalf("%d mm should be %v points (current: %v)", d.mm, d.points, points)
+			}
+		})
+	}
+}
@@ -19,6 +19,10 @@ func LogExecutionTime(msg string, ctx context.Context, f func()) {
 
 	duration := time.Since(start)
 
+	if r := GetReport(ctx); r != nil {
+		r.addStage(msg, duration)
+	}
+
 	var logger *zerolog.Logger
 	if ctx == nil {
 		logger = &log.Logger
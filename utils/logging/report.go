@@ -0,0 +1,76 @@
+package logging
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const contextKeyReport = ContextKey("contextKeyReport")
+
+// Report collects the execution times and warnings of a request to return them with the result
+type Report struct {
+	start    time.Time
+	mutex    sync.Mutex
+	stages   []ReportStage
+	warnings []string
+}
+
+type ReportStage struct {
+	Name     string
+	Duration time.Duration
+}
+
+func ContextWithReport(ctx context.Context) context.Context {
+	return context.WithValue(ctx, contextKeyReport, &Report{start: time.Now()})
+}
+
+// GetReport returns the report of the context or nil
+func GetReport(ctx context.Context) *Report {
+	if ctx == nil {
+		return nil
+	}
+
+	r, _ := ctx.Value(contextKeyReport).(*Report)
+	return r
+}
+
+// Warn logs the warning and adds it to the report of the context
+func Warn(ctx context.Context, msg string) {
+	log.Ctx(ctx).Warn().CallerSkipFrame(1).Msg(msg)
+
+	if r := GetReport(ctx); r != nil {
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+
+		r.warnings = append(r.warnings, msg)
+	}
+}
+
+func (r *Report) addStage(name string, duration time.Duration) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.stages = append(r.stages, ReportStage{Name: name, Duration: duration})
+}
+
+// Duration returns the time since the report was created
+func (r *Report) Duration() time.Duration {
+	return time.Since(r.start)
+}
+
+func (r *Report) Stages() []ReportStage {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return append([]ReportStage{}, r.stages...)
+}
+
+func (r *Report) Warnings() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return append([]string{}, r.warnings...)
+}